@@ -1,18 +1,21 @@
 package main
 
 import (
+	"context"
+
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"secure-payment-service/internal/auth"
 	"secure-payment-service/internal/config"
 	"secure-payment-service/internal/controller"
 	"secure-payment-service/internal/logging"
 	"secure-payment-service/internal/middleware"
-	"secure-payment-service/internal/models"
 	"secure-payment-service/internal/repository"
 	"secure-payment-service/internal/routes"
+	"secure-payment-service/internal/scheduler"
 	"secure-payment-service/internal/service"
 )
 
@@ -33,21 +36,49 @@ func main() {
 		logging.Logger.Fatalf("Failed to connect to database: %v", err)
 	}
 
-	err = db.AutoMigrate(&models.Transfer{})
-	if err != nil {
+	if err := repository.Migrate(db); err != nil {
 		logging.Logger.Fatalf("Failed to auto migrate database: %v", err)
 	}
+	if err := repository.EnsureSettlementAccounts(db, cfg.SettlementAccounts...); err != nil {
+		logging.Logger.Fatalf("Failed to open settlement accounts: %v", err)
+	}
 	logging.Logger.Info("Database connection established and migrations run successfully.")
 
-	repo := repository.NewGormRepository(db)
-	svc := service.NewTransferService(repo)
+	repo := repository.NewGormRepository(db, cfg.SettlementAccounts...)
+	jobRepo := repository.NewGormJobRepository(db)
+	svc := service.NewTransferService(repo, jobRepo)
 	ctrl := controller.NewTransferController(svc)
+	accountCtrl := controller.NewAccountController(service.NewAccountService(repository.NewGormAccountRepository(db)))
+	standingOrderSvc := service.NewStandingOrderService(repository.NewGormStandingOrderRepository(db), jobRepo, svc)
+	standingOrderCtrl := controller.NewStandingOrderController(standingOrderSvc)
+	batchSvc := service.NewBatchService(repository.NewGormBatchRepository(db), jobRepo, svc, cfg.Batches.Workers)
+	batchCtrl := controller.NewBatchController(batchSvc)
+
+	jobScheduler := scheduler.New(jobRepo, cfg.Scheduler)
+	jobScheduler.Register(service.MonitorTransferJob, service.NewTransferMonitor(svc))
+	jobScheduler.Register(service.ExecuteScheduledTransferJob, service.NewScheduledTransferExecutor(svc))
+	jobScheduler.Register(service.RunStandingOrderJob, service.NewStandingOrderExecutor(standingOrderSvc))
+	jobScheduler.Register(service.ProcessBatchJob, service.NewBatchExecutor(batchSvc))
+	jobScheduler.Start(context.Background())
 
 	router := gin.Default()
+	router.Use(middleware.Timeout(cfg.RequestTimeout))
 
-	jwtMiddleware := middleware.Auth()
+	verifier, err := auth.NewVerifier(cfg.Auth)
+	if err != nil {
+		logging.Logger.Fatalf("Failed to configure JWT verification: %v", err)
+	}
+	jwtMiddleware := middleware.Auth(verifier)
+	// No request outlives its timeout, so a key still in flight after twice
+	// that belongs to one that crashed. Bulk uploads are the largest bodies.
+	idempotencyMiddleware := middleware.Idempotency(repository.NewGormIdempotencyRepository(db), controller.MaxBatchBytes, 2*cfg.RequestTimeout)
+
+	if len(cfg.Webhooks.Secrets) == 0 {
+		logging.Logger.Warn("No WEBHOOK_SECRETS configured, all webhook calls will be rejected")
+	}
+	webhookMiddleware := middleware.WebhookSignature(cfg.Webhooks, repository.NewGormWebhookDeliveryRepository(db))
 
-	routes.SetupRoutes(router, jwtMiddleware, ctrl)
+	routes.SetupRoutes(router, jwtMiddleware, idempotencyMiddleware, webhookMiddleware, ctrl, accountCtrl, standingOrderCtrl, batchCtrl)
 
 	logging.Logger.WithField("address", cfg.Address).Info("Server running")
 	logging.Logger.Fatal(router.Run(cfg.Address))
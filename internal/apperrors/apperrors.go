@@ -0,0 +1,154 @@
+package apperrors
+
+import (
+	"errors"
+	"strings"
+)
+
+// Error categories. Callers branch on them with errors.Is; the HTTP layer maps
+// each one to a status code.
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrValidation        = errors.New("validation failed")
+	ErrConflict          = errors.New("conflict")
+	ErrInvalidTransition = errors.New("invalid status transition")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrPrecondition      = errors.New("precondition failed")
+)
+
+// Stable error codes returned to API clients. They are part of the API
+// contract, so existing values must never change meaning.
+const (
+	CodeTransferNotFound   = "transfer_not_found"
+	CodeAccountNotFound    = "account_not_found"
+	CodeInvalidCurrency    = "invalid_currency"
+	CodeInvalidStatus      = "invalid_status"
+	CodeInvalidTransition  = "invalid_transition"
+	CodeInsufficientFunds  = "insufficient_funds"
+	CodeValidationFailed   = "validation_failed"
+	CodeConflict           = "conflict"
+	CodeInvalidRequest     = "invalid_request"
+	CodeUnauthorized       = "unauthorized"
+	CodeForbidden          = "forbidden"
+	CodeTimeout            = "timeout"
+	CodeInternal           = "internal_error"
+	CodeIdempotencyReused  = "idempotency_key_reused"
+	CodeIdempotencyPending = "idempotency_request_in_progress"
+	CodeWebhookRejected    = "webhook_rejected"
+	CodeWebhookReplayed    = "webhook_replayed"
+	CodeNotAcceptable      = "not_acceptable"
+	CodeVersionMismatch    = "version_mismatch"
+	CodeAccountFrozen      = "account_frozen"
+	CodeAccountClosed      = "account_closed"
+	CodeAccountNotEmpty    = "account_not_empty"
+	CodeNotCancellable     = "transfer_not_cancellable"
+	CodeNotRefundable      = "transfer_not_refundable"
+	CodeRefundExceeds      = "refund_exceeds_amount"
+	CodeNotEditable        = "transfer_not_editable"
+	CodeOrderNotFound      = "standing_order_not_found"
+	CodeBatchNotFound      = "batch_not_found"
+	CodeGroupNotFound      = "transfer_group_not_found"
+	CodeUnsupportedMedia   = "unsupported_media_type"
+
+	// Field-level codes used in ValidationError.
+	CodeRequired       = "required"
+	CodeInvalidFormat  = "invalid_format"
+	CodeInvalidType    = "invalid_type"
+	CodeInvalidAmount  = "invalid_amount"
+	CodeMustBePositive = "must_be_positive"
+	CodeTooPrecise     = "too_many_decimals"
+	CodeSameAccount    = "same_account"
+	CodeMustBeFuture   = "must_be_in_future"
+	CodeTooFarAhead    = "too_far_ahead"
+	CodeTooManyRows    = "too_many_rows"
+	CodeTooManyLegs    = "too_many_legs"
+)
+
+// Coded is implemented by errors that carry a stable error code.
+type Coded interface {
+	ErrorCode() string
+}
+
+// Error is a domain error of one of the categories above, with a stable code
+// and an optional underlying cause.
+type Error struct {
+	kind    error
+	code    string
+	message string
+	cause   error
+}
+
+func New(kind error, code, message string) *Error {
+	return &Error{kind: kind, code: code, message: message}
+}
+
+// Wrap is New with a cause, which stays reachable through errors.Is and
+// errors.As.
+func Wrap(cause, kind error, code, message string) *Error {
+	return &Error{kind: kind, code: code, message: message, cause: cause}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) ErrorCode() string {
+	return e.code
+}
+
+func (e *Error) Unwrap() []error {
+	if e.cause == nil {
+		return []error{e.kind}
+	}
+	return []error{e.kind, e.cause}
+}
+
+// FieldError describes one invalid field of a request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError collects every invalid field of a request so clients can
+// fix them all at once.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Add(field, code, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Code: code, Message: message})
+}
+
+// Err returns e, or nil when no field was reported.
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+": "+field.Message)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+func (e *ValidationError) ErrorCode() string {
+	return CodeValidationFailed
+}
+
+// Code returns the stable code carried by err, or fallback when there is none.
+func Code(err error, fallback string) string {
+	var coded Coded
+	if errors.As(err, &coded) {
+		return coded.ErrorCode()
+	}
+	return fallback
+}
@@ -0,0 +1,273 @@
+package auth_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+
+	"secure-payment-service/internal/auth"
+	"secure-payment-service/internal/config"
+)
+
+var hmacSecret = []byte("test-secret")
+
+func validClaims() jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Subject:   "user-1",
+		Issuer:    "https://issuer.example",
+		Audience:  jwt.ClaimStrings{"payments"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.Claims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return signed
+}
+
+func publicKeyPEM(t *testing.T, key interface{}) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func newVerifier(t *testing.T, cfg config.AuthConfig) *auth.Verifier {
+	verifier, err := auth.NewVerifier(cfg)
+	assert.NoError(t, err)
+	return verifier
+}
+
+func TestVerifier_HS256(t *testing.T) {
+	verifier := newVerifier(t, config.AuthConfig{HMACSecret: hmacSecret})
+
+	claims, err := verifier.Verify(sign(t, jwt.SigningMethodHS256, hmacSecret, "", validClaims()))
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+
+	_, err = verifier.Verify(sign(t, jwt.SigningMethodHS256, []byte("other-secret"), "", validClaims()))
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+}
+
+func TestVerifier_RS256PublicKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	verifier := newVerifier(t, config.AuthConfig{PublicKeyPEM: publicKeyPEM(t, &key.PublicKey)})
+
+	_, err = verifier.Verify(sign(t, jwt.SigningMethodRS256, key, "", validClaims()))
+	assert.NoError(t, err)
+
+	// An HS256 token must not be verified with the public key as the secret.
+	_, err = verifier.Verify(sign(t, jwt.SigningMethodHS256, publicKeyPEM(t, &key.PublicKey), "", validClaims()))
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+}
+
+func TestVerifier_ES256PublicKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	verifier := newVerifier(t, config.AuthConfig{PublicKeyPEM: publicKeyPEM(t, &key.PublicKey)})
+
+	_, err = verifier.Verify(sign(t, jwt.SigningMethodES256, key, "", validClaims()))
+	assert.NoError(t, err)
+}
+
+func TestVerifier_RejectsUnsupportedAlgorithms(t *testing.T) {
+	verifier := newVerifier(t, config.AuthConfig{HMACSecret: hmacSecret})
+
+	_, err := verifier.Verify(sign(t, jwt.SigningMethodHS512, hmacSecret, "", validClaims()))
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+
+	_, err = verifier.Verify(sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", validClaims()))
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+}
+
+func TestVerifier_RegisteredClaims(t *testing.T) {
+	verifier := newVerifier(t, config.AuthConfig{
+		HMACSecret: hmacSecret,
+		Issuer:     "https://issuer.example",
+		Audience:   "payments",
+		ClockSkew:  time.Minute,
+	})
+
+	tests := []struct {
+		name   string
+		modify func(*jwt.RegisteredClaims)
+		valid  bool
+	}{
+		{"valid", func(*jwt.RegisteredClaims) {}, true},
+		{"wrong_issuer", func(c *jwt.RegisteredClaims) { c.Issuer = "https://evil.example" }, false},
+		{"wrong_audience", func(c *jwt.RegisteredClaims) { c.Audience = jwt.ClaimStrings{"other"} }, false},
+		{"missing_exp", func(c *jwt.RegisteredClaims) { c.ExpiresAt = nil }, false},
+		{"expired", func(c *jwt.RegisteredClaims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-2 * time.Minute)) }, false},
+		{"expired_within_skew", func(c *jwt.RegisteredClaims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-30 * time.Second)) }, true},
+		{"not_yet_valid", func(c *jwt.RegisteredClaims) { c.NotBefore = jwt.NewNumericDate(time.Now().Add(2 * time.Minute)) }, false},
+		{"not_before_within_skew", func(c *jwt.RegisteredClaims) { c.NotBefore = jwt.NewNumericDate(time.Now().Add(30 * time.Second)) }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			tt.modify(&claims)
+
+			_, err := verifier.Verify(sign(t, jwt.SigningMethodHS256, hmacSecret, "", claims))
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, auth.ErrInvalidToken)
+			}
+		})
+	}
+}
+
+type jwksServer struct {
+	mu       sync.Mutex
+	keys     map[string]*rsa.PublicKey
+	requests int
+}
+
+func (s *jwksServer) set(kid string, key *rsa.PublicKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = map[string]*rsa.PublicKey{kid: key}
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+
+	keys := []map[string]string{}
+	for kid, key := range s.keys {
+		keys = append(keys, map[string]string{
+			"kid": kid,
+			"kty": "RSA",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+}
+
+func TestVerifier_JWKS(t *testing.T) {
+	first, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	second, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	jwks := &jwksServer{}
+	jwks.set("key-1", &first.PublicKey)
+	server := httptest.NewServer(jwks)
+	defer server.Close()
+
+	verifier := newVerifier(t, config.AuthConfig{JWKSURL: server.URL, JWKSCacheTTL: 50 * time.Millisecond})
+
+	t.Run("verifies_by_kid", func(t *testing.T) {
+		_, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, first, "key-1", validClaims()))
+		assert.NoError(t, err)
+		_, err = verifier.Verify(sign(t, jwt.SigningMethodRS256, first, "key-1", validClaims()))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, jwks.requests)
+	})
+
+	t.Run("rejects_unknown_kid", func(t *testing.T) {
+		_, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, second, "key-2", validClaims()))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("picks_up_rotated_keys", func(t *testing.T) {
+		jwks.set("key-2", &second.PublicKey)
+		time.Sleep(60 * time.Millisecond)
+
+		_, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, second, "key-2", validClaims()))
+		assert.NoError(t, err)
+
+		_, err = verifier.Verify(sign(t, jwt.SigningMethodRS256, first, "key-1", validClaims()))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+}
+
+func TestVerifier_Principal(t *testing.T) {
+	verifier := newVerifier(t, config.AuthConfig{HMACSecret: hmacSecret})
+
+	claims, err := verifier.Verify(sign(t, jwt.SigningMethodHS256, hmacSecret, "", auth.Claims{
+		RegisteredClaims: validClaims(),
+		Scope:            "transfers:write balances:read",
+		Accounts:         []string{"acc-001"},
+	}))
+	assert.NoError(t, err)
+
+	principal := claims.Principal()
+	assert.Equal(t, "user-1", principal.Subject)
+	assert.True(t, principal.HasScope(auth.ScopeTransfersWrite))
+	assert.True(t, principal.HasScope(auth.ScopeBalancesRead))
+	assert.False(t, principal.HasScope(auth.ScopeTransfersRead))
+	assert.True(t, principal.CanAccessAccount("acc-001"))
+	assert.False(t, principal.CanAccessAccount("acc-002"))
+
+	ops := auth.Principal{Accounts: []string{auth.AllAccounts}}
+	assert.True(t, ops.CanAccessAccount("acc-002"))
+}
+
+func TestVerifier_JWKSServesCachedKeysDuringRefresh(t *testing.T) {
+	first, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	second, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	jwks := &jwksServer{}
+	jwks.set("key-1", &first.PublicKey)
+	arrived := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var hang atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hang.Load() {
+			arrived <- struct{}{}
+			<-release
+		}
+		jwks.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	verifier := newVerifier(t, config.AuthConfig{JWKSURL: server.URL, JWKSCacheTTL: 50 * time.Millisecond})
+	_, err = verifier.Verify(sign(t, jwt.SigningMethodRS256, first, "key-1", validClaims()))
+	assert.NoError(t, err)
+
+	hang.Store(true)
+	time.Sleep(60 * time.Millisecond)
+
+	rotated := make(chan error, 1)
+	go func() {
+		_, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, second, "key-2", validClaims()))
+		rotated <- err
+	}()
+	<-arrived
+
+	// The refresh is hanging on the identity provider, yet a token signed
+	// with a cached key verifies without waiting for it.
+	_, err = verifier.Verify(sign(t, jwt.SigningMethodRS256, first, "key-1", validClaims()))
+	assert.NoError(t, err)
+
+	jwks.set("key-2", &second.PublicKey)
+	close(release)
+	assert.NoError(t, <-rotated)
+}
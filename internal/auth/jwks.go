@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"secure-payment-service/internal/logging"
+)
+
+// jwksMinRefreshInterval limits how often an unknown kid can force a refetch,
+// so tokens with made-up key IDs cannot hammer the identity provider.
+const jwksMinRefreshInterval = 30 * time.Second
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// JWKS caches the signing keys published by an identity provider. Keys are
+// refetched once the cache TTL expires, or early when a token references a
+// kid we have not seen yet, which is how key rotation shows up.
+//
+// The key set is fetched without holding the lock, one fetch at a time.
+// Cached keys keep being served while it runs; only tokens with a kid that
+// is not cached wait for it, bounded by the client timeout.
+type JWKS struct {
+	url       string
+	ttl       time.Duration
+	client    *http.Client
+	mu        sync.Mutex
+	keys      map[string]interface{}
+	fetchedAt time.Time
+	// fetching is closed when the fetch in flight, if any, is over.
+	fetching chan struct{}
+	now      func() time.Time
+}
+
+func NewJWKS(url string, ttl time.Duration) *JWKS {
+	return &JWKS{
+		url:    url,
+		ttl:    ttl,
+		client: &http.Client{Timeout: 5 * time.Second},
+		keys:   map[string]interface{}{},
+		now:    time.Now,
+	}
+}
+
+func (j *JWKS) Key(kid string) (interface{}, error) {
+	j.mu.Lock()
+	now := j.now()
+	key, known := j.keys[kid]
+	expired := now.Sub(j.fetchedAt) > j.ttl
+	fetching := j.fetching
+	switch {
+	case known && (!expired || fetching != nil):
+		j.mu.Unlock()
+		return key, nil
+	case fetching == nil && !expired && now.Sub(j.fetchedAt) < jwksMinRefreshInterval:
+		j.mu.Unlock()
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	var err error
+	if fetching == nil {
+		fetching = make(chan struct{})
+		j.fetching = fetching
+		j.mu.Unlock()
+		err = j.refresh(now, fetching)
+	} else {
+		j.mu.Unlock()
+		<-fetching
+	}
+
+	j.mu.Lock()
+	key, known = j.keys[kid]
+	j.mu.Unlock()
+	switch {
+	case known && err != nil:
+		logging.Logger.WithError(err).Warn("failed to refresh JWKS, using cached key")
+		return key, nil
+	case known:
+		return key, nil
+	case err != nil:
+		return nil, err
+	default:
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+}
+
+// refresh fetches the key set and swaps it into the cache, then closes done.
+// A failed fetch leaves the cache as it was.
+func (j *JWKS) refresh(now time.Time, done chan struct{}) error {
+	keys, err := j.fetch()
+
+	j.mu.Lock()
+	if err == nil {
+		j.keys = keys
+		j.fetchedAt = now
+	}
+	j.fetching = nil
+	j.mu.Unlock()
+	close(done)
+
+	return err
+}
+
+func (j *JWKS) fetch() (map[string]interface{}, error) {
+	resp, err := j.client.Get(j.url)
+	if err != nil {
+		return nil, fmt.Errorf("fetching JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		return nil, fmt.Errorf("decoding JWKS: %w", err)
+	}
+
+	keys := map[string]interface{}{}
+	for _, jwk := range document.Keys {
+		if jwk.Kid == "" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			logging.Logger.WithError(err).WithField("kid", jwk.Kid).Warn("skipping unsupported JWKS key")
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBase64URLInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBase64URLInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBase64URLInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBase64URLInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBase64URLInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64url value: %w", err)
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	ScopeTransfersRead  = "transfers:read"
+	ScopeTransfersWrite = "transfers:write"
+	ScopeBalancesRead   = "balances:read"
+	ScopeAccountsRead   = "accounts:read"
+	ScopeAccountsWrite  = "accounts:write"
+	// ScopeAccountsAdmin allows freezing and unfreezing any account, for
+	// compliance teams.
+	ScopeAccountsAdmin = "accounts:admin"
+
+	// AllAccounts in the accounts claim grants access to every account, for
+	// back-office and operations clients.
+	AllAccounts = "*"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Subject  string
+	Scopes   []string
+	Accounts []string
+}
+
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+func (p Principal) CanAccessAccount(accountID string) bool {
+	return slices.Contains(p.Accounts, AllAccounts) || slices.Contains(p.Accounts, accountID)
+}
+
+func (c *Claims) Principal() Principal {
+	return Principal{
+		Subject:  c.Subject,
+		Scopes:   strings.Fields(c.Scope),
+		Accounts: c.Accounts,
+	}
+}
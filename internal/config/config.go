@@ -1,12 +1,60 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
-	DatabaseURL string
-	Address     string
+	DatabaseURL    string
+	Address        string
+	RequestTimeout time.Duration
+	// SettlementAccounts may send transfers without covering funds; they
+	// are how money enters and leaves the service.
+	SettlementAccounts []string
+	Scheduler          SchedulerConfig
+	Batches            BatchConfig
+	Webhooks           WebhookConfig
+	Auth               AuthConfig
+}
+
+type SchedulerConfig struct {
+	Workers       int
+	PollInterval  time.Duration
+	Lease         time.Duration
+	MaxAttempts   int
+	BackoffBase   time.Duration
+	BackoffMax    time.Duration
+	BackoffJitter float64
+}
+
+// BatchConfig bounds how many transfers of a bulk upload are created at once.
+type BatchConfig struct {
+	Workers int
+}
+
+// WebhookConfig holds the shared secret of every provider allowed to call the
+// webhook endpoint, keyed by the provider name sent in the request.
+type WebhookConfig struct {
+	Secrets   map[string]string
+	Tolerance time.Duration
+	// MaxBodyBytes caps the body read before the signature is checked.
+	MaxBodyBytes int64
+}
+
+// AuthConfig describes how bearer tokens are verified. At least one of
+// HMACSecret, PublicKeyPEM or JWKSURL must be set.
+type AuthConfig struct {
+	HMACSecret   []byte
+	PublicKeyPEM []byte
+	JWKSURL      string
+	JWKSCacheTTL time.Duration
+	Issuer       string
+	Audience     string
+	ClockSkew    time.Duration
 }
 
 func Load() (Config, error) {
@@ -20,10 +68,236 @@ func Load() (Config, error) {
 		address = ":8080"
 	}
 
+	requestTimeout, err := durationEnv("REQUEST_TIMEOUT", 10*time.Second)
+	if err != nil {
+		return Config{}, err
+	}
+	if requestTimeout <= 0 {
+		return Config{}, fmt.Errorf("REQUEST_TIMEOUT must be positive")
+	}
+
+	var settlementAccounts []string
+	for _, id := range strings.Split(os.Getenv("SETTLEMENT_ACCOUNTS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			settlementAccounts = append(settlementAccounts, id)
+		}
+	}
+
+	scheduler, err := loadSchedulerConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
+	batchWorkers, err := intEnv("BATCH_WORKERS", 4)
+	if err != nil {
+		return Config{}, err
+	}
+	if batchWorkers < 1 {
+		return Config{}, fmt.Errorf("BATCH_WORKERS must be at least 1")
+	}
+
+	webhooks, err := loadWebhookConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
+	auth, err := loadAuthConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
 	cfg := Config{
-		DatabaseURL: databaseURL,
-		Address:     address,
+		DatabaseURL:        databaseURL,
+		Address:            address,
+		RequestTimeout:     requestTimeout,
+		SettlementAccounts: settlementAccounts,
+		Scheduler:          scheduler,
+		Batches:            BatchConfig{Workers: batchWorkers},
+		Webhooks:           webhooks,
+		Auth:               auth,
+	}
+
+	return cfg, nil
+}
+
+func loadSchedulerConfig() (SchedulerConfig, error) {
+	var cfg SchedulerConfig
+	var err error
+
+	if cfg.Workers, err = intEnv("SCHEDULER_WORKERS", 4); err != nil {
+		return cfg, err
+	}
+	if cfg.PollInterval, err = durationEnv("SCHEDULER_POLL_INTERVAL", time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.Lease, err = durationEnv("SCHEDULER_LEASE", 30*time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.MaxAttempts, err = intEnv("SCHEDULER_MAX_ATTEMPTS", 5); err != nil {
+		return cfg, err
+	}
+	if cfg.BackoffBase, err = durationEnv("SCHEDULER_BACKOFF_BASE", 5*time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.BackoffMax, err = durationEnv("SCHEDULER_BACKOFF_MAX", 5*time.Minute); err != nil {
+		return cfg, err
+	}
+	if cfg.BackoffJitter, err = floatEnv("SCHEDULER_BACKOFF_JITTER", 0.2); err != nil {
+		return cfg, err
+	}
+
+	if cfg.Workers < 1 {
+		return cfg, fmt.Errorf("SCHEDULER_WORKERS must be at least 1")
+	}
+	if cfg.PollInterval <= 0 {
+		return cfg, fmt.Errorf("SCHEDULER_POLL_INTERVAL must be positive")
+	}
+	if cfg.Lease <= 0 {
+		return cfg, fmt.Errorf("SCHEDULER_LEASE must be positive")
+	}
+	if cfg.MaxAttempts < 1 {
+		return cfg, fmt.Errorf("SCHEDULER_MAX_ATTEMPTS must be at least 1")
+	}
+	if cfg.BackoffBase < 0 {
+		return cfg, fmt.Errorf("SCHEDULER_BACKOFF_BASE must not be negative")
+	}
+	if cfg.BackoffMax < cfg.BackoffBase {
+		return cfg, fmt.Errorf("SCHEDULER_BACKOFF_MAX must not be less than SCHEDULER_BACKOFF_BASE")
+	}
+	if cfg.BackoffJitter < 0 || cfg.BackoffJitter > 1 {
+		return cfg, fmt.Errorf("SCHEDULER_BACKOFF_JITTER must be between 0 and 1")
 	}
 
 	return cfg, nil
 }
+
+// loadWebhookConfig reads WEBHOOK_SECRETS as a comma-separated list of
+// provider=secret pairs.
+func loadWebhookConfig() (WebhookConfig, error) {
+	cfg := WebhookConfig{Secrets: map[string]string{}}
+
+	for _, pair := range strings.Split(os.Getenv("WEBHOOK_SECRETS"), ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		provider, secret, ok := strings.Cut(pair, "=")
+		if !ok || provider == "" || secret == "" {
+			return cfg, fmt.Errorf("invalid WEBHOOK_SECRETS entry %q, expected provider=secret", pair)
+		}
+		cfg.Secrets[provider] = secret
+	}
+
+	var err error
+	if cfg.Tolerance, err = durationEnv("WEBHOOK_TOLERANCE", 5*time.Minute); err != nil {
+		return cfg, err
+	}
+	if cfg.Tolerance <= 0 {
+		return cfg, fmt.Errorf("WEBHOOK_TOLERANCE must be positive")
+	}
+
+	maxBodyBytes, err := intEnv("WEBHOOK_MAX_BODY_BYTES", 64<<10)
+	if err != nil {
+		return cfg, err
+	}
+	if maxBodyBytes < 1 {
+		return cfg, fmt.Errorf("WEBHOOK_MAX_BODY_BYTES must be at least 1")
+	}
+	cfg.MaxBodyBytes = int64(maxBodyBytes)
+
+	return cfg, nil
+}
+
+func loadAuthConfig() (AuthConfig, error) {
+	cfg := AuthConfig{
+		JWKSURL:  os.Getenv("JWT_JWKS_URL"),
+		Issuer:   os.Getenv("JWT_ISSUER"),
+		Audience: os.Getenv("JWT_AUDIENCE"),
+	}
+
+	secret, err := secretEnv("JWT_HS256_SECRET")
+	if err != nil {
+		return cfg, err
+	}
+	cfg.HMACSecret = []byte(secret)
+
+	if path := os.Getenv("JWT_PUBLIC_KEY_FILE"); path != "" {
+		if cfg.PublicKeyPEM, err = os.ReadFile(path); err != nil {
+			return cfg, fmt.Errorf("invalid JWT_PUBLIC_KEY_FILE: %w", err)
+		}
+	}
+
+	if cfg.JWKSCacheTTL, err = durationEnv("JWT_JWKS_CACHE_TTL", 10*time.Minute); err != nil {
+		return cfg, err
+	}
+	if cfg.ClockSkew, err = durationEnv("JWT_CLOCK_SKEW", 30*time.Second); err != nil {
+		return cfg, err
+	}
+
+	if len(cfg.HMACSecret) == 0 && len(cfg.PublicKeyPEM) == 0 && cfg.JWKSURL == "" {
+		return cfg, fmt.Errorf("no JWT verification key configured, set JWT_HS256_SECRET(_FILE), JWT_PUBLIC_KEY_FILE or JWT_JWKS_URL")
+	}
+	if cfg.ClockSkew < 0 {
+		return cfg, fmt.Errorf("JWT_CLOCK_SKEW must not be negative")
+	}
+
+	return cfg, nil
+}
+
+// secretEnv reads a secret from the variable itself or, when NAME_FILE is
+// set instead, from the file it points to.
+func secretEnv(name string) (string, error) {
+	if value := os.Getenv(name); value != "" {
+		return value, nil
+	}
+
+	path := os.Getenv(name + "_FILE")
+	if path == "" {
+		return "", nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s_FILE: %w", name, err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+func intEnv(name string, fallback int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return parsed, nil
+}
+
+func floatEnv(name string, fallback float64) (float64, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return parsed, nil
+}
+
+func durationEnv(name string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return parsed, nil
+}
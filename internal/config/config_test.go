@@ -0,0 +1,59 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"secure-payment-service/internal/config"
+)
+
+func TestLoad_Defaults(t *testing.T) {
+	t.Setenv("JWT_HS256_SECRET", "secret")
+
+	cfg, err := config.Load()
+
+	assert.NoError(t, err)
+	assert.Equal(t, config.SchedulerConfig{
+		Workers:       4,
+		PollInterval:  time.Second,
+		Lease:         30 * time.Second,
+		MaxAttempts:   5,
+		BackoffBase:   5 * time.Second,
+		BackoffMax:    5 * time.Minute,
+		BackoffJitter: 0.2,
+	}, cfg.Scheduler)
+}
+
+func TestLoad_RejectsInvalidScheduler(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected string
+	}{
+		{"no_workers", map[string]string{"SCHEDULER_WORKERS": "0"}, "SCHEDULER_WORKERS must be at least 1"},
+		{"zero_poll_interval", map[string]string{"SCHEDULER_POLL_INTERVAL": "0s"}, "SCHEDULER_POLL_INTERVAL must be positive"},
+		{"negative_poll_interval", map[string]string{"SCHEDULER_POLL_INTERVAL": "-1s"}, "SCHEDULER_POLL_INTERVAL must be positive"},
+		{"zero_lease", map[string]string{"SCHEDULER_LEASE": "0s"}, "SCHEDULER_LEASE must be positive"},
+		{"negative_lease", map[string]string{"SCHEDULER_LEASE": "-30s"}, "SCHEDULER_LEASE must be positive"},
+		{"no_attempts", map[string]string{"SCHEDULER_MAX_ATTEMPTS": "0"}, "SCHEDULER_MAX_ATTEMPTS must be at least 1"},
+		{"negative_backoff_base", map[string]string{"SCHEDULER_BACKOFF_BASE": "-5s"}, "SCHEDULER_BACKOFF_BASE must not be negative"},
+		{"base_above_max", map[string]string{"SCHEDULER_BACKOFF_BASE": "10m", "SCHEDULER_BACKOFF_MAX": "1m"}, "SCHEDULER_BACKOFF_MAX must not be less than SCHEDULER_BACKOFF_BASE"},
+		{"jitter_above_one", map[string]string{"SCHEDULER_BACKOFF_JITTER": "1.5"}, "SCHEDULER_BACKOFF_JITTER must be between 0 and 1"},
+		{"unparsable_duration", map[string]string{"SCHEDULER_LEASE": "soon"}, "invalid SCHEDULER_LEASE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JWT_HS256_SECRET", "secret")
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			_, err := config.Load()
+
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/auth"
+	"secure-payment-service/internal/middleware"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/problem"
+	"secure-payment-service/internal/service"
+	"secure-payment-service/internal/transfers"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountController struct {
+	accountService service.AccountService
+}
+
+func NewAccountController(svc service.AccountService) *AccountController {
+	return &AccountController{accountService: svc}
+}
+
+func (ctrl *AccountController) OpenAccount(c *gin.Context) {
+	var req transfers.OpenAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithBindingError(c, err)
+		return
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	if req.Owner == "" {
+		req.Owner = principal.Subject
+	}
+
+	if err := req.Validate(); err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	// Access is granted by the accounts claim, so a caller limited to some
+	// accounts may only open one of those, for themselves. Generated ids and
+	// other owners are left to callers with access to every account.
+	if !callerCanAccess(c, auth.AllAccounts) {
+		switch {
+		case req.AccountID == "":
+			problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "account_id is required unless the caller can access every account")
+			return
+		case !callerCanAccess(c, req.AccountID):
+			problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to open account "+req.AccountID)
+			return
+		case req.Owner != principal.Subject:
+			problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to open an account for another owner")
+			return
+		}
+	}
+
+	account, err := ctrl.accountService.OpenAccount(c.Request.Context(), req)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, account)
+}
+
+func (ctrl *AccountController) GetAccount(c *gin.Context) {
+	id := c.Param("id")
+
+	if !callerCanAccess(c, id) {
+		problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to read account "+id)
+		return
+	}
+
+	account, err := ctrl.accountService.GetAccount(c.Request.Context(), id)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+// FreezeAccount and UnfreezeAccount are compliance actions; the route requires
+// the accounts:admin scope instead of access to the account itself.
+func (ctrl *AccountController) FreezeAccount(c *gin.Context) {
+	ctrl.changeStatus(c, ctrl.accountService.FreezeAccount)
+}
+
+func (ctrl *AccountController) UnfreezeAccount(c *gin.Context) {
+	ctrl.changeStatus(c, ctrl.accountService.UnfreezeAccount)
+}
+
+func (ctrl *AccountController) CloseAccount(c *gin.Context) {
+	id := c.Param("id")
+
+	if !callerCanAccess(c, id) {
+		problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to close account "+id)
+		return
+	}
+
+	ctrl.changeStatus(c, ctrl.accountService.CloseAccount)
+}
+
+func (ctrl *AccountController) changeStatus(c *gin.Context, change func(ctx context.Context, id string) (models.Account, error)) {
+	account, err := change(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
@@ -0,0 +1,180 @@
+package controller_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/auth"
+	"secure-payment-service/internal/controller"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/middleware"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/transfers"
+)
+
+func setupAccountRouter(svc *controller.MockAccountService, principal auth.Principal) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	ctrl := controller.NewAccountController(svc)
+
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.PrincipalKey, principal)
+	})
+
+	r.POST("/accounts", ctrl.OpenAccount)
+	r.GET("/accounts/:id", ctrl.GetAccount)
+	r.POST("/accounts/:id/freeze", ctrl.FreezeAccount)
+	r.POST("/accounts/:id/unfreeze", ctrl.UnfreezeAccount)
+	r.POST("/accounts/:id/close", ctrl.CloseAccount)
+
+	return r
+}
+
+var accountOwner = auth.Principal{Subject: "user-1", Accounts: []string{fromAccount}}
+
+func TestOpenAccount_DefaultsOwnerToCaller(t *testing.T) {
+	svc := controller.NewMockAccountService(t)
+	router := setupAccountRouter(svc, accountOwner)
+
+	expected := transfers.OpenAccountRequest{AccountID: fromAccount, Owner: "user-1", Currency: currency}
+	svc.EXPECT().OpenAccount(mock.Anything, expected).
+		Return(models.Account{AccountID: fromAccount, Owner: "user-1", Currency: currency, Status: enums.AccountActive.String()}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewBufferString(`{"account_id":"acc-001","currency":"USD"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	var account models.Account
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &account))
+	assert.Equal(t, fromAccount, account.AccountID)
+	assert.Equal(t, enums.AccountActive.String(), account.Status)
+}
+
+func TestOpenAccount_ForbiddenAccountID(t *testing.T) {
+	svc := controller.NewMockAccountService(t)
+	router := setupAccountRouter(svc, accountOwner)
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewBufferString(`{"account_id":"acc-999"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	svc.AssertNotCalled(t, "OpenAccount", mock.Anything, mock.Anything)
+}
+
+func TestOpenAccount_LimitedCallerMustChooseOwnAccount(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"generated_id", `{"currency":"USD"}`},
+		{"other_owner", `{"account_id":"acc-001","owner":"user-2"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := controller.NewMockAccountService(t)
+			router := setupAccountRouter(svc, accountOwner)
+
+			req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusForbidden, resp.Code)
+			svc.AssertNotCalled(t, "OpenAccount", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOpenAccount_OperatorMayGenerateIDAndSetOwner(t *testing.T) {
+	svc := controller.NewMockAccountService(t)
+	router := setupAccountRouter(svc, auth.Principal{Subject: "backoffice", Accounts: []string{auth.AllAccounts}})
+
+	expected := transfers.OpenAccountRequest{Owner: "user-2", Currency: currency}
+	svc.EXPECT().OpenAccount(mock.Anything, expected).
+		Return(models.Account{AccountID: "acc-generated", Owner: "user-2", Currency: currency, Status: enums.AccountActive.String()}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewBufferString(`{"owner":"user-2","currency":"USD"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+}
+
+func TestOpenAccount_Duplicate(t *testing.T) {
+	svc := controller.NewMockAccountService(t)
+	router := setupAccountRouter(svc, accountOwner)
+
+	svc.EXPECT().OpenAccount(mock.Anything, mock.Anything).
+		Return(models.Account{}, apperrors.New(apperrors.ErrConflict, apperrors.CodeConflict, "account acc-001 already exists")).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewBufferString(`{"account_id":"acc-001"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+}
+
+func TestGetAccount_Forbidden(t *testing.T) {
+	svc := controller.NewMockAccountService(t)
+	router := setupAccountRouter(svc, accountOwner)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/accounts/"+toAccount, nil))
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
+
+func TestFreezeAccount_AnyAccount(t *testing.T) {
+	svc := controller.NewMockAccountService(t)
+	router := setupAccountRouter(svc, auth.Principal{Subject: "compliance"})
+
+	svc.EXPECT().FreezeAccount(mock.Anything, toAccount).
+		Return(models.Account{AccountID: toAccount, Status: enums.AccountFrozen.String()}, nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/accounts/"+toAccount+"/freeze", nil))
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"FROZEN"`)
+}
+
+func TestCloseAccount_NotEmpty(t *testing.T) {
+	svc := controller.NewMockAccountService(t)
+	router := setupAccountRouter(svc, accountOwner)
+
+	svc.EXPECT().CloseAccount(mock.Anything, fromAccount).
+		Return(models.Account{}, apperrors.New(apperrors.ErrConflict, apperrors.CodeAccountNotEmpty, "account still holds funds or has transfers in flight")).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/accounts/"+fromAccount+"/close", nil))
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	var responseBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &responseBody)
+	assert.Equal(t, apperrors.CodeAccountNotEmpty, responseBody["code"])
+}
+
+func TestCloseAccount_Forbidden(t *testing.T) {
+	svc := controller.NewMockAccountService(t)
+	router := setupAccountRouter(svc, accountOwner)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/accounts/"+toAccount+"/close", nil))
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
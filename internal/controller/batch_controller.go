@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/auth"
+	"secure-payment-service/internal/middleware"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/problem"
+	"secure-payment-service/internal/service"
+	"secure-payment-service/internal/transfers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxBatchBytes is the largest bulk upload accepted.
+const MaxBatchBytes = 10 << 20
+
+type BatchController struct {
+	batchService service.BatchService
+}
+
+func NewBatchController(svc service.BatchService) *BatchController {
+	return &BatchController{batchService: svc}
+}
+
+// CreateBatch accepts a CSV or NDJSON file of transfers, chosen by the
+// Content-Type of the body, and answers 202 once its rows are stored. The
+// caller must be allowed to move funds from every source account in it.
+func (ctrl *BatchController) CreateBatch(c *gin.Context) {
+	format := c.ContentType()
+	if format != transfers.BatchCSV && format != transfers.BatchNDJSON {
+		problem.Abort(c, http.StatusUnsupportedMediaType, apperrors.CodeUnsupportedMedia,
+			"upload must be "+transfers.BatchCSV+" or "+transfers.BatchNDJSON)
+		return
+	}
+
+	rows, err := transfers.ParseBatch(http.MaxBytesReader(c.Writer, c.Request.Body, MaxBatchBytes), format)
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		problem.Abort(c, http.StatusRequestEntityTooLarge, apperrors.CodeInvalidRequest, "upload must be at most 10 MiB")
+		return
+	case errors.Is(err, apperrors.ErrValidation):
+		problem.AbortWithError(c, err)
+		return
+	case err != nil:
+		problem.Abort(c, http.StatusBadRequest, apperrors.CodeInvalidRequest, "could not read request body")
+		return
+	}
+
+	for _, row := range rows {
+		if row.Request.FromAccount != "" && !callerCanAccess(c, row.Request.FromAccount) {
+			problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to move funds from account "+row.Request.FromAccount)
+			return
+		}
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	batch, err := ctrl.batchService.CreateBatch(c.Request.Context(), format, rows, principal.Subject)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, batch)
+}
+
+func (ctrl *BatchController) GetBatch(c *gin.Context) {
+	batch, ok := ctrl.authorize(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, batch)
+}
+
+// ListBatchRows pages through the rows of a batch in file order. Pass the
+// returned next_after as after to get the following page; it is absent on the
+// last one.
+func (ctrl *BatchController) ListBatchRows(c *gin.Context) {
+	var query transfers.BatchRowsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		abortWithBindingError(c, err)
+		return
+	}
+
+	filter, err := query.Filter()
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	batch, ok := ctrl.authorize(c)
+	if !ok {
+		return
+	}
+
+	rows, err := ctrl.batchService.ListBatchRows(c.Request.Context(), batch.BatchID, filter.Status, filter.AfterLine, filter.Limit)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	response := gin.H{"rows": rows}
+	if len(rows) == filter.Limit {
+		response["next_after"] = rows[len(rows)-1].Line
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// authorize loads the batch named in the path, which only its submitter and
+// callers with access to every account may read. It aborts the request and
+// reports false otherwise.
+func (ctrl *BatchController) authorize(c *gin.Context) (models.TransferBatch, bool) {
+	batch, err := ctrl.batchService.GetBatch(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return models.TransferBatch{}, false
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	submitter := principal.Subject != "" && principal.Subject == batch.CreatedBy
+	if !submitter && !principal.CanAccessAccount(auth.AllAccounts) {
+		problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to access this batch")
+		return models.TransferBatch{}, false
+	}
+
+	return batch, true
+}
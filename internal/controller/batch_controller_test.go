@@ -0,0 +1,184 @@
+package controller_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/auth"
+	"secure-payment-service/internal/controller"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/middleware"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/transfers"
+)
+
+const batchID = "batch-id"
+
+func setupBatchRouter(svc *controller.MockBatchService, principal auth.Principal) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	ctrl := controller.NewBatchController(svc)
+
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.PrincipalKey, principal)
+	})
+
+	r.POST("/transfers/batch", ctrl.CreateBatch)
+	r.GET("/transfers/batch/:id", ctrl.GetBatch)
+	r.GET("/transfers/batch/:id/rows", ctrl.ListBatchRows)
+
+	return r
+}
+
+func givenABatch() models.TransferBatch {
+	return models.TransferBatch{
+		BatchID:     batchID,
+		Format:      transfers.BatchCSV,
+		Status:      enums.BatchProcessing.String(),
+		TotalRows:   2,
+		PendingRows: 1,
+		FailedRows:  1,
+		CreatedBy:   payer.Subject,
+	}
+}
+
+func postBatch(router *gin.Engine, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/transfers/batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", contentType)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+func TestCreateBatch_CSV(t *testing.T) {
+	svc := controller.NewMockBatchService(t)
+	router := setupBatchRouter(svc, payer)
+
+	svc.EXPECT().CreateBatch(mock.Anything, transfers.BatchCSV, mock.MatchedBy(func(rows []transfers.BatchRow) bool {
+		return len(rows) == 2 && rows[0].Err == nil && rows[1].Err != nil
+	}), "user-1").Return(givenABatch(), nil).Once()
+
+	resp := postBatch(router, "text/csv; charset=utf-8",
+		"source_account_id,destination_account_id,amount,currency\nacc-001,acc-002,10.00,USD\nacc-001,acc-002,0,USD\n")
+
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"batch_id":"`+batchID+`"`)
+	assert.Contains(t, resp.Body.String(), `"failed_rows":1`)
+}
+
+func TestCreateBatch_NDJSON(t *testing.T) {
+	svc := controller.NewMockBatchService(t)
+	router := setupBatchRouter(svc, payer)
+
+	svc.EXPECT().CreateBatch(mock.Anything, transfers.BatchNDJSON, mock.MatchedBy(func(rows []transfers.BatchRow) bool {
+		return len(rows) == 1 && rows[0].Request.ToAccount == toAccount
+	}), "user-1").Return(givenABatch(), nil).Once()
+
+	resp := postBatch(router, transfers.BatchNDJSON,
+		`{"source_account_id":"acc-001","destination_account_id":"acc-002","amount":"10.00","currency":"USD"}`+"\n")
+
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+}
+
+func TestCreateBatch_UnsupportedMediaType(t *testing.T) {
+	svc := controller.NewMockBatchService(t)
+	router := setupBatchRouter(svc, payer)
+
+	resp := postBatch(router, "application/json", `[]`)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.Code)
+	assert.Contains(t, resp.Body.String(), apperrors.CodeUnsupportedMedia)
+}
+
+func TestCreateBatch_UnusableFile(t *testing.T) {
+	svc := controller.NewMockBatchService(t)
+	router := setupBatchRouter(svc, payer)
+
+	resp := postBatch(router, transfers.BatchCSV, "source_account_id,amount\nacc-001,10\n")
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"field":"file"`)
+}
+
+func TestCreateBatch_TooLarge(t *testing.T) {
+	svc := controller.NewMockBatchService(t)
+	router := setupBatchRouter(svc, payer)
+
+	resp := postBatch(router, transfers.BatchNDJSON, strings.Repeat("\n", controller.MaxBatchBytes+1))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+}
+
+func TestCreateBatch_ForbiddenSourceAccount(t *testing.T) {
+	svc := controller.NewMockBatchService(t)
+	router := setupBatchRouter(svc, payer)
+
+	resp := postBatch(router, transfers.BatchCSV,
+		"source_account_id,destination_account_id,amount,currency\nacc-001,acc-002,10.00,USD\nacc-009,acc-002,10.00,USD\n")
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	assert.Contains(t, resp.Body.String(), "acc-009")
+	svc.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetBatch_OnlySubmitterMayRead(t *testing.T) {
+	svc := controller.NewMockBatchService(t)
+	router := setupBatchRouter(svc, auth.Principal{Subject: "user-2", Accounts: []string{fromAccount}})
+
+	svc.EXPECT().GetBatch(mock.Anything, batchID).Return(givenABatch(), nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/transfers/batch/"+batchID, nil))
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
+
+func TestGetBatch_NotFound(t *testing.T) {
+	svc := controller.NewMockBatchService(t)
+	router := setupBatchRouter(svc, payer)
+
+	svc.EXPECT().GetBatch(mock.Anything, batchID).
+		Return(models.TransferBatch{}, apperrors.New(apperrors.ErrNotFound, apperrors.CodeBatchNotFound, "batch not found")).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/transfers/batch/"+batchID, nil))
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Contains(t, resp.Body.String(), apperrors.CodeBatchNotFound)
+}
+
+func TestListBatchRows_Pages(t *testing.T) {
+	svc := controller.NewMockBatchService(t)
+	router := setupBatchRouter(svc, payer)
+
+	svc.EXPECT().GetBatch(mock.Anything, batchID).Return(givenABatch(), nil).Once()
+	svc.EXPECT().ListBatchRows(mock.Anything, batchID, enums.BatchRowCreated, 2, 1).Return([]models.TransferBatchRow{
+		{Line: 3, Status: enums.BatchRowCreated.String(), TransferID: expTransferID},
+	}, nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/transfers/batch/"+batchID+"/rows?status=created&after=2&limit=1", nil))
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"transfer_id":"`+expTransferID+`"`)
+	assert.Contains(t, resp.Body.String(), `"next_after":3`)
+}
+
+func TestListBatchRows_InvalidStatus(t *testing.T) {
+	svc := controller.NewMockBatchService(t)
+	router := setupBatchRouter(svc, payer)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/transfers/batch/"+batchID+"/rows?status=DONE", nil))
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	svc.AssertNotCalled(t, "GetBatch", mock.Anything, mock.Anything)
+}
@@ -2,39 +2,66 @@ package controller_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/auth"
 	"secure-payment-service/internal/controller"
 	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/middleware"
 	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/money"
+	"secure-payment-service/internal/problem"
 	"secure-payment-service/internal/transfers"
 )
 
 const (
-	fromAccount     = "acc-001"
-	toAccount       = "acc-002"
-	amount          = 100.00
-	currency        = "USD"
-	expTransferID   = "test-transfer-id-123"
-	expectedBalance = 750.50
+	fromAccount   = "acc-001"
+	toAccount     = "acc-002"
+	currency      = "USD"
+	expTransferID = "test-transfer-id-123"
+)
+
+var (
+	amount          = money.MustParse("100.00")
+	expectedBalance = money.MustParse("750.50")
 )
 
 func setupRouter(svc *controller.MockTransferService) *gin.Engine {
+	return setupRouterAs(svc, auth.Principal{Subject: "user-1", Accounts: []string{fromAccount, toAccount}})
+}
+
+func setupRouterAs(svc *controller.MockTransferService, principal auth.Principal) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
 	ctrl := controller.NewTransferController(svc)
 
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.PrincipalKey, principal)
+	})
+
 	r.POST("/transfers", ctrl.CreateTransfer)
+	r.GET("/transfers", ctrl.ListTransfers)
+	r.GET("/transfers/scheduled", ctrl.ListScheduledTransfers)
+	r.POST("/transfers/group", ctrl.CreateTransferGroup)
+	r.GET("/transfers/group/:id", ctrl.GetTransferGroup)
 	r.GET("/transfers/:id", ctrl.GetTransfer)
+	r.GET("/transfers/:id/history", ctrl.GetTransferHistory)
+	r.PATCH("/transfers/:id", ctrl.UpdateScheduledTransfer)
+	r.POST("/transfers/:id/cancel", ctrl.CancelTransfer)
+	r.POST("/transfers/:id/refund", ctrl.RefundTransfer)
 	r.GET("/accounts/:id/balance", ctrl.GetAccountBalance)
+	r.GET("/accounts/:id/statement", ctrl.GetAccountStatement)
 	r.POST("/webhooks/transfer", ctrl.UpdateTransfer)
 
 	return r
@@ -45,6 +72,7 @@ func givenATransferRequest() transfers.TransferRequest {
 		FromAccount: fromAccount,
 		ToAccount:   toAccount,
 		Amount:      amount,
+		Currency:    currency,
 	}
 }
 
@@ -54,7 +82,7 @@ func TestCreateTransfer_Success(t *testing.T) {
 
 	reqBody := givenATransferRequest()
 
-	svc.EXPECT().CreateTransfer(reqBody).Return(expTransferID, nil).Once()
+	svc.EXPECT().CreateTransfer(mock.Anything, reqBody).Return(expTransferID, nil).Once()
 
 	jsonBody, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewBuffer(jsonBody))
@@ -72,6 +100,51 @@ func TestCreateTransfer_Success(t *testing.T) {
 	assert.Equal(t, enums.PENDING.String(), responseBody["status"])
 }
 
+func TestCreateTransfer_Scheduled(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	reqBody := givenATransferRequest()
+	executeAt := time.Now().UTC().Truncate(time.Second).Add(48 * time.Hour)
+	reqBody.ExecuteAt = &executeAt
+
+	svc.EXPECT().CreateTransfer(mock.Anything, mock.MatchedBy(func(r transfers.TransferRequest) bool {
+		return r.ExecuteAt != nil && r.ExecuteAt.Equal(executeAt)
+	})).Return(expTransferID, nil).Once()
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	var responseBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &responseBody)
+
+	assert.Equal(t, expTransferID, responseBody["transfer_id"])
+	assert.Equal(t, enums.SCHEDULED.String(), responseBody["status"])
+	assert.Equal(t, executeAt.Format(time.RFC3339), responseBody["execute_at"])
+}
+
+func TestCreateTransfer_ExecuteAtInPast(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewBufferString(
+		`{"source_account_id":"acc-001","destination_account_id":"acc-002","amount":"10.00","currency":"USD","execute_at":"2020-01-01T00:00:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), apperrors.CodeMustBeFuture)
+	svc.AssertNotCalled(t, "CreateTransfer", mock.Anything, mock.Anything)
+}
+
 func TestCreateTransfer_InvalidJSON(t *testing.T) {
 	svc := controller.NewMockTransferService(t)
 	router := setupRouter(svc)
@@ -86,8 +159,9 @@ func TestCreateTransfer_InvalidJSON(t *testing.T) {
 
 	var responseBody map[string]interface{}
 	json.Unmarshal(resp.Body.Bytes(), &responseBody)
-	assert.Contains(t, responseBody["error"].(string), "invalid character")
-	svc.AssertNotCalled(t, "CreateTransfer", mock.Anything)
+	assert.Contains(t, responseBody["detail"].(string), "invalid character")
+	assert.Equal(t, apperrors.CodeInvalidRequest, responseBody["code"])
+	svc.AssertNotCalled(t, "CreateTransfer", mock.Anything, mock.Anything)
 }
 
 func TestCreateTransfer_ServiceError(t *testing.T) {
@@ -97,7 +171,7 @@ func TestCreateTransfer_ServiceError(t *testing.T) {
 	reqBody := givenATransferRequest()
 	serviceError := errors.New("error simulado del servicio de transferencia")
 
-	svc.EXPECT().CreateTransfer(reqBody).Return("", serviceError).Once()
+	svc.EXPECT().CreateTransfer(mock.Anything, reqBody).Return("", serviceError).Once()
 
 	jsonBody, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewBuffer(jsonBody))
@@ -107,10 +181,289 @@ func TestCreateTransfer_ServiceError(t *testing.T) {
 	router.ServeHTTP(resp, req)
 
 	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, problem.ContentType, resp.Header().Get("Content-Type"))
+
+	var responseBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &responseBody)
+	assert.Equal(t, apperrors.CodeInternal, responseBody["code"])
+	assert.NotContains(t, resp.Body.String(), serviceError.Error())
+}
+
+func TestCreateTransfer_InsufficientFunds(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	reqBody := givenATransferRequest()
+	svc.EXPECT().CreateTransfer(mock.Anything, reqBody).
+		Return("", apperrors.New(apperrors.ErrInsufficientFunds, apperrors.CodeInsufficientFunds, "insufficient funds in account acc-001")).Once()
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	var responseBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &responseBody)
+	assert.Equal(t, apperrors.CodeInsufficientFunds, responseBody["code"])
+}
+
+func TestCreateTransfer_ValidationErrors(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewBufferString(`{
+		"source_account_id": "acc-001",
+		"destination_account_id": "acc-001",
+		"amount": -5,
+		"currency": "XYZ"
+	}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "the request has invalid fields",
+		"instance": "/transfers",
+		"code": "validation_failed",
+		"errors": [
+			{"field": "destination_account_id", "code": "same_account", "message": "must differ from source_account_id"},
+			{"field": "currency", "code": "invalid_currency", "message": "'XYZ' is not an ISO 4217 currency code"},
+			{"field": "amount", "code": "must_be_positive", "message": "must be greater than zero"}
+		]
+	}`, resp.Body.String())
+	svc.AssertNotCalled(t, "CreateTransfer", mock.Anything, mock.Anything)
+}
+
+func TestCreateTransfer_InvalidFieldType(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewBufferString(`{"source_account_id": 12, "amount": 1, "currency": "USD"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	var responseBody struct {
+		Code   string                 `json:"code"`
+		Errors []apperrors.FieldError `json:"errors"`
+	}
+	json.Unmarshal(resp.Body.Bytes(), &responseBody)
+	assert.Equal(t, apperrors.CodeValidationFailed, responseBody.Code)
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "source_account_id", Code: apperrors.CodeInvalidType, Message: "must be a string"},
+		{Field: "destination_account_id", Code: apperrors.CodeRequired, Message: "is required"},
+	}, responseBody.Errors)
+}
+
+func TestCreateTransfer_MalformedAmountReportedWithOtherFields(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewBufferString(`{
+		"source_account_id": "acc-001",
+		"destination_account_id": "acc_002",
+		"amount": "12.3.4",
+		"currency": "XYZ"
+	}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	var responseBody struct {
+		Code   string                 `json:"code"`
+		Errors []apperrors.FieldError `json:"errors"`
+	}
+	json.Unmarshal(resp.Body.Bytes(), &responseBody)
+	assert.Equal(t, apperrors.CodeValidationFailed, responseBody.Code)
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "amount", Code: apperrors.CodeInvalidAmount, Message: `invalid amount: "12.3.4"`},
+		{Field: "currency", Code: apperrors.CodeInvalidCurrency, Message: "'XYZ' is not an ISO 4217 currency code"},
+	}, responseBody.Errors)
+	svc.AssertNotCalled(t, "CreateTransfer", mock.Anything, mock.Anything)
+}
+
+func TestCreateTransfer_ForbiddenSourceAccount(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouterAs(svc, auth.Principal{Subject: "user-2", Accounts: []string{toAccount}})
+
+	jsonBody, _ := json.Marshal(givenATransferRequest())
+	req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	svc.AssertNotCalled(t, "CreateTransfer", mock.Anything, mock.Anything)
+}
+
+func givenATransferGroupRequest() transfers.TransferGroupRequest {
+	return transfers.TransferGroupRequest{
+		FromAccount: fromAccount,
+		Currency:    currency,
+		Legs: []transfers.TransferLegRequest{
+			{ToAccount: toAccount, Amount: money.MustParse("90")},
+			{ToAccount: "acc-fee", Amount: money.MustParse("10")},
+		},
+	}
+}
+
+func givenATransferGroup() models.TransferGroup {
+	return models.NewTransferGroup([]models.Transfer{
+		{TransferID: "leg-1", ParentID: "parent-1", FromAccount: fromAccount, ToAccount: toAccount, Amount: money.MustParse("90"), Currency: currency, Status: enums.PENDING.String()},
+		{TransferID: "leg-2", ParentID: "parent-1", FromAccount: fromAccount, ToAccount: "acc-fee", Amount: money.MustParse("10"), Currency: currency, Status: enums.PENDING.String()},
+	})
+}
+
+func TestCreateTransferGroup_Success(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	reqBody := givenATransferGroupRequest()
+	svc.EXPECT().CreateTransferGroup(mock.Anything, reqBody).Return(givenATransferGroup(), nil).Once()
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/transfers/group", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
 
 	var responseBody map[string]interface{}
 	json.Unmarshal(resp.Body.Bytes(), &responseBody)
-	assert.Equal(t, serviceError.Error(), responseBody["error"])
+	assert.Equal(t, "parent-1", responseBody["parent_transfer_id"])
+	assert.Equal(t, 100.0, responseBody["amount"])
+	assert.Equal(t, enums.PENDING.String(), responseBody["status"])
+	assert.Len(t, responseBody["legs"], 2)
+}
+
+func TestCreateTransferGroup_ValidationErrors(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	reqBody := givenATransferGroupRequest()
+	reqBody.Legs[1].ToAccount = fromAccount
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/transfers/group", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	var responseBody problem.Problem
+	json.Unmarshal(resp.Body.Bytes(), &responseBody)
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "legs[1].destination_account_id", Code: apperrors.CodeSameAccount, Message: "must differ from source_account_id"},
+	}, responseBody.Errors)
+	svc.AssertNotCalled(t, "CreateTransferGroup", mock.Anything, mock.Anything)
+}
+
+func TestCreateTransferGroup_ForbiddenSourceAccount(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouterAs(svc, auth.Principal{Subject: "user-2", Accounts: []string{toAccount}})
+
+	jsonBody, _ := json.Marshal(givenATransferGroupRequest())
+	req := httptest.NewRequest(http.MethodPost, "/transfers/group", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	svc.AssertNotCalled(t, "CreateTransferGroup", mock.Anything, mock.Anything)
+}
+
+func TestGetTransferGroup_PayeeMayRead(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouterAs(svc, auth.Principal{Subject: "platform", Accounts: []string{"acc-fee"}})
+
+	svc.EXPECT().GetTransferGroup(mock.Anything, "parent-1").Return(givenATransferGroup(), nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/transfers/group/parent-1", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var group models.TransferGroup
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &group))
+	assert.Len(t, group.Legs, 1)
+	assert.Equal(t, "leg-2", group.Legs[0].TransferID)
+	assert.Equal(t, money.MustParse("10"), group.Amount)
+	assert.NotContains(t, resp.Body.String(), toAccount)
+}
+
+func TestGetTransferGroup_PayerSeesEveryLeg(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouterAs(svc, auth.Principal{Subject: "buyer", Accounts: []string{fromAccount}})
+
+	svc.EXPECT().GetTransferGroup(mock.Anything, "parent-1").Return(givenATransferGroup(), nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/transfers/group/parent-1", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var group models.TransferGroup
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &group))
+	assert.Len(t, group.Legs, 2)
+	assert.Equal(t, money.MustParse("100"), group.Amount)
+}
+
+func TestGetTransferGroup_Forbidden(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouterAs(svc, auth.Principal{Subject: "user-2", Accounts: []string{"acc-999"}})
+
+	svc.EXPECT().GetTransferGroup(mock.Anything, "parent-1").Return(givenATransferGroup(), nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/transfers/group/parent-1", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
+
+func TestGetTransferGroup_NotFound(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	serviceError := apperrors.New(apperrors.ErrNotFound, apperrors.CodeGroupNotFound, "transfer group not found")
+	svc.EXPECT().GetTransferGroup(mock.Anything, "missing").Return(models.TransferGroup{}, serviceError).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/transfers/group/missing", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestGetTransfer_Forbidden(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouterAs(svc, auth.Principal{Subject: "user-2", Accounts: []string{"acc-999"}})
+
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).Return(models.Transfer{TransferID: expTransferID, FromAccount: fromAccount, ToAccount: toAccount}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/transfers/"+expTransferID, nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
 }
 
 func TestGetTransfer_Success(t *testing.T) {
@@ -123,15 +476,17 @@ func TestGetTransfer_Success(t *testing.T) {
 		ToAccount:   toAccount,
 		Amount:      amount,
 		Status:      enums.COMPLETED.String(),
+		Version:     4,
 	}
 
-	svc.EXPECT().GetTransfer(expTransferID).Return(expectedTransfer, nil).Once()
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).Return(expectedTransfer, nil).Once()
 
 	req := httptest.NewRequest(http.MethodGet, "/transfers/"+expTransferID, nil)
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 
 	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `"4"`, resp.Header().Get("ETag"))
 
 	var responseTransfer models.Transfer
 	json.Unmarshal(resp.Body.Bytes(), &responseTransfer)
@@ -142,9 +497,9 @@ func TestGetTransfer_NotFound(t *testing.T) {
 	svc := controller.NewMockTransferService(t)
 	router := setupRouter(svc)
 
-	serviceError := errors.New("transfer not found")
+	serviceError := apperrors.New(apperrors.ErrNotFound, apperrors.CodeTransferNotFound, "transfer not found")
 
-	svc.EXPECT().GetTransfer(expTransferID).Return(models.Transfer{}, serviceError).Once()
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).Return(models.Transfer{}, serviceError).Once()
 
 	req := httptest.NewRequest(http.MethodGet, "/transfers/"+expTransferID, nil)
 	resp := httptest.NewRecorder()
@@ -154,89 +509,753 @@ func TestGetTransfer_NotFound(t *testing.T) {
 
 	var responseBody map[string]interface{}
 	json.Unmarshal(resp.Body.Bytes(), &responseBody)
-	assert.Equal(t, serviceError.Error(), responseBody["error"])
+	assert.Equal(t, serviceError.Error(), responseBody["detail"])
+	assert.Equal(t, serviceError.ErrorCode(), responseBody["code"])
 }
 
-func TestGetAccountBalance_Success(t *testing.T) {
+func TestGetTransferHistory_Success(t *testing.T) {
 	svc := controller.NewMockTransferService(t)
 	router := setupRouter(svc)
 
-	svc.EXPECT().GetAccountBalance(fromAccount).Return(expectedBalance, nil).Once()
+	history := []models.TransferStatusHistory{
+		{TransferID: expTransferID, ToStatus: enums.PENDING.String(), Source: enums.SourceAPI.String()},
+		{TransferID: expTransferID, FromStatus: enums.PENDING.String(), ToStatus: enums.COMPLETED.String(), Source: enums.SourceWebhook.String()},
+	}
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).Return(models.Transfer{TransferID: expTransferID, FromAccount: fromAccount, ToAccount: toAccount}, nil).Once()
+	svc.EXPECT().GetTransferHistory(mock.Anything, expTransferID).Return(history, nil).Once()
 
-	req := httptest.NewRequest(http.MethodGet, "/accounts/"+fromAccount+"/balance", nil)
+	req := httptest.NewRequest(http.MethodGet, "/transfers/"+expTransferID+"/history", nil)
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 
 	assert.Equal(t, http.StatusOK, resp.Code)
 
-	var responseBody map[string]interface{}
+	var responseBody struct {
+		TransferID string                         `json:"transfer_id"`
+		History    []models.TransferStatusHistory `json:"history"`
+	}
 	json.Unmarshal(resp.Body.Bytes(), &responseBody)
-	assert.Equal(t, fromAccount, responseBody["account_id"])
-	assert.Equal(t, expectedBalance, responseBody["balance"])
+	assert.Equal(t, expTransferID, responseBody.TransferID)
+	assert.Equal(t, history, responseBody.History)
 }
 
-func TestGetAccountBalance_ServiceError(t *testing.T) {
+func TestGetTransferHistory_NotFound(t *testing.T) {
 	svc := controller.NewMockTransferService(t)
 	router := setupRouter(svc)
 
-	serviceError := errors.New("error obteniendo balance")
-
-	svc.EXPECT().GetAccountBalance(fromAccount).Return(0.0, serviceError).Once()
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).Return(models.Transfer{}, apperrors.New(apperrors.ErrNotFound, apperrors.CodeTransferNotFound, "transfer not found")).Once()
 
-	req := httptest.NewRequest(http.MethodGet, "/accounts/"+fromAccount+"/balance", nil)
+	req := httptest.NewRequest(http.MethodGet, "/transfers/"+expTransferID+"/history", nil)
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 
 	assert.Equal(t, http.StatusNotFound, resp.Code)
-	var responseBody map[string]interface{}
-	json.Unmarshal(resp.Body.Bytes(), &responseBody)
-	assert.Equal(t, serviceError.Error(), responseBody["error"])
 }
 
-func givenAWebhookEvent() transfers.WebhookEvent {
-	return transfers.WebhookEvent{
-		ID:     expTransferID,
-		Status: enums.COMPLETED.String(),
-	}
+func TestGetTransferHistory_Forbidden(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouterAs(svc, auth.Principal{Subject: "user-2", Accounts: []string{"acc-999"}})
+
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).Return(models.Transfer{TransferID: expTransferID, FromAccount: fromAccount, ToAccount: toAccount}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/transfers/"+expTransferID+"/history", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
 }
 
-func TestUpdateTransfer_Success(t *testing.T) {
+func TestGetAccountBalance_Success(t *testing.T) {
 	svc := controller.NewMockTransferService(t)
 	router := setupRouter(svc)
 
-	webhookBody := givenAWebhookEvent()
-
-	svc.EXPECT().UpdateTransfer(webhookBody.ID, webhookBody.Status).Return(nil).Once()
-
-	jsonBody, _ := json.Marshal(webhookBody)
-	req := httptest.NewRequest(http.MethodPost, "/webhooks/transfer", bytes.NewBuffer(jsonBody))
-	req.Header.Set("Content-Type", "application/json")
+	svc.EXPECT().GetAccountBalance(mock.Anything, fromAccount).Return([]models.AccountBalance{
+		{Currency: "EUR", Ledger: money.MustParse("20.25"), Available: money.MustParse("20.25")},
+		{Currency: currency, Ledger: expectedBalance, Pending: money.MustParse("50"), Available: money.MustParse("700.5")},
+	}, nil).Once()
 
+	req := httptest.NewRequest(http.MethodGet, "/accounts/"+fromAccount+"/balance", nil)
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 
 	assert.Equal(t, http.StatusOK, resp.Code)
 
+	assert.JSONEq(t, `{
+		"account_id": "acc-001",
+		"balances": [
+			{"currency": "EUR", "ledger": 20.25, "pending": 0, "available": 20.25},
+			{"currency": "USD", "ledger": 750.5, "pending": 50, "available": 700.5}
+		]
+	}`, resp.Body.String())
+}
+
+func TestGetAccountBalance_ServiceError(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	serviceError := errors.New("error obteniendo balance")
+
+	svc.EXPECT().GetAccountBalance(mock.Anything, fromAccount).Return(nil, serviceError).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/"+fromAccount+"/balance", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
 	var responseBody map[string]interface{}
 	json.Unmarshal(resp.Body.Bytes(), &responseBody)
-	assert.Equal(t, "Transfer updated", responseBody["status"])
+	assert.Equal(t, apperrors.CodeInternal, responseBody["code"])
 }
 
-func TestUpdateTransfer_InvalidJSON(t *testing.T) {
+func TestGetAccountBalance_NotFound(t *testing.T) {
 	svc := controller.NewMockTransferService(t)
 	router := setupRouter(svc)
 
-	req := httptest.NewRequest(http.MethodPost, "/webhooks/transfer", bytes.NewBufferString("{invalid json"))
-	req.Header.Set("Content-Type", "application/json")
+	svc.EXPECT().GetAccountBalance(mock.Anything, fromAccount).Return(nil, apperrors.New(apperrors.ErrNotFound, apperrors.CodeAccountNotFound, "account not found")).Once()
 
+	req := httptest.NewRequest(http.MethodGet, "/accounts/"+fromAccount+"/balance", nil)
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 
-	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
 	var responseBody map[string]interface{}
 	json.Unmarshal(resp.Body.Bytes(), &responseBody)
-	assert.Contains(t, responseBody["error"].(string), "invalid character")
-	svc.AssertNotCalled(t, "UpdateTransfer", mock.Anything, mock.Anything)
+	assert.Equal(t, apperrors.CodeAccountNotFound, responseBody["code"])
+}
+
+func TestGetAccountBalance_Timeout(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	svc.EXPECT().GetAccountBalance(mock.Anything, fromAccount).Return(nil, context.DeadlineExceeded).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/"+fromAccount+"/balance", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusGatewayTimeout, resp.Code)
+}
+
+func TestGetAccountBalance_Forbidden(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouterAs(svc, auth.Principal{Subject: "user-2", Accounts: []string{toAccount}})
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/"+fromAccount+"/balance", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
+
+func TestGetAccountBalance_AllAccountsPrincipal(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouterAs(svc, auth.Principal{Subject: "ops", Accounts: []string{auth.AllAccounts}})
+
+	svc.EXPECT().GetAccountBalance(mock.Anything, "acc-777").Return([]models.AccountBalance{}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/acc-777/balance", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestListTransfers_Success(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	next := models.TransferCursor{CreatedAt: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), ID: 9}
+	expectedFilter := models.TransferFilter{
+		Accounts:    []string{fromAccount},
+		AccountRole: enums.RoleSource,
+		Statuses:    []string{enums.COMPLETED.String()},
+		Limit:       10,
+	}
+	svc.EXPECT().ListTransfers(mock.Anything, expectedFilter).Return(models.TransferPage{
+		Transfers: []models.Transfer{{TransferID: expTransferID, FromAccount: fromAccount}},
+		Next:      &next,
+	}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/transfers?account="+fromAccount+"&role=source&status=COMPLETED&limit=10", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var body struct {
+		Transfers  []models.Transfer `json:"transfers"`
+		NextCursor string            `json:"next_cursor"`
+	}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.Len(t, body.Transfers, 1)
+	assert.Equal(t, expTransferID, body.Transfers[0].TransferID)
+	assert.Equal(t, transfers.EncodeCursor(next), body.NextCursor)
+}
+
+func TestListTransfers_DefaultsToCallerAccounts(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	svc.EXPECT().ListTransfers(mock.Anything, models.TransferFilter{
+		Accounts:    []string{fromAccount, toAccount},
+		AccountRole: enums.RoleAny,
+		Limit:       transfers.DefaultListLimit,
+	}).Return(models.TransferPage{Transfers: []models.Transfer{}}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/transfers", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"transfers": []}`, resp.Body.String())
+}
+
+func TestListTransfers_AllAccountsPrincipalIsNotRestricted(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouterAs(svc, auth.Principal{Subject: "ops", Accounts: []string{auth.AllAccounts}})
+
+	svc.EXPECT().ListTransfers(mock.Anything, models.TransferFilter{
+		AccountRole: enums.RoleAny,
+		Limit:       transfers.DefaultListLimit,
+	}).Return(models.TransferPage{Transfers: []models.Transfer{}}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/transfers", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestListTransfers_ForbiddenAccount(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouterAs(svc, auth.Principal{Subject: "user-2", Accounts: []string{toAccount}})
+
+	req := httptest.NewRequest(http.MethodGet, "/transfers?account="+fromAccount, nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	svc.AssertNotCalled(t, "ListTransfers", mock.Anything, mock.Anything)
+}
+
+func TestListTransfers_InvalidParameters(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/transfers?limit=0&currency=XYZ", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "the request has invalid fields",
+		"instance": "/transfers",
+		"code": "validation_failed",
+		"errors": [
+			{"field": "currency", "code": "invalid_currency", "message": "'XYZ' is not an ISO 4217 currency code"},
+			{"field": "limit", "code": "invalid_format", "message": "must be an integer between 1 and 200"}
+		]
+	}`, resp.Body.String())
+}
+
+func givenAStatement() models.Statement {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	return models.Statement{
+		AccountID: fromAccount,
+		From:      from,
+		To:        from.AddDate(0, 1, 0),
+		Currencies: []models.CurrencyStatement{{
+			Currency:       currency,
+			OpeningBalance: expectedBalance,
+			ClosingBalance: expectedBalance,
+			Lines:          []models.StatementLine{},
+		}},
+	}
+}
+
+func TestGetAccountStatement_Formats(t *testing.T) {
+	tests := []struct {
+		accept      string
+		contentType string
+		contains    string
+	}{
+		{"", "application/json; charset=utf-8", `"opening_balance":750.5`},
+		{"application/json", "application/json; charset=utf-8", `"account_id":"acc-001"`},
+		{"text/csv", "text/csv; charset=utf-8", "acc-001,USD,opening,2024-03-01T00:00:00Z,,,,,,750.50"},
+		{"application/xml", "application/xml; charset=utf-8", "<Cd>OPBD</Cd>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			svc := controller.NewMockTransferService(t)
+			router := setupRouter(svc)
+
+			statement := givenAStatement()
+			svc.EXPECT().GetAccountStatement(mock.Anything, fromAccount, statement.From, statement.To).Return(statement, nil).Once()
+
+			req := httptest.NewRequest(http.MethodGet, "/accounts/"+fromAccount+"/statement?from=2024-03-01&to=2024-03-31", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.Equal(t, tt.contentType, resp.Header().Get("Content-Type"))
+			assert.Contains(t, resp.Body.String(), tt.contains)
+		})
+	}
+}
+
+func TestGetAccountStatement_NotAcceptable(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/"+fromAccount+"/statement?from=2024-03-01&to=2024-03-31", nil)
+	req.Header.Set("Accept", "application/pdf")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotAcceptable, resp.Code)
+	assert.Equal(t, problem.ContentType, resp.Header().Get("Content-Type"))
+}
+
+func TestGetAccountStatement_InvalidPeriod(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/"+fromAccount+"/statement?from=2024-03-31&to=2024-03-01", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	svc.AssertNotCalled(t, "GetAccountStatement", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetAccountStatement_Forbidden(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouterAs(svc, auth.Principal{Subject: "user-2", Accounts: []string{toAccount}})
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/"+fromAccount+"/statement?from=2024-03-01&to=2024-03-31", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
+
+func TestCancelTransfer_Success(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).
+		Return(models.Transfer{TransferID: expTransferID, FromAccount: fromAccount, ToAccount: "acc-999", Status: enums.PENDING.String()}, nil).Once()
+	svc.EXPECT().CancelTransfer(mock.Anything, expTransferID, models.StatusChange{
+		Source:  enums.SourceAPI,
+		Actor:   "user-1",
+		Reason:  "wrong beneficiary",
+		IfMatch: []uint{2},
+	}).Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/transfers/"+expTransferID+"/cancel", bytes.NewBufferString(`{"reason":"wrong beneficiary"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var responseBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &responseBody)
+	assert.Equal(t, enums.CANCELLED.String(), responseBody["status"])
+}
+
+func TestCancelTransfer_WithoutBody(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).
+		Return(models.Transfer{TransferID: expTransferID, FromAccount: fromAccount}, nil).Once()
+	svc.EXPECT().CancelTransfer(mock.Anything, expTransferID, models.StatusChange{Source: enums.SourceAPI, Actor: "user-1"}).Return(nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/transfers/"+expTransferID+"/cancel", nil))
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestCancelTransfer_OnlyPayerMayCancel(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouterAs(svc, auth.Principal{Subject: "user-2", Accounts: []string{toAccount}})
+
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).
+		Return(models.Transfer{TransferID: expTransferID, FromAccount: fromAccount, ToAccount: toAccount}, nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/transfers/"+expTransferID+"/cancel", nil))
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	svc.AssertNotCalled(t, "CancelTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCancelTransfer_AlreadySettled(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).
+		Return(models.Transfer{TransferID: expTransferID, FromAccount: fromAccount, Status: enums.COMPLETED.String()}, nil).Once()
+	svc.EXPECT().CancelTransfer(mock.Anything, expTransferID, mock.Anything).
+		Return(apperrors.New(apperrors.ErrConflict, apperrors.CodeNotCancellable, "transfer is COMPLETED and can no longer be cancelled")).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/transfers/"+expTransferID+"/cancel", nil))
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	var responseBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &responseBody)
+	assert.Equal(t, apperrors.CodeNotCancellable, responseBody["code"])
+}
+
+func TestRefundTransfer_Success(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+	partial := money.MustParse("25")
+
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).
+		Return(models.Transfer{TransferID: expTransferID, FromAccount: "acc-999", ToAccount: fromAccount, Status: enums.COMPLETED.String()}, nil).Once()
+	svc.EXPECT().RefundTransfer(mock.Anything, expTransferID, transfers.RefundRequest{Amount: &partial, Reason: "damaged"},
+		models.StatusChange{Source: enums.SourceAPI, Actor: "user-1", Reason: "damaged"}).
+		Return(models.Transfer{TransferID: "refund-id", RefundOf: expTransferID, Amount: partial}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/transfers/"+expTransferID+"/refund", bytes.NewBufferString(`{"amount": 25, "reason": "damaged"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	var refund models.Transfer
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &refund))
+	assert.Equal(t, expTransferID, refund.RefundOf)
+}
+
+func TestRefundTransfer_OnlyPayeeMayRefund(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).
+		Return(models.Transfer{TransferID: expTransferID, FromAccount: fromAccount, ToAccount: "acc-999"}, nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/transfers/"+expTransferID+"/refund", nil))
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	svc.AssertNotCalled(t, "RefundTransfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRefundTransfer_InvalidAmount(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/transfers/"+expTransferID+"/refund", bytes.NewBufferString(`{"amount": -5}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	svc.AssertNotCalled(t, "GetTransfer", mock.Anything, mock.Anything)
+}
+
+func TestRefundTransfer_ExceedsAmount(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).
+		Return(models.Transfer{TransferID: expTransferID, FromAccount: "acc-999", ToAccount: fromAccount}, nil).Once()
+	svc.EXPECT().RefundTransfer(mock.Anything, expTransferID, mock.Anything, mock.Anything).
+		Return(models.Transfer{}, apperrors.New(apperrors.ErrConflict, apperrors.CodeRefundExceeds, "at most 10.00 USD can still be refunded")).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/transfers/"+expTransferID+"/refund", nil))
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	var responseBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &responseBody)
+	assert.Equal(t, apperrors.CodeRefundExceeds, responseBody["code"])
+}
+
+func givenAWebhookEvent() transfers.WebhookEvent {
+	return transfers.WebhookEvent{
+		ID:     expTransferID,
+		Status: enums.COMPLETED.String(),
+	}
+}
+
+func changeFrom(event transfers.WebhookEvent) models.StatusChange {
+	return models.StatusChange{
+		Status: event.Status,
+		Source: enums.SourceWebhook,
+		Reason: event.Reason,
+	}
+}
+
+func TestListScheduledTransfers_DefaultsToCallerAccounts(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	svc.EXPECT().ListScheduledTransfers(mock.Anything, []string{fromAccount, toAccount}, 50).
+		Return([]models.Transfer{{TransferID: expTransferID, Status: enums.SCHEDULED.String()}}, nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/transfers/scheduled", nil))
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var responseBody struct {
+		Transfers []models.Transfer `json:"transfers"`
+	}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &responseBody))
+	assert.Len(t, responseBody.Transfers, 1)
+	assert.Equal(t, expTransferID, responseBody.Transfers[0].TransferID)
+}
+
+func TestListScheduledTransfers_ForbiddenAccount(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/transfers/scheduled?account=acc-999", nil))
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	svc.AssertNotCalled(t, "ListScheduledTransfers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateScheduledTransfer_Success(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	newAmount := money.MustParse("25.00")
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).
+		Return(models.Transfer{TransferID: expTransferID, FromAccount: fromAccount, Status: enums.SCHEDULED.String()}, nil).Once()
+	svc.EXPECT().UpdateScheduledTransfer(mock.Anything, expTransferID, transfers.ScheduledTransferUpdate{Amount: &newAmount}, []uint{3}).
+		Return(models.Transfer{TransferID: expTransferID, FromAccount: fromAccount, Amount: newAmount, Version: 4, Status: enums.SCHEDULED.String()}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPatch, "/transfers/"+expTransferID, bytes.NewBufferString(`{"amount":"25.00"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"3"`)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `"4"`, resp.Header().Get("ETag"))
+	assert.Contains(t, resp.Body.String(), `"Version":4`)
+}
+
+func TestUpdateScheduledTransfer_OnlyPayerMayEdit(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouterAs(svc, auth.Principal{Subject: "user-2", Accounts: []string{toAccount}})
+
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).
+		Return(models.Transfer{TransferID: expTransferID, FromAccount: fromAccount, ToAccount: toAccount}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPatch, "/transfers/"+expTransferID, bytes.NewBufferString(`{"amount":"1.00"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	svc.AssertNotCalled(t, "UpdateScheduledTransfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateScheduledTransfer_EmptyBody(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	req := httptest.NewRequest(http.MethodPatch, "/transfers/"+expTransferID, bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestUpdateScheduledTransfer_AlreadyReleased(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	svc.EXPECT().GetTransfer(mock.Anything, expTransferID).
+		Return(models.Transfer{TransferID: expTransferID, FromAccount: fromAccount, Status: enums.PENDING.String()}, nil).Once()
+	svc.EXPECT().UpdateScheduledTransfer(mock.Anything, expTransferID, mock.Anything, []uint(nil)).
+		Return(models.Transfer{}, apperrors.New(apperrors.ErrConflict, apperrors.CodeNotEditable, "transfer is PENDING and can no longer be changed")).Once()
+
+	req := httptest.NewRequest(http.MethodPatch, "/transfers/"+expTransferID, bytes.NewBufferString(`{"amount":"1.00"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	var responseBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &responseBody)
+	assert.Equal(t, apperrors.CodeNotEditable, responseBody["code"])
+}
+
+func TestUpdateTransfer_Success(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	webhookBody := givenAWebhookEvent()
+
+	svc.EXPECT().UpdateTransfer(mock.Anything, webhookBody.ID, changeFrom(webhookBody)).Return(nil).Once()
+
+	jsonBody, _ := json.Marshal(webhookBody)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/transfer", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var responseBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &responseBody)
+	assert.Equal(t, "Transfer updated", responseBody["status"])
+}
+
+func TestUpdateTransfer_IfMatch(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected []uint
+	}{
+		{`"3"`, []uint{3}},
+		{`W/"1", "2", "5"`, []uint{2, 5}},
+		{`*`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			svc := controller.NewMockTransferService(t)
+			router := setupRouter(svc)
+
+			webhookBody := givenAWebhookEvent()
+			change := changeFrom(webhookBody)
+			change.IfMatch = tt.expected
+			svc.EXPECT().UpdateTransfer(mock.Anything, webhookBody.ID, change).Return(nil).Once()
+
+			jsonBody, _ := json.Marshal(webhookBody)
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/transfer", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("If-Match", tt.header)
+
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+		})
+	}
+}
+
+func TestUpdateTransfer_IfMatchCannotMatch(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	jsonBody, _ := json.Marshal(givenAWebhookEvent())
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/transfer", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `W/"3"`)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, resp.Code)
+	svc.AssertNotCalled(t, "UpdateTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateTransfer_VersionMismatch(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	webhookBody := givenAWebhookEvent()
+	change := changeFrom(webhookBody)
+	change.IfMatch = []uint{1}
+	svc.EXPECT().UpdateTransfer(mock.Anything, webhookBody.ID, change).
+		Return(apperrors.New(apperrors.ErrPrecondition, apperrors.CodeVersionMismatch, "transfer is at version 2")).Once()
+
+	jsonBody, _ := json.Marshal(webhookBody)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/transfer", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, resp.Code)
+	var responseBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &responseBody)
+	assert.Equal(t, apperrors.CodeVersionMismatch, responseBody["code"])
+}
+
+func TestUpdateTransfer_InvalidJSON(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/transfer", bytes.NewBufferString("{invalid json"))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	var responseBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &responseBody)
+	assert.Contains(t, responseBody["detail"].(string), "invalid character")
+	assert.Equal(t, apperrors.CodeInvalidRequest, responseBody["code"])
+	svc.AssertNotCalled(t, "UpdateTransfer", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTransfer_IllegalTransition(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	webhookBody := givenAWebhookEvent()
+	webhookBody.Status = enums.PENDING.String()
+	serviceError := &enums.InvalidTransitionError{From: enums.COMPLETED, To: enums.PENDING}
+
+	svc.EXPECT().UpdateTransfer(mock.Anything, webhookBody.ID, changeFrom(webhookBody)).Return(serviceError).Once()
+
+	jsonBody, _ := json.Marshal(webhookBody)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/transfer", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	var responseBody map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &responseBody)
+	assert.Equal(t, serviceError.Error(), responseBody["detail"])
+	assert.Equal(t, serviceError.ErrorCode(), responseBody["code"])
+}
+
+func TestUpdateTransfer_InvalidStatus(t *testing.T) {
+	svc := controller.NewMockTransferService(t)
+	router := setupRouter(svc)
+
+	webhookBody := givenAWebhookEvent()
+	webhookBody.Status = "DONE"
+
+	svc.EXPECT().UpdateTransfer(mock.Anything, webhookBody.ID, changeFrom(webhookBody)).Return(&enums.InvalidStatusError{Value: "DONE"}).Once()
+
+	jsonBody, _ := json.Marshal(webhookBody)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/transfer", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
 }
 
 func TestUpdateTransfer_ServiceError(t *testing.T) {
@@ -244,9 +1263,9 @@ func TestUpdateTransfer_ServiceError(t *testing.T) {
 	router := setupRouter(svc)
 
 	webhookBody := givenAWebhookEvent()
-	serviceError := errors.New("transfer not found")
+	serviceError := apperrors.New(apperrors.ErrNotFound, apperrors.CodeTransferNotFound, "transfer not found")
 
-	svc.EXPECT().UpdateTransfer(webhookBody.ID, webhookBody.Status).Return(serviceError).Once()
+	svc.EXPECT().UpdateTransfer(mock.Anything, webhookBody.ID, changeFrom(webhookBody)).Return(serviceError).Once()
 
 	jsonBody, _ := json.Marshal(webhookBody)
 	req := httptest.NewRequest(http.MethodPost, "/webhooks/transfer", bytes.NewBuffer(jsonBody))
@@ -258,5 +1277,6 @@ func TestUpdateTransfer_ServiceError(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, resp.Code)
 	var responseBody map[string]interface{}
 	json.Unmarshal(resp.Body.Bytes(), &responseBody)
-	assert.Equal(t, serviceError.Error(), responseBody["error"])
+	assert.Equal(t, serviceError.Error(), responseBody["detail"])
+	assert.Equal(t, serviceError.ErrorCode(), responseBody["code"])
 }
@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"strconv"
+	"strings"
+)
+
+// transferETag is the strong entity tag of a transfer, derived from its
+// version.
+func transferETag(version uint) string {
+	return `"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+// ifMatchVersions parses an If-Match header into the transfer versions it
+// accepts. A missing header or "*" accepts any version and returns none. ok is
+// false when the header is present but cannot match any version, e.g. when it
+// only holds weak or foreign tags.
+func ifMatchVersions(header string) (versions []uint, ok bool) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return nil, true
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil, true
+		}
+		// If-Match uses the strong comparison, so weak W/"..." tags fail to
+		// unquote and never match.
+		unquoted, err := strconv.Unquote(tag)
+		if err != nil {
+			continue
+		}
+		version, err := strconv.ParseUint(unquoted, 10, 0)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, uint(version))
+	}
+
+	return versions, len(versions) > 0
+}
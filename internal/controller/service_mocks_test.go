@@ -4,6 +4,10 @@
 package controller
 
 import (
+	"context"
+	"time"
+
+	"secure-payment-service/internal/enums"
 	"secure-payment-service/internal/models"
 	"secure-payment-service/internal/transfers"
 
@@ -37,9 +41,72 @@ func (_m *MockTransferService) EXPECT() *MockTransferService_Expecter {
 	return &MockTransferService_Expecter{mock: &_m.Mock}
 }
 
+// CancelTransfer provides a mock function for the type MockTransferService
+func (_mock *MockTransferService) CancelTransfer(ctx context.Context, id string, change models.StatusChange) error {
+	ret := _mock.Called(ctx, id, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelTransfer")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.StatusChange) error); ok {
+		r0 = returnFunc(ctx, id, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTransferService_CancelTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelTransfer'
+type MockTransferService_CancelTransfer_Call struct {
+	*mock.Call
+}
+
+// CancelTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - change models.StatusChange
+func (_e *MockTransferService_Expecter) CancelTransfer(ctx interface{}, id interface{}, change interface{}) *MockTransferService_CancelTransfer_Call {
+	return &MockTransferService_CancelTransfer_Call{Call: _e.mock.On("CancelTransfer", ctx, id, change)}
+}
+
+func (_c *MockTransferService_CancelTransfer_Call) Run(run func(ctx context.Context, id string, change models.StatusChange)) *MockTransferService_CancelTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 models.StatusChange
+		if args[2] != nil {
+			arg2 = args[2].(models.StatusChange)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferService_CancelTransfer_Call) Return(err error) *MockTransferService_CancelTransfer_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTransferService_CancelTransfer_Call) RunAndReturn(run func(ctx context.Context, id string, change models.StatusChange) error) *MockTransferService_CancelTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateTransfer provides a mock function for the type MockTransferService
-func (_mock *MockTransferService) CreateTransfer(req transfers.TransferRequest) (string, error) {
-	ret := _mock.Called(req)
+func (_mock *MockTransferService) CreateTransfer(ctx context.Context, req transfers.TransferRequest) (string, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateTransfer")
@@ -47,16 +114,16 @@ func (_mock *MockTransferService) CreateTransfer(req transfers.TransferRequest)
 
 	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(transfers.TransferRequest) (string, error)); ok {
-		return returnFunc(req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, transfers.TransferRequest) (string, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(transfers.TransferRequest) string); ok {
-		r0 = returnFunc(req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, transfers.TransferRequest) string); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(transfers.TransferRequest) error); ok {
-		r1 = returnFunc(req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, transfers.TransferRequest) error); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -69,19 +136,25 @@ type MockTransferService_CreateTransfer_Call struct {
 }
 
 // CreateTransfer is a helper method to define mock.On call
+//   - ctx context.Context
 //   - req transfers.TransferRequest
-func (_e *MockTransferService_Expecter) CreateTransfer(req interface{}) *MockTransferService_CreateTransfer_Call {
-	return &MockTransferService_CreateTransfer_Call{Call: _e.mock.On("CreateTransfer", req)}
+func (_e *MockTransferService_Expecter) CreateTransfer(ctx interface{}, req interface{}) *MockTransferService_CreateTransfer_Call {
+	return &MockTransferService_CreateTransfer_Call{Call: _e.mock.On("CreateTransfer", ctx, req)}
 }
 
-func (_c *MockTransferService_CreateTransfer_Call) Run(run func(req transfers.TransferRequest)) *MockTransferService_CreateTransfer_Call {
+func (_c *MockTransferService_CreateTransfer_Call) Run(run func(ctx context.Context, req transfers.TransferRequest)) *MockTransferService_CreateTransfer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 transfers.TransferRequest
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(transfers.TransferRequest)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 transfers.TransferRequest
+		if args[1] != nil {
+			arg1 = args[1].(transfers.TransferRequest)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -92,31 +165,156 @@ func (_c *MockTransferService_CreateTransfer_Call) Return(s string, err error) *
 	return _c
 }
 
-func (_c *MockTransferService_CreateTransfer_Call) RunAndReturn(run func(req transfers.TransferRequest) (string, error)) *MockTransferService_CreateTransfer_Call {
+func (_c *MockTransferService_CreateTransfer_Call) RunAndReturn(run func(ctx context.Context, req transfers.TransferRequest) (string, error)) *MockTransferService_CreateTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTransferGroup provides a mock function for the type MockTransferService
+func (_mock *MockTransferService) CreateTransferGroup(ctx context.Context, req transfers.TransferGroupRequest) (models.TransferGroup, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTransferGroup")
+	}
+
+	var r0 models.TransferGroup
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, transfers.TransferGroupRequest) (models.TransferGroup, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, transfers.TransferGroupRequest) models.TransferGroup); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Get(0).(models.TransferGroup)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, transfers.TransferGroupRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransferService_CreateTransferGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTransferGroup'
+type MockTransferService_CreateTransferGroup_Call struct {
+	*mock.Call
+}
+
+// CreateTransferGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req transfers.TransferGroupRequest
+func (_e *MockTransferService_Expecter) CreateTransferGroup(ctx interface{}, req interface{}) *MockTransferService_CreateTransferGroup_Call {
+	return &MockTransferService_CreateTransferGroup_Call{Call: _e.mock.On("CreateTransferGroup", ctx, req)}
+}
+
+func (_c *MockTransferService_CreateTransferGroup_Call) Run(run func(ctx context.Context, req transfers.TransferGroupRequest)) *MockTransferService_CreateTransferGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 transfers.TransferGroupRequest
+		if args[1] != nil {
+			arg1 = args[1].(transfers.TransferGroupRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferService_CreateTransferGroup_Call) Return(transferGroup models.TransferGroup, err error) *MockTransferService_CreateTransferGroup_Call {
+	_c.Call.Return(transferGroup, err)
+	return _c
+}
+
+func (_c *MockTransferService_CreateTransferGroup_Call) RunAndReturn(run func(ctx context.Context, req transfers.TransferGroupRequest) (models.TransferGroup, error)) *MockTransferService_CreateTransferGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecuteScheduledTransfer provides a mock function for the type MockTransferService
+func (_mock *MockTransferService) ExecuteScheduledTransfer(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteScheduledTransfer")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTransferService_ExecuteScheduledTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteScheduledTransfer'
+type MockTransferService_ExecuteScheduledTransfer_Call struct {
+	*mock.Call
+}
+
+// ExecuteScheduledTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockTransferService_Expecter) ExecuteScheduledTransfer(ctx interface{}, id interface{}) *MockTransferService_ExecuteScheduledTransfer_Call {
+	return &MockTransferService_ExecuteScheduledTransfer_Call{Call: _e.mock.On("ExecuteScheduledTransfer", ctx, id)}
+}
+
+func (_c *MockTransferService_ExecuteScheduledTransfer_Call) Run(run func(ctx context.Context, id string)) *MockTransferService_ExecuteScheduledTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferService_ExecuteScheduledTransfer_Call) Return(err error) *MockTransferService_ExecuteScheduledTransfer_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTransferService_ExecuteScheduledTransfer_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockTransferService_ExecuteScheduledTransfer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetAccountBalance provides a mock function for the type MockTransferService
-func (_mock *MockTransferService) GetAccountBalance(id string) (float64, error) {
-	ret := _mock.Called(id)
+func (_mock *MockTransferService) GetAccountBalance(ctx context.Context, id string) ([]models.AccountBalance, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAccountBalance")
 	}
 
-	var r0 float64
+	var r0 []models.AccountBalance
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (float64, error)); ok {
-		return returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]models.AccountBalance, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) float64); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []models.AccountBalance); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Get(0).(float64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccountBalance)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -129,37 +327,121 @@ type MockTransferService_GetAccountBalance_Call struct {
 }
 
 // GetAccountBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockTransferService_Expecter) GetAccountBalance(ctx interface{}, id interface{}) *MockTransferService_GetAccountBalance_Call {
+	return &MockTransferService_GetAccountBalance_Call{Call: _e.mock.On("GetAccountBalance", ctx, id)}
+}
+
+func (_c *MockTransferService_GetAccountBalance_Call) Run(run func(ctx context.Context, id string)) *MockTransferService_GetAccountBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferService_GetAccountBalance_Call) Return(accountBalances []models.AccountBalance, err error) *MockTransferService_GetAccountBalance_Call {
+	_c.Call.Return(accountBalances, err)
+	return _c
+}
+
+func (_c *MockTransferService_GetAccountBalance_Call) RunAndReturn(run func(ctx context.Context, id string) ([]models.AccountBalance, error)) *MockTransferService_GetAccountBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountStatement provides a mock function for the type MockTransferService
+func (_mock *MockTransferService) GetAccountStatement(ctx context.Context, id string, from time.Time, to time.Time) (models.Statement, error) {
+	ret := _mock.Called(ctx, id, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountStatement")
+	}
+
+	var r0 models.Statement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (models.Statement, error)); ok {
+		return returnFunc(ctx, id, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) models.Statement); ok {
+		r0 = returnFunc(ctx, id, from, to)
+	} else {
+		r0 = ret.Get(0).(models.Statement)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransferService_GetAccountStatement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountStatement'
+type MockTransferService_GetAccountStatement_Call struct {
+	*mock.Call
+}
+
+// GetAccountStatement is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id string
-func (_e *MockTransferService_Expecter) GetAccountBalance(id interface{}) *MockTransferService_GetAccountBalance_Call {
-	return &MockTransferService_GetAccountBalance_Call{Call: _e.mock.On("GetAccountBalance", id)}
+//   - from time.Time
+//   - to time.Time
+func (_e *MockTransferService_Expecter) GetAccountStatement(ctx interface{}, id interface{}, from interface{}, to interface{}) *MockTransferService_GetAccountStatement_Call {
+	return &MockTransferService_GetAccountStatement_Call{Call: _e.mock.On("GetAccountStatement", ctx, id, from, to)}
 }
 
-func (_c *MockTransferService_GetAccountBalance_Call) Run(run func(id string)) *MockTransferService_GetAccountBalance_Call {
+func (_c *MockTransferService_GetAccountStatement_Call) Run(run func(ctx context.Context, id string, from time.Time, to time.Time)) *MockTransferService_GetAccountStatement_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTransferService_GetAccountBalance_Call) Return(f float64, err error) *MockTransferService_GetAccountBalance_Call {
-	_c.Call.Return(f, err)
+func (_c *MockTransferService_GetAccountStatement_Call) Return(statement models.Statement, err error) *MockTransferService_GetAccountStatement_Call {
+	_c.Call.Return(statement, err)
 	return _c
 }
 
-func (_c *MockTransferService_GetAccountBalance_Call) RunAndReturn(run func(id string) (float64, error)) *MockTransferService_GetAccountBalance_Call {
+func (_c *MockTransferService_GetAccountStatement_Call) RunAndReturn(run func(ctx context.Context, id string, from time.Time, to time.Time) (models.Statement, error)) *MockTransferService_GetAccountStatement_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetTransfer provides a mock function for the type MockTransferService
-func (_mock *MockTransferService) GetTransfer(id string) (models.Transfer, error) {
-	ret := _mock.Called(id)
+func (_mock *MockTransferService) GetTransfer(ctx context.Context, id string) (models.Transfer, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTransfer")
@@ -167,16 +449,16 @@ func (_mock *MockTransferService) GetTransfer(id string) (models.Transfer, error
 
 	var r0 models.Transfer
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (models.Transfer, error)); ok {
-		return returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.Transfer, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) models.Transfer); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.Transfer); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Get(0).(models.Transfer)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -189,19 +471,25 @@ type MockTransferService_GetTransfer_Call struct {
 }
 
 // GetTransfer is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id string
-func (_e *MockTransferService_Expecter) GetTransfer(id interface{}) *MockTransferService_GetTransfer_Call {
-	return &MockTransferService_GetTransfer_Call{Call: _e.mock.On("GetTransfer", id)}
+func (_e *MockTransferService_Expecter) GetTransfer(ctx interface{}, id interface{}) *MockTransferService_GetTransfer_Call {
+	return &MockTransferService_GetTransfer_Call{Call: _e.mock.On("GetTransfer", ctx, id)}
 }
 
-func (_c *MockTransferService_GetTransfer_Call) Run(run func(id string)) *MockTransferService_GetTransfer_Call {
+func (_c *MockTransferService_GetTransfer_Call) Run(run func(ctx context.Context, id string)) *MockTransferService_GetTransfer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -212,45 +500,54 @@ func (_c *MockTransferService_GetTransfer_Call) Return(transfer models.Transfer,
 	return _c
 }
 
-func (_c *MockTransferService_GetTransfer_Call) RunAndReturn(run func(id string) (models.Transfer, error)) *MockTransferService_GetTransfer_Call {
+func (_c *MockTransferService_GetTransfer_Call) RunAndReturn(run func(ctx context.Context, id string) (models.Transfer, error)) *MockTransferService_GetTransfer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateTransfer provides a mock function for the type MockTransferService
-func (_mock *MockTransferService) UpdateTransfer(id string, status string) error {
-	ret := _mock.Called(id, status)
+// GetTransferGroup provides a mock function for the type MockTransferService
+func (_mock *MockTransferService) GetTransferGroup(ctx context.Context, id string) (models.TransferGroup, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateTransfer")
+		panic("no return value specified for GetTransferGroup")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = returnFunc(id, status)
+	var r0 models.TransferGroup
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.TransferGroup, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.TransferGroup); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(models.TransferGroup)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockTransferService_UpdateTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTransfer'
-type MockTransferService_UpdateTransfer_Call struct {
+// MockTransferService_GetTransferGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransferGroup'
+type MockTransferService_GetTransferGroup_Call struct {
 	*mock.Call
 }
 
-// UpdateTransfer is a helper method to define mock.On call
+// GetTransferGroup is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id string
-//   - status string
-func (_e *MockTransferService_Expecter) UpdateTransfer(id interface{}, status interface{}) *MockTransferService_UpdateTransfer_Call {
-	return &MockTransferService_UpdateTransfer_Call{Call: _e.mock.On("UpdateTransfer", id, status)}
+func (_e *MockTransferService_Expecter) GetTransferGroup(ctx interface{}, id interface{}) *MockTransferService_GetTransferGroup_Call {
+	return &MockTransferService_GetTransferGroup_Call{Call: _e.mock.On("GetTransferGroup", ctx, id)}
 }
 
-func (_c *MockTransferService_UpdateTransfer_Call) Run(run func(id string, status string)) *MockTransferService_UpdateTransfer_Call {
+func (_c *MockTransferService_GetTransferGroup_Call) Run(run func(ctx context.Context, id string)) *MockTransferService_GetTransferGroup_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
 		}
 		var arg1 string
 		if args[1] != nil {
@@ -264,12 +561,1741 @@ func (_c *MockTransferService_UpdateTransfer_Call) Run(run func(id string, statu
 	return _c
 }
 
-func (_c *MockTransferService_UpdateTransfer_Call) Return(err error) *MockTransferService_UpdateTransfer_Call {
+func (_c *MockTransferService_GetTransferGroup_Call) Return(transferGroup models.TransferGroup, err error) *MockTransferService_GetTransferGroup_Call {
+	_c.Call.Return(transferGroup, err)
+	return _c
+}
+
+func (_c *MockTransferService_GetTransferGroup_Call) RunAndReturn(run func(ctx context.Context, id string) (models.TransferGroup, error)) *MockTransferService_GetTransferGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransferHistory provides a mock function for the type MockTransferService
+func (_mock *MockTransferService) GetTransferHistory(ctx context.Context, id string) ([]models.TransferStatusHistory, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransferHistory")
+	}
+
+	var r0 []models.TransferStatusHistory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]models.TransferStatusHistory, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []models.TransferStatusHistory); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TransferStatusHistory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransferService_GetTransferHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransferHistory'
+type MockTransferService_GetTransferHistory_Call struct {
+	*mock.Call
+}
+
+// GetTransferHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockTransferService_Expecter) GetTransferHistory(ctx interface{}, id interface{}) *MockTransferService_GetTransferHistory_Call {
+	return &MockTransferService_GetTransferHistory_Call{Call: _e.mock.On("GetTransferHistory", ctx, id)}
+}
+
+func (_c *MockTransferService_GetTransferHistory_Call) Run(run func(ctx context.Context, id string)) *MockTransferService_GetTransferHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferService_GetTransferHistory_Call) Return(transferStatusHistorys []models.TransferStatusHistory, err error) *MockTransferService_GetTransferHistory_Call {
+	_c.Call.Return(transferStatusHistorys, err)
+	return _c
+}
+
+func (_c *MockTransferService_GetTransferHistory_Call) RunAndReturn(run func(ctx context.Context, id string) ([]models.TransferStatusHistory, error)) *MockTransferService_GetTransferHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListScheduledTransfers provides a mock function for the type MockTransferService
+func (_mock *MockTransferService) ListScheduledTransfers(ctx context.Context, accounts []string, limit int) ([]models.Transfer, error) {
+	ret := _mock.Called(ctx, accounts, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListScheduledTransfers")
+	}
+
+	var r0 []models.Transfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, int) ([]models.Transfer, error)); ok {
+		return returnFunc(ctx, accounts, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, int) []models.Transfer); ok {
+		r0 = returnFunc(ctx, accounts, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Transfer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, int) error); ok {
+		r1 = returnFunc(ctx, accounts, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransferService_ListScheduledTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListScheduledTransfers'
+type MockTransferService_ListScheduledTransfers_Call struct {
+	*mock.Call
+}
+
+// ListScheduledTransfers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accounts []string
+//   - limit int
+func (_e *MockTransferService_Expecter) ListScheduledTransfers(ctx interface{}, accounts interface{}, limit interface{}) *MockTransferService_ListScheduledTransfers_Call {
+	return &MockTransferService_ListScheduledTransfers_Call{Call: _e.mock.On("ListScheduledTransfers", ctx, accounts, limit)}
+}
+
+func (_c *MockTransferService_ListScheduledTransfers_Call) Run(run func(ctx context.Context, accounts []string, limit int)) *MockTransferService_ListScheduledTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferService_ListScheduledTransfers_Call) Return(transfers1 []models.Transfer, err error) *MockTransferService_ListScheduledTransfers_Call {
+	_c.Call.Return(transfers1, err)
+	return _c
+}
+
+func (_c *MockTransferService_ListScheduledTransfers_Call) RunAndReturn(run func(ctx context.Context, accounts []string, limit int) ([]models.Transfer, error)) *MockTransferService_ListScheduledTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTransfers provides a mock function for the type MockTransferService
+func (_mock *MockTransferService) ListTransfers(ctx context.Context, filter models.TransferFilter) (models.TransferPage, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransfers")
+	}
+
+	var r0 models.TransferPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransferFilter) (models.TransferPage, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransferFilter) models.TransferPage); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(models.TransferPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.TransferFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransferService_ListTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTransfers'
+type MockTransferService_ListTransfers_Call struct {
+	*mock.Call
+}
+
+// ListTransfers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter models.TransferFilter
+func (_e *MockTransferService_Expecter) ListTransfers(ctx interface{}, filter interface{}) *MockTransferService_ListTransfers_Call {
+	return &MockTransferService_ListTransfers_Call{Call: _e.mock.On("ListTransfers", ctx, filter)}
+}
+
+func (_c *MockTransferService_ListTransfers_Call) Run(run func(ctx context.Context, filter models.TransferFilter)) *MockTransferService_ListTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.TransferFilter
+		if args[1] != nil {
+			arg1 = args[1].(models.TransferFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferService_ListTransfers_Call) Return(transferPage models.TransferPage, err error) *MockTransferService_ListTransfers_Call {
+	_c.Call.Return(transferPage, err)
+	return _c
+}
+
+func (_c *MockTransferService_ListTransfers_Call) RunAndReturn(run func(ctx context.Context, filter models.TransferFilter) (models.TransferPage, error)) *MockTransferService_ListTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefundTransfer provides a mock function for the type MockTransferService
+func (_mock *MockTransferService) RefundTransfer(ctx context.Context, id string, req transfers.RefundRequest, change models.StatusChange) (models.Transfer, error) {
+	ret := _mock.Called(ctx, id, req, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundTransfer")
+	}
+
+	var r0 models.Transfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, transfers.RefundRequest, models.StatusChange) (models.Transfer, error)); ok {
+		return returnFunc(ctx, id, req, change)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, transfers.RefundRequest, models.StatusChange) models.Transfer); ok {
+		r0 = returnFunc(ctx, id, req, change)
+	} else {
+		r0 = ret.Get(0).(models.Transfer)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, transfers.RefundRequest, models.StatusChange) error); ok {
+		r1 = returnFunc(ctx, id, req, change)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransferService_RefundTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefundTransfer'
+type MockTransferService_RefundTransfer_Call struct {
+	*mock.Call
+}
+
+// RefundTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - req transfers.RefundRequest
+//   - change models.StatusChange
+func (_e *MockTransferService_Expecter) RefundTransfer(ctx interface{}, id interface{}, req interface{}, change interface{}) *MockTransferService_RefundTransfer_Call {
+	return &MockTransferService_RefundTransfer_Call{Call: _e.mock.On("RefundTransfer", ctx, id, req, change)}
+}
+
+func (_c *MockTransferService_RefundTransfer_Call) Run(run func(ctx context.Context, id string, req transfers.RefundRequest, change models.StatusChange)) *MockTransferService_RefundTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 transfers.RefundRequest
+		if args[2] != nil {
+			arg2 = args[2].(transfers.RefundRequest)
+		}
+		var arg3 models.StatusChange
+		if args[3] != nil {
+			arg3 = args[3].(models.StatusChange)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferService_RefundTransfer_Call) Return(transfer models.Transfer, err error) *MockTransferService_RefundTransfer_Call {
+	_c.Call.Return(transfer, err)
+	return _c
+}
+
+func (_c *MockTransferService_RefundTransfer_Call) RunAndReturn(run func(ctx context.Context, id string, req transfers.RefundRequest, change models.StatusChange) (models.Transfer, error)) *MockTransferService_RefundTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateScheduledTransfer provides a mock function for the type MockTransferService
+func (_mock *MockTransferService) UpdateScheduledTransfer(ctx context.Context, id string, req transfers.ScheduledTransferUpdate, ifMatch []uint) (models.Transfer, error) {
+	ret := _mock.Called(ctx, id, req, ifMatch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateScheduledTransfer")
+	}
+
+	var r0 models.Transfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, transfers.ScheduledTransferUpdate, []uint) (models.Transfer, error)); ok {
+		return returnFunc(ctx, id, req, ifMatch)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, transfers.ScheduledTransferUpdate, []uint) models.Transfer); ok {
+		r0 = returnFunc(ctx, id, req, ifMatch)
+	} else {
+		r0 = ret.Get(0).(models.Transfer)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, transfers.ScheduledTransferUpdate, []uint) error); ok {
+		r1 = returnFunc(ctx, id, req, ifMatch)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransferService_UpdateScheduledTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateScheduledTransfer'
+type MockTransferService_UpdateScheduledTransfer_Call struct {
+	*mock.Call
+}
+
+// UpdateScheduledTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - req transfers.ScheduledTransferUpdate
+//   - ifMatch []uint
+func (_e *MockTransferService_Expecter) UpdateScheduledTransfer(ctx interface{}, id interface{}, req interface{}, ifMatch interface{}) *MockTransferService_UpdateScheduledTransfer_Call {
+	return &MockTransferService_UpdateScheduledTransfer_Call{Call: _e.mock.On("UpdateScheduledTransfer", ctx, id, req, ifMatch)}
+}
+
+func (_c *MockTransferService_UpdateScheduledTransfer_Call) Run(run func(ctx context.Context, id string, req transfers.ScheduledTransferUpdate, ifMatch []uint)) *MockTransferService_UpdateScheduledTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 transfers.ScheduledTransferUpdate
+		if args[2] != nil {
+			arg2 = args[2].(transfers.ScheduledTransferUpdate)
+		}
+		var arg3 []uint
+		if args[3] != nil {
+			arg3 = args[3].([]uint)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferService_UpdateScheduledTransfer_Call) Return(transfer models.Transfer, err error) *MockTransferService_UpdateScheduledTransfer_Call {
+	_c.Call.Return(transfer, err)
+	return _c
+}
+
+func (_c *MockTransferService_UpdateScheduledTransfer_Call) RunAndReturn(run func(ctx context.Context, id string, req transfers.ScheduledTransferUpdate, ifMatch []uint) (models.Transfer, error)) *MockTransferService_UpdateScheduledTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTransfer provides a mock function for the type MockTransferService
+func (_mock *MockTransferService) UpdateTransfer(ctx context.Context, id string, change models.StatusChange) error {
+	ret := _mock.Called(ctx, id, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTransfer")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.StatusChange) error); ok {
+		r0 = returnFunc(ctx, id, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTransferService_UpdateTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTransfer'
+type MockTransferService_UpdateTransfer_Call struct {
+	*mock.Call
+}
+
+// UpdateTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - change models.StatusChange
+func (_e *MockTransferService_Expecter) UpdateTransfer(ctx interface{}, id interface{}, change interface{}) *MockTransferService_UpdateTransfer_Call {
+	return &MockTransferService_UpdateTransfer_Call{Call: _e.mock.On("UpdateTransfer", ctx, id, change)}
+}
+
+func (_c *MockTransferService_UpdateTransfer_Call) Run(run func(ctx context.Context, id string, change models.StatusChange)) *MockTransferService_UpdateTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 models.StatusChange
+		if args[2] != nil {
+			arg2 = args[2].(models.StatusChange)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferService_UpdateTransfer_Call) Return(err error) *MockTransferService_UpdateTransfer_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTransferService_UpdateTransfer_Call) RunAndReturn(run func(ctx context.Context, id string, change models.StatusChange) error) *MockTransferService_UpdateTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAccountService creates a new instance of MockAccountService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAccountService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAccountService {
+	mock := &MockAccountService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAccountService is an autogenerated mock type for the AccountService type
+type MockAccountService struct {
+	mock.Mock
+}
+
+type MockAccountService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAccountService) EXPECT() *MockAccountService_Expecter {
+	return &MockAccountService_Expecter{mock: &_m.Mock}
+}
+
+// CloseAccount provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) CloseAccount(ctx context.Context, id string) (models.Account, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseAccount")
+	}
+
+	var r0 models.Account
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.Account, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.Account); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Account)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_CloseAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseAccount'
+type MockAccountService_CloseAccount_Call struct {
+	*mock.Call
+}
+
+// CloseAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockAccountService_Expecter) CloseAccount(ctx interface{}, id interface{}) *MockAccountService_CloseAccount_Call {
+	return &MockAccountService_CloseAccount_Call{Call: _e.mock.On("CloseAccount", ctx, id)}
+}
+
+func (_c *MockAccountService_CloseAccount_Call) Run(run func(ctx context.Context, id string)) *MockAccountService_CloseAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_CloseAccount_Call) Return(account models.Account, err error) *MockAccountService_CloseAccount_Call {
+	_c.Call.Return(account, err)
+	return _c
+}
+
+func (_c *MockAccountService_CloseAccount_Call) RunAndReturn(run func(ctx context.Context, id string) (models.Account, error)) *MockAccountService_CloseAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FreezeAccount provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) FreezeAccount(ctx context.Context, id string) (models.Account, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FreezeAccount")
+	}
+
+	var r0 models.Account
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.Account, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.Account); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Account)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_FreezeAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FreezeAccount'
+type MockAccountService_FreezeAccount_Call struct {
+	*mock.Call
+}
+
+// FreezeAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockAccountService_Expecter) FreezeAccount(ctx interface{}, id interface{}) *MockAccountService_FreezeAccount_Call {
+	return &MockAccountService_FreezeAccount_Call{Call: _e.mock.On("FreezeAccount", ctx, id)}
+}
+
+func (_c *MockAccountService_FreezeAccount_Call) Run(run func(ctx context.Context, id string)) *MockAccountService_FreezeAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_FreezeAccount_Call) Return(account models.Account, err error) *MockAccountService_FreezeAccount_Call {
+	_c.Call.Return(account, err)
+	return _c
+}
+
+func (_c *MockAccountService_FreezeAccount_Call) RunAndReturn(run func(ctx context.Context, id string) (models.Account, error)) *MockAccountService_FreezeAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccount provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) GetAccount(ctx context.Context, id string) (models.Account, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccount")
+	}
+
+	var r0 models.Account
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.Account, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.Account); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Account)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_GetAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccount'
+type MockAccountService_GetAccount_Call struct {
+	*mock.Call
+}
+
+// GetAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockAccountService_Expecter) GetAccount(ctx interface{}, id interface{}) *MockAccountService_GetAccount_Call {
+	return &MockAccountService_GetAccount_Call{Call: _e.mock.On("GetAccount", ctx, id)}
+}
+
+func (_c *MockAccountService_GetAccount_Call) Run(run func(ctx context.Context, id string)) *MockAccountService_GetAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_GetAccount_Call) Return(account models.Account, err error) *MockAccountService_GetAccount_Call {
+	_c.Call.Return(account, err)
+	return _c
+}
+
+func (_c *MockAccountService_GetAccount_Call) RunAndReturn(run func(ctx context.Context, id string) (models.Account, error)) *MockAccountService_GetAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenAccount provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) OpenAccount(ctx context.Context, req transfers.OpenAccountRequest) (models.Account, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenAccount")
+	}
+
+	var r0 models.Account
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, transfers.OpenAccountRequest) (models.Account, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, transfers.OpenAccountRequest) models.Account); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Get(0).(models.Account)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, transfers.OpenAccountRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_OpenAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenAccount'
+type MockAccountService_OpenAccount_Call struct {
+	*mock.Call
+}
+
+// OpenAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req transfers.OpenAccountRequest
+func (_e *MockAccountService_Expecter) OpenAccount(ctx interface{}, req interface{}) *MockAccountService_OpenAccount_Call {
+	return &MockAccountService_OpenAccount_Call{Call: _e.mock.On("OpenAccount", ctx, req)}
+}
+
+func (_c *MockAccountService_OpenAccount_Call) Run(run func(ctx context.Context, req transfers.OpenAccountRequest)) *MockAccountService_OpenAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 transfers.OpenAccountRequest
+		if args[1] != nil {
+			arg1 = args[1].(transfers.OpenAccountRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_OpenAccount_Call) Return(account models.Account, err error) *MockAccountService_OpenAccount_Call {
+	_c.Call.Return(account, err)
+	return _c
+}
+
+func (_c *MockAccountService_OpenAccount_Call) RunAndReturn(run func(ctx context.Context, req transfers.OpenAccountRequest) (models.Account, error)) *MockAccountService_OpenAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnfreezeAccount provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) UnfreezeAccount(ctx context.Context, id string) (models.Account, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnfreezeAccount")
+	}
+
+	var r0 models.Account
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.Account, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.Account); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Account)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_UnfreezeAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnfreezeAccount'
+type MockAccountService_UnfreezeAccount_Call struct {
+	*mock.Call
+}
+
+// UnfreezeAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockAccountService_Expecter) UnfreezeAccount(ctx interface{}, id interface{}) *MockAccountService_UnfreezeAccount_Call {
+	return &MockAccountService_UnfreezeAccount_Call{Call: _e.mock.On("UnfreezeAccount", ctx, id)}
+}
+
+func (_c *MockAccountService_UnfreezeAccount_Call) Run(run func(ctx context.Context, id string)) *MockAccountService_UnfreezeAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_UnfreezeAccount_Call) Return(account models.Account, err error) *MockAccountService_UnfreezeAccount_Call {
+	_c.Call.Return(account, err)
+	return _c
+}
+
+func (_c *MockAccountService_UnfreezeAccount_Call) RunAndReturn(run func(ctx context.Context, id string) (models.Account, error)) *MockAccountService_UnfreezeAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStandingOrderService creates a new instance of MockStandingOrderService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStandingOrderService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStandingOrderService {
+	mock := &MockStandingOrderService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStandingOrderService is an autogenerated mock type for the StandingOrderService type
+type MockStandingOrderService struct {
+	mock.Mock
+}
+
+type MockStandingOrderService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStandingOrderService) EXPECT() *MockStandingOrderService_Expecter {
+	return &MockStandingOrderService_Expecter{mock: &_m.Mock}
+}
+
+// CancelStandingOrder provides a mock function for the type MockStandingOrderService
+func (_mock *MockStandingOrderService) CancelStandingOrder(ctx context.Context, id string) (models.StandingOrder, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelStandingOrder")
+	}
+
+	var r0 models.StandingOrder
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.StandingOrder, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.StandingOrder); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.StandingOrder)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStandingOrderService_CancelStandingOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelStandingOrder'
+type MockStandingOrderService_CancelStandingOrder_Call struct {
+	*mock.Call
+}
+
+// CancelStandingOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStandingOrderService_Expecter) CancelStandingOrder(ctx interface{}, id interface{}) *MockStandingOrderService_CancelStandingOrder_Call {
+	return &MockStandingOrderService_CancelStandingOrder_Call{Call: _e.mock.On("CancelStandingOrder", ctx, id)}
+}
+
+func (_c *MockStandingOrderService_CancelStandingOrder_Call) Run(run func(ctx context.Context, id string)) *MockStandingOrderService_CancelStandingOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderService_CancelStandingOrder_Call) Return(standingOrder models.StandingOrder, err error) *MockStandingOrderService_CancelStandingOrder_Call {
+	_c.Call.Return(standingOrder, err)
+	return _c
+}
+
+func (_c *MockStandingOrderService_CancelStandingOrder_Call) RunAndReturn(run func(ctx context.Context, id string) (models.StandingOrder, error)) *MockStandingOrderService_CancelStandingOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateStandingOrder provides a mock function for the type MockStandingOrderService
+func (_mock *MockStandingOrderService) CreateStandingOrder(ctx context.Context, req transfers.StandingOrderRequest, createdBy string) (models.StandingOrder, error) {
+	ret := _mock.Called(ctx, req, createdBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStandingOrder")
+	}
+
+	var r0 models.StandingOrder
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, transfers.StandingOrderRequest, string) (models.StandingOrder, error)); ok {
+		return returnFunc(ctx, req, createdBy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, transfers.StandingOrderRequest, string) models.StandingOrder); ok {
+		r0 = returnFunc(ctx, req, createdBy)
+	} else {
+		r0 = ret.Get(0).(models.StandingOrder)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, transfers.StandingOrderRequest, string) error); ok {
+		r1 = returnFunc(ctx, req, createdBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStandingOrderService_CreateStandingOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStandingOrder'
+type MockStandingOrderService_CreateStandingOrder_Call struct {
+	*mock.Call
+}
+
+// CreateStandingOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req transfers.StandingOrderRequest
+//   - createdBy string
+func (_e *MockStandingOrderService_Expecter) CreateStandingOrder(ctx interface{}, req interface{}, createdBy interface{}) *MockStandingOrderService_CreateStandingOrder_Call {
+	return &MockStandingOrderService_CreateStandingOrder_Call{Call: _e.mock.On("CreateStandingOrder", ctx, req, createdBy)}
+}
+
+func (_c *MockStandingOrderService_CreateStandingOrder_Call) Run(run func(ctx context.Context, req transfers.StandingOrderRequest, createdBy string)) *MockStandingOrderService_CreateStandingOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 transfers.StandingOrderRequest
+		if args[1] != nil {
+			arg1 = args[1].(transfers.StandingOrderRequest)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderService_CreateStandingOrder_Call) Return(standingOrder models.StandingOrder, err error) *MockStandingOrderService_CreateStandingOrder_Call {
+	_c.Call.Return(standingOrder, err)
+	return _c
+}
+
+func (_c *MockStandingOrderService_CreateStandingOrder_Call) RunAndReturn(run func(ctx context.Context, req transfers.StandingOrderRequest, createdBy string) (models.StandingOrder, error)) *MockStandingOrderService_CreateStandingOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStandingOrder provides a mock function for the type MockStandingOrderService
+func (_mock *MockStandingOrderService) GetStandingOrder(ctx context.Context, id string) (models.StandingOrder, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStandingOrder")
+	}
+
+	var r0 models.StandingOrder
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.StandingOrder, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.StandingOrder); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.StandingOrder)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStandingOrderService_GetStandingOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStandingOrder'
+type MockStandingOrderService_GetStandingOrder_Call struct {
+	*mock.Call
+}
+
+// GetStandingOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStandingOrderService_Expecter) GetStandingOrder(ctx interface{}, id interface{}) *MockStandingOrderService_GetStandingOrder_Call {
+	return &MockStandingOrderService_GetStandingOrder_Call{Call: _e.mock.On("GetStandingOrder", ctx, id)}
+}
+
+func (_c *MockStandingOrderService_GetStandingOrder_Call) Run(run func(ctx context.Context, id string)) *MockStandingOrderService_GetStandingOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderService_GetStandingOrder_Call) Return(standingOrder models.StandingOrder, err error) *MockStandingOrderService_GetStandingOrder_Call {
+	_c.Call.Return(standingOrder, err)
+	return _c
+}
+
+func (_c *MockStandingOrderService_GetStandingOrder_Call) RunAndReturn(run func(ctx context.Context, id string) (models.StandingOrder, error)) *MockStandingOrderService_GetStandingOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListExecutions provides a mock function for the type MockStandingOrderService
+func (_mock *MockStandingOrderService) ListExecutions(ctx context.Context, id string, limit int) ([]models.StandingOrderExecution, error) {
+	ret := _mock.Called(ctx, id, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExecutions")
+	}
+
+	var r0 []models.StandingOrderExecution
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]models.StandingOrderExecution, error)); ok {
+		return returnFunc(ctx, id, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []models.StandingOrderExecution); ok {
+		r0 = returnFunc(ctx, id, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StandingOrderExecution)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStandingOrderService_ListExecutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExecutions'
+type MockStandingOrderService_ListExecutions_Call struct {
+	*mock.Call
+}
+
+// ListExecutions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - limit int
+func (_e *MockStandingOrderService_Expecter) ListExecutions(ctx interface{}, id interface{}, limit interface{}) *MockStandingOrderService_ListExecutions_Call {
+	return &MockStandingOrderService_ListExecutions_Call{Call: _e.mock.On("ListExecutions", ctx, id, limit)}
+}
+
+func (_c *MockStandingOrderService_ListExecutions_Call) Run(run func(ctx context.Context, id string, limit int)) *MockStandingOrderService_ListExecutions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderService_ListExecutions_Call) Return(standingOrderExecutions []models.StandingOrderExecution, err error) *MockStandingOrderService_ListExecutions_Call {
+	_c.Call.Return(standingOrderExecutions, err)
+	return _c
+}
+
+func (_c *MockStandingOrderService_ListExecutions_Call) RunAndReturn(run func(ctx context.Context, id string, limit int) ([]models.StandingOrderExecution, error)) *MockStandingOrderService_ListExecutions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStandingOrders provides a mock function for the type MockStandingOrderService
+func (_mock *MockStandingOrderService) ListStandingOrders(ctx context.Context, accounts []string, limit int) ([]models.StandingOrder, error) {
+	ret := _mock.Called(ctx, accounts, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStandingOrders")
+	}
+
+	var r0 []models.StandingOrder
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, int) ([]models.StandingOrder, error)); ok {
+		return returnFunc(ctx, accounts, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, int) []models.StandingOrder); ok {
+		r0 = returnFunc(ctx, accounts, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StandingOrder)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, int) error); ok {
+		r1 = returnFunc(ctx, accounts, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStandingOrderService_ListStandingOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStandingOrders'
+type MockStandingOrderService_ListStandingOrders_Call struct {
+	*mock.Call
+}
+
+// ListStandingOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accounts []string
+//   - limit int
+func (_e *MockStandingOrderService_Expecter) ListStandingOrders(ctx interface{}, accounts interface{}, limit interface{}) *MockStandingOrderService_ListStandingOrders_Call {
+	return &MockStandingOrderService_ListStandingOrders_Call{Call: _e.mock.On("ListStandingOrders", ctx, accounts, limit)}
+}
+
+func (_c *MockStandingOrderService_ListStandingOrders_Call) Run(run func(ctx context.Context, accounts []string, limit int)) *MockStandingOrderService_ListStandingOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderService_ListStandingOrders_Call) Return(standingOrders []models.StandingOrder, err error) *MockStandingOrderService_ListStandingOrders_Call {
+	_c.Call.Return(standingOrders, err)
+	return _c
+}
+
+func (_c *MockStandingOrderService_ListStandingOrders_Call) RunAndReturn(run func(ctx context.Context, accounts []string, limit int) ([]models.StandingOrder, error)) *MockStandingOrderService_ListStandingOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseStandingOrder provides a mock function for the type MockStandingOrderService
+func (_mock *MockStandingOrderService) PauseStandingOrder(ctx context.Context, id string) (models.StandingOrder, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseStandingOrder")
+	}
+
+	var r0 models.StandingOrder
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.StandingOrder, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.StandingOrder); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.StandingOrder)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStandingOrderService_PauseStandingOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseStandingOrder'
+type MockStandingOrderService_PauseStandingOrder_Call struct {
+	*mock.Call
+}
+
+// PauseStandingOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStandingOrderService_Expecter) PauseStandingOrder(ctx interface{}, id interface{}) *MockStandingOrderService_PauseStandingOrder_Call {
+	return &MockStandingOrderService_PauseStandingOrder_Call{Call: _e.mock.On("PauseStandingOrder", ctx, id)}
+}
+
+func (_c *MockStandingOrderService_PauseStandingOrder_Call) Run(run func(ctx context.Context, id string)) *MockStandingOrderService_PauseStandingOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderService_PauseStandingOrder_Call) Return(standingOrder models.StandingOrder, err error) *MockStandingOrderService_PauseStandingOrder_Call {
+	_c.Call.Return(standingOrder, err)
+	return _c
+}
+
+func (_c *MockStandingOrderService_PauseStandingOrder_Call) RunAndReturn(run func(ctx context.Context, id string) (models.StandingOrder, error)) *MockStandingOrderService_PauseStandingOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeStandingOrder provides a mock function for the type MockStandingOrderService
+func (_mock *MockStandingOrderService) ResumeStandingOrder(ctx context.Context, id string) (models.StandingOrder, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeStandingOrder")
+	}
+
+	var r0 models.StandingOrder
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.StandingOrder, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.StandingOrder); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.StandingOrder)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStandingOrderService_ResumeStandingOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeStandingOrder'
+type MockStandingOrderService_ResumeStandingOrder_Call struct {
+	*mock.Call
+}
+
+// ResumeStandingOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStandingOrderService_Expecter) ResumeStandingOrder(ctx interface{}, id interface{}) *MockStandingOrderService_ResumeStandingOrder_Call {
+	return &MockStandingOrderService_ResumeStandingOrder_Call{Call: _e.mock.On("ResumeStandingOrder", ctx, id)}
+}
+
+func (_c *MockStandingOrderService_ResumeStandingOrder_Call) Run(run func(ctx context.Context, id string)) *MockStandingOrderService_ResumeStandingOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderService_ResumeStandingOrder_Call) Return(standingOrder models.StandingOrder, err error) *MockStandingOrderService_ResumeStandingOrder_Call {
+	_c.Call.Return(standingOrder, err)
+	return _c
+}
+
+func (_c *MockStandingOrderService_ResumeStandingOrder_Call) RunAndReturn(run func(ctx context.Context, id string) (models.StandingOrder, error)) *MockStandingOrderService_ResumeStandingOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunStandingOrder provides a mock function for the type MockStandingOrderService
+func (_mock *MockStandingOrderService) RunStandingOrder(ctx context.Context, id string, jobID string) error {
+	ret := _mock.Called(ctx, id, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunStandingOrder")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, jobID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStandingOrderService_RunStandingOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunStandingOrder'
+type MockStandingOrderService_RunStandingOrder_Call struct {
+	*mock.Call
+}
+
+// RunStandingOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - jobID string
+func (_e *MockStandingOrderService_Expecter) RunStandingOrder(ctx interface{}, id interface{}, jobID interface{}) *MockStandingOrderService_RunStandingOrder_Call {
+	return &MockStandingOrderService_RunStandingOrder_Call{Call: _e.mock.On("RunStandingOrder", ctx, id, jobID)}
+}
+
+func (_c *MockStandingOrderService_RunStandingOrder_Call) Run(run func(ctx context.Context, id string, jobID string)) *MockStandingOrderService_RunStandingOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderService_RunStandingOrder_Call) Return(err error) *MockStandingOrderService_RunStandingOrder_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStandingOrderService_RunStandingOrder_Call) RunAndReturn(run func(ctx context.Context, id string, jobID string) error) *MockStandingOrderService_RunStandingOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBatchService creates a new instance of MockBatchService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBatchService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBatchService {
+	mock := &MockBatchService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBatchService is an autogenerated mock type for the BatchService type
+type MockBatchService struct {
+	mock.Mock
+}
+
+type MockBatchService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBatchService) EXPECT() *MockBatchService_Expecter {
+	return &MockBatchService_Expecter{mock: &_m.Mock}
+}
+
+// AbandonBatch provides a mock function for the type MockBatchService
+func (_mock *MockBatchService) AbandonBatch(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AbandonBatch")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBatchService_AbandonBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AbandonBatch'
+type MockBatchService_AbandonBatch_Call struct {
+	*mock.Call
+}
+
+// AbandonBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockBatchService_Expecter) AbandonBatch(ctx interface{}, id interface{}) *MockBatchService_AbandonBatch_Call {
+	return &MockBatchService_AbandonBatch_Call{Call: _e.mock.On("AbandonBatch", ctx, id)}
+}
+
+func (_c *MockBatchService_AbandonBatch_Call) Run(run func(ctx context.Context, id string)) *MockBatchService_AbandonBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchService_AbandonBatch_Call) Return(err error) *MockBatchService_AbandonBatch_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBatchService_AbandonBatch_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockBatchService_AbandonBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBatch provides a mock function for the type MockBatchService
+func (_mock *MockBatchService) CreateBatch(ctx context.Context, format string, rows []transfers.BatchRow, createdBy string) (models.TransferBatch, error) {
+	ret := _mock.Called(ctx, format, rows, createdBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 models.TransferBatch
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []transfers.BatchRow, string) (models.TransferBatch, error)); ok {
+		return returnFunc(ctx, format, rows, createdBy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []transfers.BatchRow, string) models.TransferBatch); ok {
+		r0 = returnFunc(ctx, format, rows, createdBy)
+	} else {
+		r0 = ret.Get(0).(models.TransferBatch)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []transfers.BatchRow, string) error); ok {
+		r1 = returnFunc(ctx, format, rows, createdBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchService_CreateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatch'
+type MockBatchService_CreateBatch_Call struct {
+	*mock.Call
+}
+
+// CreateBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - format string
+//   - rows []transfers.BatchRow
+//   - createdBy string
+func (_e *MockBatchService_Expecter) CreateBatch(ctx interface{}, format interface{}, rows interface{}, createdBy interface{}) *MockBatchService_CreateBatch_Call {
+	return &MockBatchService_CreateBatch_Call{Call: _e.mock.On("CreateBatch", ctx, format, rows, createdBy)}
+}
+
+func (_c *MockBatchService_CreateBatch_Call) Run(run func(ctx context.Context, format string, rows []transfers.BatchRow, createdBy string)) *MockBatchService_CreateBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []transfers.BatchRow
+		if args[2] != nil {
+			arg2 = args[2].([]transfers.BatchRow)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchService_CreateBatch_Call) Return(transferBatch models.TransferBatch, err error) *MockBatchService_CreateBatch_Call {
+	_c.Call.Return(transferBatch, err)
+	return _c
+}
+
+func (_c *MockBatchService_CreateBatch_Call) RunAndReturn(run func(ctx context.Context, format string, rows []transfers.BatchRow, createdBy string) (models.TransferBatch, error)) *MockBatchService_CreateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBatch provides a mock function for the type MockBatchService
+func (_mock *MockBatchService) GetBatch(ctx context.Context, id string) (models.TransferBatch, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatch")
+	}
+
+	var r0 models.TransferBatch
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.TransferBatch, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.TransferBatch); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.TransferBatch)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchService_GetBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatch'
+type MockBatchService_GetBatch_Call struct {
+	*mock.Call
+}
+
+// GetBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockBatchService_Expecter) GetBatch(ctx interface{}, id interface{}) *MockBatchService_GetBatch_Call {
+	return &MockBatchService_GetBatch_Call{Call: _e.mock.On("GetBatch", ctx, id)}
+}
+
+func (_c *MockBatchService_GetBatch_Call) Run(run func(ctx context.Context, id string)) *MockBatchService_GetBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchService_GetBatch_Call) Return(transferBatch models.TransferBatch, err error) *MockBatchService_GetBatch_Call {
+	_c.Call.Return(transferBatch, err)
+	return _c
+}
+
+func (_c *MockBatchService_GetBatch_Call) RunAndReturn(run func(ctx context.Context, id string) (models.TransferBatch, error)) *MockBatchService_GetBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBatchRows provides a mock function for the type MockBatchService
+func (_mock *MockBatchService) ListBatchRows(ctx context.Context, id string, status enums.BatchRowStatus, afterLine int, limit int) ([]models.TransferBatchRow, error) {
+	ret := _mock.Called(ctx, id, status, afterLine, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBatchRows")
+	}
+
+	var r0 []models.TransferBatchRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, enums.BatchRowStatus, int, int) ([]models.TransferBatchRow, error)); ok {
+		return returnFunc(ctx, id, status, afterLine, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, enums.BatchRowStatus, int, int) []models.TransferBatchRow); ok {
+		r0 = returnFunc(ctx, id, status, afterLine, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TransferBatchRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, enums.BatchRowStatus, int, int) error); ok {
+		r1 = returnFunc(ctx, id, status, afterLine, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchService_ListBatchRows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBatchRows'
+type MockBatchService_ListBatchRows_Call struct {
+	*mock.Call
+}
+
+// ListBatchRows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status enums.BatchRowStatus
+//   - afterLine int
+//   - limit int
+func (_e *MockBatchService_Expecter) ListBatchRows(ctx interface{}, id interface{}, status interface{}, afterLine interface{}, limit interface{}) *MockBatchService_ListBatchRows_Call {
+	return &MockBatchService_ListBatchRows_Call{Call: _e.mock.On("ListBatchRows", ctx, id, status, afterLine, limit)}
+}
+
+func (_c *MockBatchService_ListBatchRows_Call) Run(run func(ctx context.Context, id string, status enums.BatchRowStatus, afterLine int, limit int)) *MockBatchService_ListBatchRows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 enums.BatchRowStatus
+		if args[2] != nil {
+			arg2 = args[2].(enums.BatchRowStatus)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchService_ListBatchRows_Call) Return(transferBatchRows []models.TransferBatchRow, err error) *MockBatchService_ListBatchRows_Call {
+	_c.Call.Return(transferBatchRows, err)
+	return _c
+}
+
+func (_c *MockBatchService_ListBatchRows_Call) RunAndReturn(run func(ctx context.Context, id string, status enums.BatchRowStatus, afterLine int, limit int) ([]models.TransferBatchRow, error)) *MockBatchService_ListBatchRows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessBatch provides a mock function for the type MockBatchService
+func (_mock *MockBatchService) ProcessBatch(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessBatch")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBatchService_ProcessBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessBatch'
+type MockBatchService_ProcessBatch_Call struct {
+	*mock.Call
+}
+
+// ProcessBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockBatchService_Expecter) ProcessBatch(ctx interface{}, id interface{}) *MockBatchService_ProcessBatch_Call {
+	return &MockBatchService_ProcessBatch_Call{Call: _e.mock.On("ProcessBatch", ctx, id)}
+}
+
+func (_c *MockBatchService_ProcessBatch_Call) Run(run func(ctx context.Context, id string)) *MockBatchService_ProcessBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchService_ProcessBatch_Call) Return(err error) *MockBatchService_ProcessBatch_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockTransferService_UpdateTransfer_Call) RunAndReturn(run func(id string, status string) error) *MockTransferService_UpdateTransfer_Call {
+func (_c *MockBatchService_ProcessBatch_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockBatchService_ProcessBatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/middleware"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/problem"
+	"secure-payment-service/internal/service"
+	"secure-payment-service/internal/transfers"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StandingOrderController struct {
+	standingOrderService service.StandingOrderService
+}
+
+func NewStandingOrderController(svc service.StandingOrderService) *StandingOrderController {
+	return &StandingOrderController{standingOrderService: svc}
+}
+
+func (ctrl *StandingOrderController) CreateStandingOrder(c *gin.Context) {
+	var req transfers.StandingOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithBindingError(c, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	if !callerCanAccess(c, req.FromAccount) {
+		problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to move funds from account "+req.FromAccount)
+		return
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	order, err := ctrl.standingOrderService.CreateStandingOrder(c.Request.Context(), req, principal.Subject)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+func (ctrl *StandingOrderController) ListStandingOrders(c *gin.Context) {
+	var query transfers.ScheduledQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		abortWithBindingError(c, err)
+		return
+	}
+
+	filter, err := query.Filter()
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	if !restrictToCaller(c, &filter) {
+		return
+	}
+
+	orders, err := ctrl.standingOrderService.ListStandingOrders(c.Request.Context(), filter.Accounts, filter.Limit)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"standing_orders": orders})
+}
+
+func (ctrl *StandingOrderController) GetStandingOrder(c *gin.Context) {
+	order, ok := ctrl.authorize(c, false)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+func (ctrl *StandingOrderController) ListExecutions(c *gin.Context) {
+	var query transfers.ExecutionsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		abortWithBindingError(c, err)
+		return
+	}
+
+	limit, err := query.ParseLimit()
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	order, ok := ctrl.authorize(c, false)
+	if !ok {
+		return
+	}
+
+	executions, err := ctrl.standingOrderService.ListExecutions(c.Request.Context(), order.OrderID, limit)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"executions": executions})
+}
+
+func (ctrl *StandingOrderController) PauseStandingOrder(c *gin.Context) {
+	ctrl.changeStatus(c, ctrl.standingOrderService.PauseStandingOrder)
+}
+
+func (ctrl *StandingOrderController) ResumeStandingOrder(c *gin.Context) {
+	ctrl.changeStatus(c, ctrl.standingOrderService.ResumeStandingOrder)
+}
+
+func (ctrl *StandingOrderController) CancelStandingOrder(c *gin.Context) {
+	ctrl.changeStatus(c, ctrl.standingOrderService.CancelStandingOrder)
+}
+
+func (ctrl *StandingOrderController) changeStatus(c *gin.Context, change func(ctx context.Context, id string) (models.StandingOrder, error)) {
+	if _, ok := ctrl.authorize(c, true); !ok {
+		return
+	}
+
+	order, err := change(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// authorize loads the order named in the path. Either party may read it, only
+// the payer may change it. It aborts the request and reports false otherwise.
+func (ctrl *StandingOrderController) authorize(c *gin.Context, write bool) (models.StandingOrder, bool) {
+	order, err := ctrl.standingOrderService.GetStandingOrder(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return models.StandingOrder{}, false
+	}
+
+	allowed := callerCanAccess(c, order.FromAccount)
+	if !write {
+		allowed = allowed || callerCanAccess(c, order.ToAccount)
+	}
+	if !allowed {
+		problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to access this standing order")
+		return models.StandingOrder{}, false
+	}
+
+	return order, true
+}
@@ -0,0 +1,204 @@
+package controller_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/auth"
+	"secure-payment-service/internal/controller"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/middleware"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/transfers"
+)
+
+const standingOrderID = "standing-order-id"
+
+func setupStandingOrderRouter(svc *controller.MockStandingOrderService, principal auth.Principal) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	ctrl := controller.NewStandingOrderController(svc)
+
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.PrincipalKey, principal)
+	})
+
+	r.POST("/standing-orders", ctrl.CreateStandingOrder)
+	r.GET("/standing-orders", ctrl.ListStandingOrders)
+	r.GET("/standing-orders/:id", ctrl.GetStandingOrder)
+	r.GET("/standing-orders/:id/executions", ctrl.ListExecutions)
+	r.POST("/standing-orders/:id/pause", ctrl.PauseStandingOrder)
+	r.POST("/standing-orders/:id/resume", ctrl.ResumeStandingOrder)
+	r.POST("/standing-orders/:id/cancel", ctrl.CancelStandingOrder)
+
+	return r
+}
+
+var payer = auth.Principal{Subject: "user-1", Accounts: []string{fromAccount}}
+
+func givenAStandingOrder() models.StandingOrder {
+	return models.StandingOrder{
+		OrderID:     standingOrderID,
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+		Amount:      amount,
+		Currency:    currency,
+		Frequency:   "MONTHLY",
+		Status:      enums.StandingOrderActive.String(),
+	}
+}
+
+func TestCreateStandingOrder_Success(t *testing.T) {
+	svc := controller.NewMockStandingOrderService(t)
+	router := setupStandingOrderRouter(svc, payer)
+
+	svc.EXPECT().CreateStandingOrder(mock.Anything, mock.MatchedBy(func(r transfers.StandingOrderRequest) bool {
+		return r.FromAccount == fromAccount && r.Cron == "0 9 1 * *" && r.MaxExecutions == 12
+	}), "user-1").Return(givenAStandingOrder(), nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/standing-orders", bytes.NewBufferString(
+		`{"source_account_id":"acc-001","destination_account_id":"acc-002","amount":"100.00","currency":"USD","cron":"0 9 1 * *","max_executions":12}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	var order models.StandingOrder
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &order))
+	assert.Equal(t, standingOrderID, order.OrderID)
+	assert.Equal(t, enums.StandingOrderActive.String(), order.Status)
+}
+
+func TestCreateStandingOrder_InvalidSchedule(t *testing.T) {
+	svc := controller.NewMockStandingOrderService(t)
+	router := setupStandingOrderRouter(svc, payer)
+
+	req := httptest.NewRequest(http.MethodPost, "/standing-orders", bytes.NewBufferString(
+		`{"source_account_id":"acc-001","destination_account_id":"acc-002","amount":"100.00","currency":"USD","frequency":"HOURLY"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"field":"frequency"`)
+	svc.AssertNotCalled(t, "CreateStandingOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateStandingOrder_ForbiddenSourceAccount(t *testing.T) {
+	svc := controller.NewMockStandingOrderService(t)
+	router := setupStandingOrderRouter(svc, auth.Principal{Subject: "user-2", Accounts: []string{toAccount}})
+
+	req := httptest.NewRequest(http.MethodPost, "/standing-orders", bytes.NewBufferString(
+		`{"source_account_id":"acc-001","destination_account_id":"acc-002","amount":"100.00","currency":"USD","frequency":"DAILY"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
+
+func TestListStandingOrders_DefaultsToCallerAccounts(t *testing.T) {
+	svc := controller.NewMockStandingOrderService(t)
+	router := setupStandingOrderRouter(svc, payer)
+
+	svc.EXPECT().ListStandingOrders(mock.Anything, []string{fromAccount}, 50).Return([]models.StandingOrder{givenAStandingOrder()}, nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/standing-orders", nil))
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"standing_orders":[{`)
+}
+
+func TestGetStandingOrder_PayeeMayRead(t *testing.T) {
+	svc := controller.NewMockStandingOrderService(t)
+	router := setupStandingOrderRouter(svc, auth.Principal{Subject: "user-2", Accounts: []string{toAccount}})
+
+	svc.EXPECT().GetStandingOrder(mock.Anything, standingOrderID).Return(givenAStandingOrder(), nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/standing-orders/"+standingOrderID, nil))
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestGetStandingOrder_NotFound(t *testing.T) {
+	svc := controller.NewMockStandingOrderService(t)
+	router := setupStandingOrderRouter(svc, payer)
+
+	svc.EXPECT().GetStandingOrder(mock.Anything, standingOrderID).
+		Return(models.StandingOrder{}, apperrors.New(apperrors.ErrNotFound, apperrors.CodeOrderNotFound, "standing order not found")).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/standing-orders/"+standingOrderID, nil))
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Contains(t, resp.Body.String(), apperrors.CodeOrderNotFound)
+}
+
+func TestListExecutions_Success(t *testing.T) {
+	svc := controller.NewMockStandingOrderService(t)
+	router := setupStandingOrderRouter(svc, payer)
+
+	svc.EXPECT().GetStandingOrder(mock.Anything, standingOrderID).Return(givenAStandingOrder(), nil).Once()
+	svc.EXPECT().ListExecutions(mock.Anything, standingOrderID, 10).Return([]models.StandingOrderExecution{
+		{OrderID: standingOrderID, Status: enums.ExecutionCreated.String(), TransferID: expTransferID},
+	}, nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/standing-orders/"+standingOrderID+"/executions?limit=10", nil))
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"transfer_id":"`+expTransferID+`"`)
+}
+
+func TestPauseStandingOrder_Success(t *testing.T) {
+	svc := controller.NewMockStandingOrderService(t)
+	router := setupStandingOrderRouter(svc, payer)
+
+	paused := givenAStandingOrder()
+	paused.Status = enums.StandingOrderPaused.String()
+	svc.EXPECT().GetStandingOrder(mock.Anything, standingOrderID).Return(givenAStandingOrder(), nil).Once()
+	svc.EXPECT().PauseStandingOrder(mock.Anything, standingOrderID).Return(paused, nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/standing-orders/"+standingOrderID+"/pause", nil))
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"PAUSED"`)
+}
+
+func TestCancelStandingOrder_OnlyPayerMayCancel(t *testing.T) {
+	svc := controller.NewMockStandingOrderService(t)
+	router := setupStandingOrderRouter(svc, auth.Principal{Subject: "user-2", Accounts: []string{toAccount}})
+
+	svc.EXPECT().GetStandingOrder(mock.Anything, standingOrderID).Return(givenAStandingOrder(), nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/standing-orders/"+standingOrderID+"/cancel", nil))
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	svc.AssertNotCalled(t, "CancelStandingOrder", mock.Anything, mock.Anything)
+}
+
+func TestResumeStandingOrder_Cancelled(t *testing.T) {
+	svc := controller.NewMockStandingOrderService(t)
+	router := setupStandingOrderRouter(svc, payer)
+
+	svc.EXPECT().GetStandingOrder(mock.Anything, standingOrderID).Return(givenAStandingOrder(), nil).Once()
+	svc.EXPECT().ResumeStandingOrder(mock.Anything, standingOrderID).
+		Return(models.StandingOrder{}, enums.ValidateStandingOrderTransition(enums.StandingOrderCancelled, enums.StandingOrderActive)).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/standing-orders/"+standingOrderID+"/resume", nil))
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+}
@@ -1,9 +1,22 @@
 package controller
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/auth"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/middleware"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/money"
+	"secure-payment-service/internal/problem"
 	"secure-payment-service/internal/service"
+	"secure-payment-service/internal/statements"
 	"secure-payment-service/internal/transfers"
 
 	"github.com/gin-gonic/gin"
@@ -18,15 +31,38 @@ func NewTransferController(svc service.TransferService) *TransferController {
 }
 
 func (ctrl *TransferController) CreateTransfer(c *gin.Context) {
-	var transfer transfers.TransferRequest
-	if err := c.ShouldBindJSON(&transfer); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		abortWithBindingError(c, err)
+		return
+	}
+
+	// Validated here as well as in the service so malformed requests get a
+	// 400 listing all their fields, including those that did not decode,
+	// before any authorization decision.
+	transfer, err := transfers.DecodeTransferRequest(body)
+	if err != nil {
+		abortWithBindingError(c, err)
+		return
+	}
+
+	if !callerCanAccess(c, transfer.FromAccount) {
+		problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to move funds from account "+transfer.FromAccount)
 		return
 	}
 
-	transferID, err := ctrl.transferService.CreateTransfer(transfer)
+	transferID, err := ctrl.transferService.CreateTransfer(c.Request.Context(), transfer)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	if transfer.ExecuteAt != nil {
+		c.JSON(http.StatusCreated, gin.H{
+			"transfer_id": transferID,
+			"status":      enums.SCHEDULED.String(),
+			"execute_at":  transfer.ExecuteAt,
+		})
 		return
 	}
 
@@ -39,41 +75,464 @@ func (ctrl *TransferController) CreateTransfer(c *gin.Context) {
 func (ctrl *TransferController) GetTransfer(c *gin.Context) {
 	id := c.Param("id")
 
-	transfer, err := ctrl.transferService.GetTransfer(id)
+	transfer, err := ctrl.transferService.GetTransfer(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	if !callerCanAccess(c, transfer.FromAccount, transfer.ToAccount) {
+		problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to read this transfer")
 		return
 	}
 
+	c.Header("ETag", transferETag(transfer.Version))
 	c.JSON(http.StatusOK, transfer)
 }
 
+// CreateTransferGroup splits one payment into several legs that are created,
+// and later settled, together. It answers with the group and its legs.
+func (ctrl *TransferController) CreateTransferGroup(c *gin.Context) {
+	var req transfers.TransferGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithBindingError(c, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	if !callerCanAccess(c, req.FromAccount) {
+		problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to move funds from account "+req.FromAccount)
+		return
+	}
+
+	group, err := ctrl.transferService.CreateTransferGroup(c.Request.Context(), req)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, group)
+}
+
+// GetTransferGroup returns a multi-leg transfer to its payer or to any of its
+// payees.
+func (ctrl *TransferController) GetTransferGroup(c *gin.Context) {
+	group, err := ctrl.transferService.GetTransferGroup(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	if callerCanAccess(c, group.FromAccount) {
+		c.JSON(http.StatusOK, group)
+		return
+	}
+
+	// A payee only sees its own legs, and the total and status of those.
+	var visible []models.Transfer
+	for _, leg := range group.Legs {
+		if callerCanAccess(c, leg.ToAccount) {
+			visible = append(visible, leg)
+		}
+	}
+	if len(visible) == 0 {
+		problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to read this transfer group")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewTransferGroup(visible))
+}
+
+func (ctrl *TransferController) GetTransferHistory(c *gin.Context) {
+	id := c.Param("id")
+
+	transfer, err := ctrl.transferService.GetTransfer(c.Request.Context(), id)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	if !callerCanAccess(c, transfer.FromAccount, transfer.ToAccount) {
+		problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to read this transfer")
+		return
+	}
+
+	history, err := ctrl.transferService.GetTransferHistory(c.Request.Context(), id)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transfer_id": id,
+		"history":     history,
+	})
+}
+
+// CancelTransfer cancels a pending transfer on behalf of its payer. The body
+// with a reason is optional; If-Match is honored as in UpdateTransfer.
+func (ctrl *TransferController) CancelTransfer(c *gin.Context) {
+	id := c.Param("id")
+
+	var req transfers.CancelRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		abortWithBindingError(c, err)
+		return
+	}
+
+	ifMatch, ok := ifMatchVersions(c.GetHeader("If-Match"))
+	if !ok {
+		problem.Abort(c, http.StatusPreconditionFailed, apperrors.CodeVersionMismatch, "If-Match does not name a version of this transfer")
+		return
+	}
+
+	transfer, err := ctrl.transferService.GetTransfer(c.Request.Context(), id)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	if !callerCanAccess(c, transfer.FromAccount) {
+		problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to cancel this transfer")
+		return
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	change := models.StatusChange{
+		Source:  enums.SourceAPI,
+		Actor:   principal.Subject,
+		Reason:  req.Reason,
+		IfMatch: ifMatch,
+	}
+
+	if err := ctrl.transferService.CancelTransfer(c.Request.Context(), id, change); err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transfer_id": id,
+		"status":      enums.CANCELLED.String(),
+	})
+}
+
+// RefundTransfer gives money back for a completed transfer. The refund is paid
+// by the destination account, so only callers acting on it may request one.
+func (ctrl *TransferController) RefundTransfer(c *gin.Context) {
+	id := c.Param("id")
+
+	var req transfers.RefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		abortWithBindingError(c, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	ifMatch, ok := ifMatchVersions(c.GetHeader("If-Match"))
+	if !ok {
+		problem.Abort(c, http.StatusPreconditionFailed, apperrors.CodeVersionMismatch, "If-Match does not name a version of this transfer")
+		return
+	}
+
+	transfer, err := ctrl.transferService.GetTransfer(c.Request.Context(), id)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	if !callerCanAccess(c, transfer.ToAccount) {
+		problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to refund this transfer")
+		return
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	change := models.StatusChange{
+		Source:  enums.SourceAPI,
+		Actor:   principal.Subject,
+		Reason:  req.Reason,
+		IfMatch: ifMatch,
+	}
+
+	refund, err := ctrl.transferService.RefundTransfer(c.Request.Context(), id, req, change)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, refund)
+}
+
+func (ctrl *TransferController) ListTransfers(c *gin.Context) {
+	var query transfers.ListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		abortWithBindingError(c, err)
+		return
+	}
+
+	filter, err := query.Filter()
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	if !restrictToCaller(c, &filter) {
+		return
+	}
+
+	page, err := ctrl.transferService.ListTransfers(c.Request.Context(), filter)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	response := gin.H{"transfers": page.Transfers}
+	if page.Next != nil {
+		response["next_cursor"] = transfers.EncodeCursor(*page.Next)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// ListScheduledTransfers lists transfers still waiting for their execution
+// time, soonest first.
+func (ctrl *TransferController) ListScheduledTransfers(c *gin.Context) {
+	var query transfers.ScheduledQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		abortWithBindingError(c, err)
+		return
+	}
+
+	filter, err := query.Filter()
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	if !restrictToCaller(c, &filter) {
+		return
+	}
+
+	scheduled, err := ctrl.transferService.ListScheduledTransfers(c.Request.Context(), filter.Accounts, filter.Limit)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"transfers": scheduled})
+}
+
+// UpdateScheduledTransfer edits a transfer that is still SCHEDULED. Only the
+// payer may do so; If-Match is honored as in UpdateTransfer.
+func (ctrl *TransferController) UpdateScheduledTransfer(c *gin.Context) {
+	id := c.Param("id")
+
+	var req transfers.ScheduledTransferUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithBindingError(c, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	ifMatch, ok := ifMatchVersions(c.GetHeader("If-Match"))
+	if !ok {
+		problem.Abort(c, http.StatusPreconditionFailed, apperrors.CodeVersionMismatch, "If-Match does not name a version of this transfer")
+		return
+	}
+
+	transfer, err := ctrl.transferService.GetTransfer(c.Request.Context(), id)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	if !callerCanAccess(c, transfer.FromAccount) {
+		problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to change this transfer")
+		return
+	}
+
+	updated, err := ctrl.transferService.UpdateScheduledTransfer(c.Request.Context(), id, req, ifMatch)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	c.Header("ETag", transferETag(updated.Version))
+	c.JSON(http.StatusOK, updated)
+}
+
 func (ctrl *TransferController) GetAccountBalance(c *gin.Context) {
 	id := c.Param("id")
 
-	balance, err := ctrl.transferService.GetAccountBalance(id)
+	if !callerCanAccess(c, id) {
+		problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to read account "+id)
+		return
+	}
+
+	balances, err := ctrl.transferService.GetAccountBalance(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		problem.AbortWithError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"account_id": id,
-		"balance":    balance,
+		"balances":   balances,
 	})
 }
 
+const (
+	mimeCSV     = "text/csv"
+	mimeCamt053 = "application/xml"
+)
+
+// GetAccountStatement renders the statement as JSON, CSV or camt.053 XML
+// depending on the Accept header; JSON is the default.
+func (ctrl *TransferController) GetAccountStatement(c *gin.Context) {
+	id := c.Param("id")
+
+	if !callerCanAccess(c, id) {
+		problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to read account "+id)
+		return
+	}
+
+	format := c.NegotiateFormat(gin.MIMEJSON, mimeCSV, mimeCamt053)
+	if format == "" {
+		problem.Abort(c, http.StatusNotAcceptable, apperrors.CodeNotAcceptable, "statements are available as application/json, text/csv or application/xml")
+		return
+	}
+
+	var query statements.Query
+	if err := c.ShouldBindQuery(&query); err != nil {
+		abortWithBindingError(c, err)
+		return
+	}
+	from, to, err := query.Period()
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	statement, err := ctrl.transferService.GetAccountStatement(c.Request.Context(), id, from, to)
+	if err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	if format == gin.MIMEJSON {
+		c.JSON(http.StatusOK, statement)
+		return
+	}
+
+	var buf bytes.Buffer
+	write, ext := statements.WriteCSV, "csv"
+	if format == mimeCamt053 {
+		write, ext = statements.WriteCamt053, "xml"
+	}
+	if err := write(&buf, statement); err != nil {
+		problem.AbortWithError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("statement-%s-%s.%s", id, from.UTC().Format("20060102"), ext)
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, format+"; charset=utf-8", buf.Bytes())
+}
+
 func (ctrl *TransferController) UpdateTransfer(c *gin.Context) {
 	var webhook transfers.WebhookEvent
 	if err := c.ShouldBindJSON(&webhook); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithBindingError(c, err)
+		return
+	}
+
+	ifMatch, ok := ifMatchVersions(c.GetHeader("If-Match"))
+	if !ok {
+		problem.Abort(c, http.StatusPreconditionFailed, apperrors.CodeVersionMismatch, "If-Match does not name a version of this transfer")
 		return
 	}
 
-	if err := ctrl.transferService.UpdateTransfer(webhook.ID, webhook.Status); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	change := models.StatusChange{
+		Status:  webhook.Status,
+		Source:  enums.SourceWebhook,
+		Actor:   c.GetString(middleware.WebhookProviderKey),
+		Reason:  webhook.Reason,
+		IfMatch: ifMatch,
+	}
+
+	if err := ctrl.transferService.UpdateTransfer(c.Request.Context(), webhook.ID, change); err != nil {
+		problem.AbortWithError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"status": "Transfer updated"})
 }
+
+// restrictToCaller checks the account filter of a listing against the caller.
+// Without one, the listing is restricted to the caller's own accounts rather
+// than leaking everyone else's transfers. It aborts the request and reports
+// false when the caller may not list.
+func restrictToCaller(c *gin.Context, filter *models.TransferFilter) bool {
+	principal, _ := middleware.CurrentPrincipal(c)
+	switch {
+	case len(filter.Accounts) > 0:
+		if !principal.CanAccessAccount(filter.Accounts[0]) {
+			problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "not allowed to read account "+filter.Accounts[0])
+			return false
+		}
+	case !principal.CanAccessAccount(auth.AllAccounts):
+		if len(principal.Accounts) == 0 {
+			problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "token does not grant access to any account")
+			return false
+		}
+		filter.Accounts = principal.Accounts
+	}
+	return true
+}
+
+// callerCanAccess reports whether the authenticated caller may act on at least
+// one of the given accounts.
+func callerCanAccess(c *gin.Context, accountIDs ...string) bool {
+	principal, ok := middleware.CurrentPrincipal(c)
+	if !ok {
+		return false
+	}
+	for _, id := range accountIDs {
+		if principal.CanAccessAccount(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// abortWithBindingError reports JSON fields of the wrong type as validation
+// errors on that field and passes validation errors through; anything else
+// means the body is not valid JSON.
+func abortWithBindingError(c *gin.Context, err error) {
+	var typeErr *json.UnmarshalTypeError
+	var errs apperrors.ValidationError
+	var invalid *apperrors.ValidationError
+	switch {
+	case errors.As(err, &invalid):
+		problem.AbortWithError(c, err)
+		return
+	case errors.As(err, &typeErr):
+		errs.Add(typeErr.Field, apperrors.CodeInvalidType, "must be a "+typeErr.Type.String())
+	case errors.Is(err, money.ErrInvalidAmount):
+		errs.Add("amount", apperrors.CodeInvalidAmount, err.Error())
+	default:
+		problem.Abort(c, http.StatusBadRequest, apperrors.CodeInvalidRequest, err.Error())
+		return
+	}
+	problem.AbortWithError(c, &errs)
+}
@@ -1,32 +1,333 @@
 package enums
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+
+	"secure-payment-service/internal/apperrors"
+)
 
 type TransactionStatus string
 
 const (
-	COMPLETED TransactionStatus = "COMPLETED"
-	PENDING   TransactionStatus = "PENDING"
-	FAILED    TransactionStatus = "FAILED"
+	COMPLETED  TransactionStatus = "COMPLETED"
+	PENDING    TransactionStatus = "PENDING"
+	FAILED     TransactionStatus = "FAILED"
+	PROCESSING TransactionStatus = "PROCESSING"
+	CANCELLED  TransactionStatus = "CANCELLED"
+	REVERSED   TransactionStatus = "REVERSED"
+	EXPIRED    TransactionStatus = "EXPIRED"
+	// PARTIALLY_REFUNDED is a completed transfer with refunds that do not yet
+	// add up to its amount.
+	PARTIALLY_REFUNDED TransactionStatus = "PARTIALLY_REFUNDED"
+	// SCHEDULED is a future-dated transfer waiting for its execution time.
+	// Funds are only reserved once it is released to PENDING.
+	SCHEDULED TransactionStatus = "SCHEDULED"
 )
 
+// transitions lists, for every status, the statuses a transfer may move to.
+// Statuses without an entry are terminal. Moving to PARTIALLY_REFUNDED is not
+// listed because it needs an amount; only refunds do it. Likewise SCHEDULED to
+// PENDING is left to the executor, which reserves the funds.
+var transitions = map[TransactionStatus][]TransactionStatus{
+	PENDING:            {PROCESSING, COMPLETED, FAILED, CANCELLED, EXPIRED},
+	PROCESSING:         {COMPLETED, FAILED, EXPIRED},
+	COMPLETED:          {REVERSED},
+	PARTIALLY_REFUNDED: {REVERSED},
+	SCHEDULED:          {CANCELLED, FAILED},
+}
+
 func (ts TransactionStatus) String() string {
 	return string(ts)
 }
 
 func (ts TransactionStatus) IsValid() bool {
 	switch ts {
-	case COMPLETED, PENDING, FAILED:
+	case COMPLETED, PENDING, FAILED, PROCESSING, CANCELLED, REVERSED, EXPIRED, PARTIALLY_REFUNDED, SCHEDULED:
 		return true
 	default:
 		return false
 	}
 }
 
+func (ts TransactionStatus) IsTerminal() bool {
+	return ts.IsValid() && len(transitions[ts]) == 0
+}
+
+// IsRefundable reports whether a refund may be booked against a transfer in
+// this status.
+func (ts TransactionStatus) IsRefundable() bool {
+	return ts == COMPLETED || ts == PARTIALLY_REFUNDED
+}
+
+// GroupStatus derives the status of a multi-leg transfer from its legs. The
+// legs move together until they settle, so they normally share one status;
+// once settled, refunds of single legs make the group PARTIALLY_REFUNDED.
+// Any other mix is still being settled and reported as PROCESSING.
+func GroupStatus(legs []TransactionStatus) TransactionStatus {
+	if len(legs) == 0 {
+		return ""
+	}
+	same, settled := true, true
+	for _, leg := range legs {
+		same = same && leg == legs[0]
+		settled = settled && (leg.IsRefundable() || leg == REVERSED)
+	}
+	switch {
+	case same:
+		return legs[0]
+	case settled:
+		return PARTIALLY_REFUNDED
+	default:
+		return PROCESSING
+	}
+}
+
+func (ts TransactionStatus) CanTransitionTo(next TransactionStatus) bool {
+	for _, allowed := range transitions[ts] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type InvalidStatusError struct {
+	Value string
+}
+
+func (e *InvalidStatusError) Error() string {
+	return fmt.Sprintf("'%s' is not a valid transaction status", e.Value)
+}
+
+func (e *InvalidStatusError) Is(target error) bool {
+	return target == apperrors.ErrValidation
+}
+
+func (e *InvalidStatusError) ErrorCode() string {
+	return apperrors.CodeInvalidStatus
+}
+
+type InvalidTransitionError struct {
+	From TransactionStatus
+	To   TransactionStatus
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("transfer cannot move from %s to %s", e.From, e.To)
+}
+
+func (e *InvalidTransitionError) Is(target error) bool {
+	return target == apperrors.ErrInvalidTransition
+}
+
+func (e *InvalidTransitionError) ErrorCode() string {
+	return apperrors.CodeInvalidTransition
+}
+
+func ValidateTransition(from, to TransactionStatus) error {
+	if !to.IsValid() {
+		return &InvalidStatusError{Value: to.String()}
+	}
+	if !from.CanTransitionTo(to) {
+		return &InvalidTransitionError{From: from, To: to}
+	}
+	return nil
+}
+
 func NewTransactionStatusFromString(s string) (TransactionStatus, error) {
 	status := TransactionStatus(s)
 	if !status.IsValid() {
-		return "", fmt.Errorf("'%s' is not a valid transaction status", s)
+		return "", &InvalidStatusError{Value: s}
 	}
 	return status, nil
 }
+
+type TransitionSource string
+
+const (
+	SourceAPI       TransitionSource = "API"
+	SourceWebhook   TransitionSource = "WEBHOOK"
+	SourceMonitor   TransitionSource = "MONITOR"
+	SourceAdmin     TransitionSource = "ADMIN"
+	SourceScheduler TransitionSource = "SCHEDULER"
+)
+
+func (s TransitionSource) String() string {
+	return string(s)
+}
+
+type JobStatus string
+
+const (
+	JobPending JobStatus = "PENDING"
+	JobRunning JobStatus = "RUNNING"
+	JobDone    JobStatus = "DONE"
+	JobFailed  JobStatus = "FAILED"
+)
+
+func (js JobStatus) String() string {
+	return string(js)
+}
+
+type AccountStatus string
+
+const (
+	AccountActive AccountStatus = "ACTIVE"
+	AccountFrozen AccountStatus = "FROZEN"
+	AccountClosed AccountStatus = "CLOSED"
+)
+
+// accountTransitions lists the statuses an account may move to. Closed
+// accounts stay closed.
+var accountTransitions = map[AccountStatus][]AccountStatus{
+	AccountActive: {AccountFrozen, AccountClosed},
+	AccountFrozen: {AccountActive},
+}
+
+func (as AccountStatus) String() string {
+	return string(as)
+}
+
+func (as AccountStatus) CanTransitionTo(next AccountStatus) bool {
+	return slices.Contains(accountTransitions[as], next)
+}
+
+// ValidateAccountTransition returns an error of kind
+// apperrors.ErrInvalidTransition when an account cannot move from one status
+// to the other.
+func ValidateAccountTransition(from, to AccountStatus) error {
+	if from.CanTransitionTo(to) {
+		return nil
+	}
+	return apperrors.New(apperrors.ErrInvalidTransition, apperrors.CodeInvalidTransition,
+		fmt.Sprintf("account cannot move from %s to %s", from, to))
+}
+
+type StandingOrderStatus string
+
+const (
+	StandingOrderActive    StandingOrderStatus = "ACTIVE"
+	StandingOrderPaused    StandingOrderStatus = "PAUSED"
+	StandingOrderCancelled StandingOrderStatus = "CANCELLED"
+	// StandingOrderFinished is reached when the end date or the number of
+	// executions has been used up.
+	StandingOrderFinished StandingOrderStatus = "FINISHED"
+)
+
+var standingOrderTransitions = map[StandingOrderStatus][]StandingOrderStatus{
+	StandingOrderActive: {StandingOrderPaused, StandingOrderCancelled, StandingOrderFinished},
+	StandingOrderPaused: {StandingOrderActive, StandingOrderCancelled, StandingOrderFinished},
+}
+
+func (ss StandingOrderStatus) String() string {
+	return string(ss)
+}
+
+func (ss StandingOrderStatus) CanTransitionTo(next StandingOrderStatus) bool {
+	return slices.Contains(standingOrderTransitions[ss], next)
+}
+
+// ValidateStandingOrderTransition works like ValidateAccountTransition.
+func ValidateStandingOrderTransition(from, to StandingOrderStatus) error {
+	if from.CanTransitionTo(to) {
+		return nil
+	}
+	return apperrors.New(apperrors.ErrInvalidTransition, apperrors.CodeInvalidTransition,
+		fmt.Sprintf("standing order cannot move from %s to %s", from, to))
+}
+
+// ExecutionStatus is the outcome of one occurrence of a standing order.
+type ExecutionStatus string
+
+const (
+	// ExecutionRunning marks an occurrence whose transfer is being created.
+	ExecutionRunning ExecutionStatus = "RUNNING"
+	ExecutionCreated ExecutionStatus = "CREATED"
+	ExecutionFailed  ExecutionStatus = "FAILED"
+)
+
+func (es ExecutionStatus) String() string {
+	return string(es)
+}
+
+// BatchStatus is the progress of a bulk upload of transfers.
+type BatchStatus string
+
+const (
+	BatchProcessing BatchStatus = "PROCESSING"
+	// BatchCompleted means every row has an outcome, not that every row
+	// created a transfer.
+	BatchCompleted BatchStatus = "COMPLETED"
+)
+
+func (bs BatchStatus) String() string {
+	return string(bs)
+}
+
+// BatchRowStatus is the outcome of one row of a bulk upload.
+type BatchRowStatus string
+
+const (
+	BatchRowPending BatchRowStatus = "PENDING"
+	// BatchRowRunning marks a row whose transfer is being created.
+	BatchRowRunning BatchRowStatus = "RUNNING"
+	BatchRowCreated BatchRowStatus = "CREATED"
+	BatchRowFailed  BatchRowStatus = "FAILED"
+)
+
+func (rs BatchRowStatus) IsValid() bool {
+	switch rs {
+	case BatchRowPending, BatchRowRunning, BatchRowCreated, BatchRowFailed:
+		return true
+	}
+	return false
+}
+
+func (rs BatchRowStatus) String() string {
+	return string(rs)
+}
+
+type HoldStatus string
+
+const (
+	HoldActive   HoldStatus = "ACTIVE"
+	HoldCaptured HoldStatus = "CAPTURED"
+	HoldReleased HoldStatus = "RELEASED"
+)
+
+func (hs HoldStatus) String() string {
+	return string(hs)
+}
+
+type EntryDirection string
+
+const (
+	DEBIT  EntryDirection = "DEBIT"
+	CREDIT EntryDirection = "CREDIT"
+)
+
+func (ed EntryDirection) String() string {
+	return string(ed)
+}
+
+// AccountRole selects which side of a transfer an account filter matches.
+type AccountRole string
+
+const (
+	RoleSource      AccountRole = "source"
+	RoleDestination AccountRole = "destination"
+	RoleAny         AccountRole = "any"
+)
+
+func (r AccountRole) IsValid() bool {
+	switch r {
+	case RoleSource, RoleDestination, RoleAny:
+		return true
+	}
+	return false
+}
+
+func (r AccountRole) String() string {
+	return string(r)
+}
@@ -1,10 +1,12 @@
 package enums_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"secure-payment-service/internal/apperrors"
 	"secure-payment-service/internal/enums"
 )
 
@@ -16,6 +18,10 @@ func TestTransactionStatus_String(t *testing.T) {
 		{enums.COMPLETED, "COMPLETED"},
 		{enums.PENDING, "PENDING"},
 		{enums.FAILED, "FAILED"},
+		{enums.PROCESSING, "PROCESSING"},
+		{enums.CANCELLED, "CANCELLED"},
+		{enums.REVERSED, "REVERSED"},
+		{enums.EXPIRED, "EXPIRED"},
 		{"UNKNOWN_STATUS", "UNKNOWN_STATUS"},
 	}
 
@@ -34,6 +40,12 @@ func TestTransactionStatus_IsValid(t *testing.T) {
 		{enums.COMPLETED, true},
 		{enums.PENDING, true},
 		{enums.FAILED, true},
+		{enums.PROCESSING, true},
+		{enums.CANCELLED, true},
+		{enums.REVERSED, true},
+		{enums.EXPIRED, true},
+		{enums.PARTIALLY_REFUNDED, true},
+		{enums.SCHEDULED, true},
 		{"", false},
 		{"completed", false},
 		{"COMPLEETED", false},
@@ -74,3 +86,112 @@ func TestNewTransactionStatusFromString(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from     enums.TransactionStatus
+		to       enums.TransactionStatus
+		expected bool
+	}{
+		{enums.PENDING, enums.PROCESSING, true},
+		{enums.PENDING, enums.COMPLETED, true},
+		{enums.PENDING, enums.FAILED, true},
+		{enums.PENDING, enums.CANCELLED, true},
+		{enums.PENDING, enums.EXPIRED, true},
+		{enums.PROCESSING, enums.COMPLETED, true},
+		{enums.PROCESSING, enums.PENDING, false},
+		{enums.PROCESSING, enums.CANCELLED, false},
+		{enums.COMPLETED, enums.REVERSED, true},
+		{enums.COMPLETED, enums.PENDING, false},
+		{enums.COMPLETED, enums.FAILED, false},
+		{enums.COMPLETED, enums.PARTIALLY_REFUNDED, false},
+		{enums.PARTIALLY_REFUNDED, enums.REVERSED, true},
+		{enums.SCHEDULED, enums.CANCELLED, true},
+		{enums.SCHEDULED, enums.FAILED, true},
+		{enums.SCHEDULED, enums.PENDING, false},
+		{enums.FAILED, enums.COMPLETED, false},
+		{enums.CANCELLED, enums.PENDING, false},
+		{enums.REVERSED, enums.COMPLETED, false},
+		{enums.EXPIRED, enums.COMPLETED, false},
+		{enums.PENDING, enums.PENDING, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from.String()+"_to_"+tt.to.String(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestTransactionStatus_IsTerminal(t *testing.T) {
+	assert.False(t, enums.PENDING.IsTerminal())
+	assert.False(t, enums.PROCESSING.IsTerminal())
+	assert.False(t, enums.COMPLETED.IsTerminal())
+	assert.True(t, enums.FAILED.IsTerminal())
+	assert.True(t, enums.CANCELLED.IsTerminal())
+	assert.True(t, enums.REVERSED.IsTerminal())
+	assert.True(t, enums.EXPIRED.IsTerminal())
+	assert.False(t, enums.PARTIALLY_REFUNDED.IsTerminal())
+	assert.False(t, enums.TransactionStatus("UNKNOWN").IsTerminal())
+}
+
+func TestTransactionStatus_IsRefundable(t *testing.T) {
+	assert.True(t, enums.COMPLETED.IsRefundable())
+	assert.True(t, enums.PARTIALLY_REFUNDED.IsRefundable())
+	assert.False(t, enums.PENDING.IsRefundable())
+	assert.False(t, enums.REVERSED.IsRefundable())
+}
+
+func TestGroupStatus(t *testing.T) {
+	assert.Equal(t, enums.PENDING, enums.GroupStatus([]enums.TransactionStatus{enums.PENDING, enums.PENDING}))
+	assert.Equal(t, enums.FAILED, enums.GroupStatus([]enums.TransactionStatus{enums.FAILED, enums.FAILED, enums.FAILED}))
+	assert.Equal(t, enums.PARTIALLY_REFUNDED, enums.GroupStatus([]enums.TransactionStatus{enums.COMPLETED, enums.REVERSED}))
+	assert.Equal(t, enums.PROCESSING, enums.GroupStatus([]enums.TransactionStatus{enums.PROCESSING, enums.PENDING}))
+	assert.Equal(t, enums.TransactionStatus(""), enums.GroupStatus(nil))
+}
+
+func TestValidateTransition(t *testing.T) {
+	assert.NoError(t, enums.ValidateTransition(enums.PENDING, enums.COMPLETED))
+
+	err := enums.ValidateTransition(enums.COMPLETED, enums.PENDING)
+	var transitionErr *enums.InvalidTransitionError
+	assert.True(t, errors.As(err, &transitionErr))
+	assert.Equal(t, enums.COMPLETED, transitionErr.From)
+	assert.Equal(t, enums.PENDING, transitionErr.To)
+	assert.EqualError(t, err, "transfer cannot move from COMPLETED to PENDING")
+	assert.ErrorIs(t, err, apperrors.ErrInvalidTransition)
+
+	err = enums.ValidateTransition(enums.PENDING, "DONE")
+	var statusErr *enums.InvalidStatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, "DONE", statusErr.Value)
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+	assert.Equal(t, apperrors.CodeInvalidStatus, apperrors.Code(err, ""))
+}
+
+func TestValidateAccountTransition(t *testing.T) {
+	assert.NoError(t, enums.ValidateAccountTransition(enums.AccountActive, enums.AccountFrozen))
+	assert.NoError(t, enums.ValidateAccountTransition(enums.AccountFrozen, enums.AccountActive))
+	assert.NoError(t, enums.ValidateAccountTransition(enums.AccountActive, enums.AccountClosed))
+
+	err := enums.ValidateAccountTransition(enums.AccountFrozen, enums.AccountClosed)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidTransition)
+	assert.ErrorIs(t, enums.ValidateAccountTransition(enums.AccountClosed, enums.AccountActive), apperrors.ErrInvalidTransition)
+}
+
+func TestValidateStandingOrderTransition(t *testing.T) {
+	assert.NoError(t, enums.ValidateStandingOrderTransition(enums.StandingOrderActive, enums.StandingOrderPaused))
+	assert.NoError(t, enums.ValidateStandingOrderTransition(enums.StandingOrderPaused, enums.StandingOrderActive))
+	assert.NoError(t, enums.ValidateStandingOrderTransition(enums.StandingOrderPaused, enums.StandingOrderCancelled))
+	assert.NoError(t, enums.ValidateStandingOrderTransition(enums.StandingOrderActive, enums.StandingOrderFinished))
+
+	assert.ErrorIs(t, enums.ValidateStandingOrderTransition(enums.StandingOrderActive, enums.StandingOrderActive), apperrors.ErrInvalidTransition)
+	assert.ErrorIs(t, enums.ValidateStandingOrderTransition(enums.StandingOrderCancelled, enums.StandingOrderActive), apperrors.ErrInvalidTransition)
+	assert.ErrorIs(t, enums.ValidateStandingOrderTransition(enums.StandingOrderFinished, enums.StandingOrderPaused), apperrors.ErrInvalidTransition)
+}
+
+func TestBatchRowStatus_IsValid(t *testing.T) {
+	assert.True(t, enums.BatchRowFailed.IsValid())
+	assert.False(t, enums.BatchRowStatus("failed").IsValid())
+	assert.False(t, enums.BatchRowStatus(enums.BatchCompleted).IsValid())
+}
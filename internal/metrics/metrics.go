@@ -30,4 +30,20 @@ var (
 		},
 		[]string{"transfer_id", "attempt_number", "result"},
 	)
+
+	ScheduledJobsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "scheduled_jobs_total",
+			Help: "Total scheduled job runs by kind and result.",
+		},
+		[]string{"kind", "result"},
+	)
+
+	WebhookRejectionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_rejections_total",
+			Help: "Total inbound webhooks rejected by reason.",
+		},
+		[]string{"reason"},
+	)
 )
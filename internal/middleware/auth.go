@@ -2,38 +2,54 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/auth"
 	"secure-payment-service/internal/logging"
+	"secure-payment-service/internal/problem"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
 	"github.com/sirupsen/logrus"
 )
 
-var jwtKey = []byte("secret_key")
+// PrincipalKey is the gin context key holding the auth.Principal of the
+// authenticated caller.
+const PrincipalKey = "auth_principal"
 
-func Auth() gin.HandlerFunc {
+func Auth(verifier *auth.Verifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header missing"})
-			c.Abort()
+			problem.Abort(c, http.StatusUnauthorized, apperrors.CodeUnauthorized, "Authorization header missing")
 			return
 		}
 
-		tokenStr := authHeader[len("Bearer "):]
+		tokenStr, ok := strings.CutPrefix(authHeader, "Bearer ")
+		if !ok {
+			problem.Abort(c, http.StatusUnauthorized, apperrors.CodeUnauthorized, "Authorization header must use the Bearer scheme")
+			return
+		}
+
+		claims, err := verifier.Verify(tokenStr)
+		if err != nil {
+			logging.Logger.WithError(err).Debug("rejected bearer token")
+			problem.Abort(c, http.StatusUnauthorized, apperrors.CodeUnauthorized, "Invalid or invalidated JWT")
+			return
+		}
 
-		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			if token.Method.Alg() != jwt.SigningMethodHS256.Alg() {
-				return nil, jwt.NewValidationError("Invalid signing algorithm", jwt.ValidationErrorSignatureInvalid)
-			}
-			return jwtKey, nil
-		})
+		c.Set(PrincipalKey, claims.Principal())
+		c.Next()
+	}
+}
 
-		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or invalidated JWT"})
-			c.Abort()
+// RequireScope rejects callers whose token was not granted scope.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := CurrentPrincipal(c)
+		if !ok || !principal.HasScope(scope) {
+			problem.Abort(c, http.StatusForbidden, apperrors.CodeForbidden, "missing required scope "+scope)
 			return
 		}
 
@@ -41,6 +57,15 @@ func Auth() gin.HandlerFunc {
 	}
 }
 
+func CurrentPrincipal(c *gin.Context) (auth.Principal, bool) {
+	value, ok := c.Get(PrincipalKey)
+	if !ok {
+		return auth.Principal{}, false
+	}
+	principal, ok := value.(auth.Principal)
+	return principal, ok
+}
+
 func RequestLogger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
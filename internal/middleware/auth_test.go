@@ -0,0 +1,89 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+
+	"secure-payment-service/internal/auth"
+	"secure-payment-service/internal/config"
+	"secure-payment-service/internal/middleware"
+)
+
+func setupAuthRouter(t *testing.T) *gin.Engine {
+	verifier, err := auth.NewVerifier(config.AuthConfig{HMACSecret: []byte("test-secret")})
+	assert.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/protected", middleware.Auth(verifier), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func getWithAuthorization(r *gin.Engine, header string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	if header != "" {
+		req.Header.Set("Authorization", header)
+	}
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+	return resp
+}
+
+func TestAuth(t *testing.T) {
+	r := setupAuthRouter(t)
+
+	valid, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString([]byte("test-secret"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, getWithAuthorization(r, "Bearer "+valid).Code)
+	assert.Equal(t, http.StatusUnauthorized, getWithAuthorization(r, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, getWithAuthorization(r, "Basic abc").Code)
+	assert.Equal(t, http.StatusUnauthorized, getWithAuthorization(r, "Bearer not-a-token").Code)
+}
+
+func TestRequireScope(t *testing.T) {
+	verifier, err := auth.NewVerifier(config.AuthConfig{HMACSecret: []byte("test-secret")})
+	assert.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/balance", middleware.Auth(verifier), middleware.RequireScope(auth.ScopeBalancesRead), func(c *gin.Context) {
+		principal, _ := middleware.CurrentPrincipal(c)
+		c.JSON(http.StatusOK, gin.H{"subject": principal.Subject})
+	})
+
+	tokenWithScope := func(scope string) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "user-1",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+			Scope: scope,
+		}).SignedString([]byte("test-secret"))
+		assert.NoError(t, err)
+		return token
+	}
+
+	granted := httptest.NewRequest(http.MethodGet, "/balance", nil)
+	granted.Header.Set("Authorization", "Bearer "+tokenWithScope("transfers:read balances:read"))
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, granted)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"subject": "user-1"}`, resp.Body.String())
+
+	denied := httptest.NewRequest(http.MethodGet, "/balance", nil)
+	denied.Header.Set("Authorization", "Bearer "+tokenWithScope("transfers:read"))
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, denied)
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/logging"
+	"secure-payment-service/internal/problem"
+	"secure-payment-service/internal/repository"
+)
+
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency replays the stored response for requests that repeat an
+// Idempotency-Key with the same body, and rejects reuse of a key with a
+// different body. Keys belong to the authenticated caller, so one caller can
+// neither replay nor detect another's. Bodies are read up to maxBodyBytes,
+// and a request still unfinished after inFlightTTL is taken to have crashed
+// and no longer holds its key. Requests without the header pass through
+// untouched.
+func Idempotency(store repository.IdempotencyRepository, maxBodyBytes int64, inFlightTTL time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes))
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			problem.Abort(c, http.StatusRequestEntityTooLarge, apperrors.CodeInvalidRequest, fmt.Sprintf("request body must be at most %d bytes", maxBodyBytes))
+			return
+		case err != nil:
+			problem.Abort(c, http.StatusBadRequest, apperrors.CodeInvalidRequest, "could not read request body")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		principal, _ := CurrentPrincipal(c)
+		subject := principal.Subject
+		fingerprint := requestFingerprint(subject, c.Request.Method, c.Request.URL.Path, body)
+
+		record, reserved, err := store.Reserve(c.Request.Context(), subject, key, fingerprint, time.Now().Add(inFlightTTL))
+		if err != nil {
+			logging.Logger.WithError(err).Error("failed to reserve idempotency key")
+			problem.Abort(c, http.StatusInternalServerError, apperrors.CodeInternal, "could not process Idempotency-Key")
+			return
+		}
+
+		if !reserved {
+			switch {
+			case record.Fingerprint != fingerprint:
+				problem.Abort(c, http.StatusUnprocessableEntity, apperrors.CodeIdempotencyReused, "Idempotency-Key was already used with a different request")
+			case record.ResponseCode == 0:
+				problem.Abort(c, http.StatusConflict, apperrors.CodeIdempotencyPending, "a request with this Idempotency-Key is still being processed")
+			default:
+				c.Header("Idempotent-Replayed", "true")
+				c.Data(record.ResponseCode, record.ContentType, record.ResponseBody)
+				c.Abort()
+			}
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		c.Next()
+
+		// The outcome is stored even if the request context has been cancelled
+		// by now, so a retry does not see a key stuck in flight.
+		ctx := context.WithoutCancel(c.Request.Context())
+
+		// Server errors are not stored so the client can retry with the same key.
+		if recorder.Status() >= http.StatusInternalServerError {
+			if err := store.Release(ctx, subject, key); err != nil {
+				logging.Logger.WithError(err).Error("failed to release idempotency key")
+			}
+			return
+		}
+
+		if err := store.Complete(ctx, subject, key, recorder.Status(), recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+			logging.Logger.WithError(err).Error("failed to store idempotent response")
+		}
+	}
+}
+
+func requestFingerprint(subject, method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(subject))
+	hash.Write([]byte{0})
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
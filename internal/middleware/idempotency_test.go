@@ -0,0 +1,117 @@
+package middleware_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"secure-payment-service/internal/auth"
+	"secure-payment-service/internal/middleware"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/repository"
+)
+
+func setupIdempotencyRouter(t *testing.T, status int) (*gin.Engine, *int) {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&models.IdempotencyRecord{}))
+
+	calls := 0
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.PrincipalKey, auth.Principal{Subject: c.GetHeader("X-Subject")})
+	})
+	r.POST("/transfers", middleware.Idempotency(repository.NewGormIdempotencyRepository(db), 1<<10, time.Minute), func(c *gin.Context) {
+		calls++
+		c.JSON(status, gin.H{"call": calls})
+	})
+	return r, &calls
+}
+
+func postWithKey(r *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	return postAs(r, "user-1", key, body)
+}
+
+func postAs(r *gin.Engine, subject, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Subject", subject)
+	if key != "" {
+		req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	}
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+	return resp
+}
+
+func TestIdempotency_ReplaysIdenticalRetry(t *testing.T) {
+	r, calls := setupIdempotencyRouter(t, http.StatusCreated)
+
+	first := postWithKey(r, "retry-key", `{"amount": 10}`)
+	second := postWithKey(r, "retry-key", `{"amount": 10}`)
+
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+}
+
+func TestIdempotency_RejectsKeyReuseWithDifferentBody(t *testing.T) {
+	r, calls := setupIdempotencyRouter(t, http.StatusCreated)
+
+	postWithKey(r, "reused-key", `{"amount": 10}`)
+	resp := postWithKey(r, "reused-key", `{"amount": 20}`)
+
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+}
+
+func TestIdempotency_WithoutHeaderAlwaysExecutes(t *testing.T) {
+	r, calls := setupIdempotencyRouter(t, http.StatusCreated)
+
+	postWithKey(r, "", `{"amount": 10}`)
+	postWithKey(r, "", `{"amount": 10}`)
+
+	assert.Equal(t, 2, *calls)
+}
+
+func TestIdempotency_ServerErrorsAreNotStored(t *testing.T) {
+	r, calls := setupIdempotencyRouter(t, http.StatusInternalServerError)
+
+	postWithKey(r, "failing-key", `{"amount": 10}`)
+	postWithKey(r, "failing-key", `{"amount": 10}`)
+
+	assert.Equal(t, 2, *calls)
+}
+
+func TestIdempotency_KeysAreScopedToTheCaller(t *testing.T) {
+	r, calls := setupIdempotencyRouter(t, http.StatusCreated)
+
+	postAs(r, "user-1", "shared-key", `{"amount": 10}`)
+	sameBody := postAs(r, "user-2", "shared-key", `{"amount": 10}`)
+	otherBody := postAs(r, "user-3", "shared-key", `{"amount": 20}`)
+
+	assert.Equal(t, 3, *calls)
+	assert.Equal(t, http.StatusCreated, sameBody.Code)
+	assert.Empty(t, sameBody.Header().Get("Idempotent-Replayed"))
+	assert.JSONEq(t, `{"call": 2}`, sameBody.Body.String())
+	assert.Equal(t, http.StatusCreated, otherBody.Code)
+}
+
+func TestIdempotency_RejectsOversizedBody(t *testing.T) {
+	r, calls := setupIdempotencyRouter(t, http.StatusCreated)
+
+	resp := postWithKey(r, "large-key", `{"memo": "`+strings.Repeat("x", 1<<10)+`"}`)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+	assert.Equal(t, 0, *calls)
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/problem"
+)
+
+// Timeout puts a deadline on the request context so database work is
+// cancelled once the caller can no longer get an answer in time. Handlers
+// that run past the deadline without responding get a 504.
+func Timeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			problem.Abort(c, http.StatusGatewayTimeout, apperrors.CodeTimeout, "request timed out")
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/config"
+	"secure-payment-service/internal/logging"
+	"secure-payment-service/internal/metrics"
+	"secure-payment-service/internal/problem"
+	"secure-payment-service/internal/repository"
+)
+
+const (
+	WebhookProviderHeader  = "X-Webhook-Provider"
+	WebhookIDHeader        = "X-Webhook-Id"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+
+	// WebhookProviderKey is the gin context key holding the provider whose
+	// signature was verified.
+	WebhookProviderKey = "webhook_provider"
+
+	webhookSignaturePrefix = "sha256="
+)
+
+// WebhookSignature accepts only webhook calls signed by a configured provider.
+// The signature is an HMAC-SHA256 of "<event id>.<unix timestamp>.<raw body>"
+// keyed with the provider secret. Events outside the tolerance window and
+// event IDs that were already accepted are rejected, as are bodies over
+// cfg.MaxBodyBytes.
+func WebhookSignature(cfg config.WebhookConfig, deliveries repository.WebhookDeliveryRepository) gin.HandlerFunc {
+	now := time.Now
+
+	return func(c *gin.Context) {
+		provider := c.GetHeader(WebhookProviderHeader)
+		eventID := c.GetHeader(WebhookIDHeader)
+		timestamp := c.GetHeader(WebhookTimestampHeader)
+		signature := c.GetHeader(WebhookSignatureHeader)
+
+		if provider == "" || eventID == "" || timestamp == "" || signature == "" {
+			rejectWebhook(c, http.StatusUnauthorized, "missing_headers", "webhook signature headers are required")
+			return
+		}
+
+		secret, ok := cfg.Secrets[provider]
+		if !ok {
+			rejectWebhook(c, http.StatusUnauthorized, "unknown_provider", "unknown webhook provider")
+			return
+		}
+
+		sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			rejectWebhook(c, http.StatusUnauthorized, "invalid_timestamp", "invalid webhook timestamp")
+			return
+		}
+		age := now().Sub(time.Unix(sentAt, 0))
+		if age > cfg.Tolerance || age < -cfg.Tolerance {
+			rejectWebhook(c, http.StatusUnauthorized, "stale", "webhook timestamp is outside the tolerance window")
+			return
+		}
+
+		// The caller is not authenticated yet, so never buffer more than
+		// the configured cap.
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, cfg.MaxBodyBytes))
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			problem.Abort(c, http.StatusRequestEntityTooLarge, apperrors.CodeInvalidRequest, fmt.Sprintf("request body must be at most %d bytes", cfg.MaxBodyBytes))
+			return
+		case err != nil:
+			problem.Abort(c, http.StatusBadRequest, apperrors.CodeInvalidRequest, "could not read request body")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		if !validWebhookSignature(secret, eventID, timestamp, body, signature) {
+			rejectWebhook(c, http.StatusUnauthorized, "invalid_signature", "invalid webhook signature")
+			return
+		}
+
+		recorded, err := deliveries.Record(c.Request.Context(), provider, eventID)
+		if err != nil {
+			logging.Logger.WithError(err).Error("failed to record webhook delivery")
+			problem.Abort(c, http.StatusInternalServerError, apperrors.CodeInternal, "could not process webhook")
+			return
+		}
+		if !recorded {
+			rejectWebhook(c, http.StatusConflict, "replayed", "webhook event was already processed")
+			return
+		}
+
+		c.Set(WebhookProviderKey, provider)
+		c.Next()
+
+		// Let the provider redeliver events we failed to handle.
+		if c.Writer.Status() >= http.StatusInternalServerError {
+			if err := deliveries.Forget(context.WithoutCancel(c.Request.Context()), provider, eventID); err != nil {
+				logging.Logger.WithError(err).Error("failed to forget webhook delivery")
+			}
+		}
+	}
+}
+
+// SignWebhook returns the signature header value for a webhook payload.
+func SignWebhook(secret, eventID, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(eventID))
+	mac.Write([]byte("."))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return webhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func validWebhookSignature(secret, eventID, timestamp string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, webhookSignaturePrefix) {
+		return false
+	}
+	expected := SignWebhook(secret, eventID, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+func rejectWebhook(c *gin.Context, status int, reason, message string) {
+	metrics.WebhookRejectionsTotal.WithLabelValues(reason).Inc()
+	logging.Logger.WithField("reason", reason).Warn("rejected webhook")
+	code := apperrors.CodeWebhookRejected
+	if status == http.StatusConflict {
+		code = apperrors.CodeWebhookReplayed
+	}
+	problem.Abort(c, status, code, message)
+}
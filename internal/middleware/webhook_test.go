@@ -0,0 +1,144 @@
+package middleware_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"secure-payment-service/internal/config"
+	"secure-payment-service/internal/middleware"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/repository"
+)
+
+const webhookSecret = "whsec_test"
+
+func setupWebhookRouter(t *testing.T, status int) (*gin.Engine, *[]string) {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&models.WebhookDelivery{}))
+
+	cfg := config.WebhookConfig{
+		Secrets:      map[string]string{"acme": webhookSecret},
+		Tolerance:    5 * time.Minute,
+		MaxBodyBytes: 1 << 10,
+	}
+
+	var providers []string
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/webhook", middleware.WebhookSignature(cfg, repository.NewGormWebhookDeliveryRepository(db)), func(c *gin.Context) {
+		providers = append(providers, c.GetString(middleware.WebhookProviderKey))
+		c.JSON(status, gin.H{"status": "ok"})
+	})
+	return r, &providers
+}
+
+func postWebhook(r *gin.Engine, provider, eventID string, sentAt time.Time, signature, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.WebhookProviderHeader, provider)
+	req.Header.Set(middleware.WebhookIDHeader, eventID)
+	req.Header.Set(middleware.WebhookTimestampHeader, strconv.FormatInt(sentAt.Unix(), 10))
+	if signature != "" {
+		req.Header.Set(middleware.WebhookSignatureHeader, signature)
+	}
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+	return resp
+}
+
+func signedWebhook(r *gin.Engine, eventID string, sentAt time.Time, body string) *httptest.ResponseRecorder {
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	signature := middleware.SignWebhook(webhookSecret, eventID, timestamp, []byte(body))
+	return postWebhook(r, "acme", eventID, sentAt, signature, body)
+}
+
+func TestWebhookSignature_AcceptsSignedEvent(t *testing.T) {
+	r, providers := setupWebhookRouter(t, http.StatusOK)
+
+	resp := signedWebhook(r, "evt_1", time.Now(), `{"transfer_id":"t1","status":"COMPLETED"}`)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, []string{"acme"}, *providers)
+}
+
+func TestWebhookSignature_RejectsUnsignedEvent(t *testing.T) {
+	r, providers := setupWebhookRouter(t, http.StatusOK)
+
+	resp := postWebhook(r, "acme", "evt_1", time.Now(), "", `{"transfer_id":"t1"}`)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Empty(t, *providers)
+}
+
+func TestWebhookSignature_RejectsTamperedBody(t *testing.T) {
+	r, providers := setupWebhookRouter(t, http.StatusOK)
+
+	sentAt := time.Now()
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	signature := middleware.SignWebhook(webhookSecret, "evt_1", timestamp, []byte(`{"status":"FAILED"}`))
+	resp := postWebhook(r, "acme", "evt_1", sentAt, signature, `{"status":"COMPLETED"}`)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Empty(t, *providers)
+}
+
+func TestWebhookSignature_RejectsOversizedBody(t *testing.T) {
+	r, providers := setupWebhookRouter(t, http.StatusOK)
+
+	resp := signedWebhook(r, "evt_1", time.Now(), `{"transfer_id":"`+strings.Repeat("t", 1<<10)+`"}`)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+	assert.Empty(t, *providers)
+}
+
+func TestWebhookSignature_RejectsUnknownProvider(t *testing.T) {
+	r, _ := setupWebhookRouter(t, http.StatusOK)
+
+	sentAt := time.Now()
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	signature := middleware.SignWebhook(webhookSecret, "evt_1", timestamp, []byte(`{}`))
+	resp := postWebhook(r, "unknown", "evt_1", sentAt, signature, `{}`)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
+
+func TestWebhookSignature_RejectsStaleTimestamp(t *testing.T) {
+	r, providers := setupWebhookRouter(t, http.StatusOK)
+
+	resp := signedWebhook(r, "evt_1", time.Now().Add(-10*time.Minute), `{}`)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Empty(t, *providers)
+}
+
+func TestWebhookSignature_RejectsReplayedEvent(t *testing.T) {
+	r, providers := setupWebhookRouter(t, http.StatusOK)
+
+	first := signedWebhook(r, "evt_1", time.Now(), `{}`)
+	replay := signedWebhook(r, "evt_1", time.Now(), `{}`)
+
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusConflict, replay.Code)
+	assert.Len(t, *providers, 1)
+}
+
+func TestWebhookSignature_AllowsRedeliveryAfterServerError(t *testing.T) {
+	r, providers := setupWebhookRouter(t, http.StatusInternalServerError)
+
+	signedWebhook(r, "evt_1", time.Now(), `{}`)
+	resp := signedWebhook(r, "evt_1", time.Now(), `{}`)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Len(t, *providers, 2)
+}
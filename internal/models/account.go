@@ -0,0 +1,17 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// Account is a holder of funds. Accounts opened through the API carry an
+// owner and may be restricted to one currency; settlement accounts and those
+// carried over from before accounts had to be opened have neither.
+type Account struct {
+	gorm.Model `json:"-"`
+	AccountID  string            `gorm:"uniqueIndex" json:"account_id"`
+	Owner      string            `gorm:"index" json:"owner,omitempty"`
+	Currency   string            `json:"currency,omitempty"`
+	Status     string            `gorm:"not null;default:ACTIVE" json:"status"`
+	Metadata   map[string]string `gorm:"type:text;serializer:json" json:"metadata,omitempty"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"secure-payment-service/internal/money"
+)
+
+type Balance struct {
+	Currency string       `json:"currency"`
+	Balance  money.Amount `json:"balance"`
+}
+
+// AccountBalance splits the balance of one currency into what has been posted
+// to the ledger, what is held by transfers in flight and what is left to spend.
+type AccountBalance struct {
+	Currency  string       `json:"currency"`
+	Ledger    money.Amount `json:"ledger"`
+	Pending   money.Amount `json:"pending"`
+	Available money.Amount `json:"available"`
+}
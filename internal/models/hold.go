@@ -0,0 +1,19 @@
+package models
+
+import (
+	"gorm.io/gorm"
+
+	"secure-payment-service/internal/money"
+)
+
+// Hold reserves funds of a transfer's source account while the transfer is
+// in flight. It is captured when the transfer completes and released when it
+// fails, so pending transfers reduce what the account can spend.
+type Hold struct {
+	gorm.Model
+	TransferID string `gorm:"uniqueIndex"`
+	AccountID  string `gorm:"index:idx_holds_account_status"`
+	Status     string `gorm:"index:idx_holds_account_status"`
+	Amount     money.Amount
+	Currency   string
+}
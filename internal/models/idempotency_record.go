@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IdempotencyRecord stores the outcome of a request sent with an Idempotency-Key
+// header. Keys are scoped to the caller's Subject. ResponseCode stays zero
+// while the original request is in flight, which holds the key until
+// LockedUntil at most.
+type IdempotencyRecord struct {
+	gorm.Model
+	Subject        string `gorm:"uniqueIndex:idx_idempotency_records_subject_key,priority:1"`
+	IdempotencyKey string `gorm:"uniqueIndex:idx_idempotency_records_subject_key,priority:2"`
+	Fingerprint    string
+	LockedUntil    time.Time
+	ResponseCode   int
+	ContentType    string
+	ResponseBody   []byte
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"gorm.io/gorm"
+
+	"secure-payment-service/internal/money"
+)
+
+// LedgerEntry is a single posting against an account. Debits carry a negative
+// amount and credits a positive one, so the entries of a transfer sum to zero.
+type LedgerEntry struct {
+	gorm.Model
+	EntryID    string `gorm:"uniqueIndex"`
+	TransferID string `gorm:"index"`
+	AccountID  string `gorm:"index"`
+	Direction  string
+	Amount     money.Amount
+	Currency   string
+}
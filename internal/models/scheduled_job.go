@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ScheduledJob is a unit of background work persisted so it survives restarts.
+// Reference identifies the entity the job acts on, e.g. a transfer ID.
+type ScheduledJob struct {
+	gorm.Model
+	JobID       string `gorm:"uniqueIndex"`
+	Kind        string
+	Reference   string `gorm:"index"`
+	Status      string `gorm:"index:idx_scheduled_jobs_due,priority:1"`
+	Attempts    int
+	MaxAttempts int
+	NextRunAt   time.Time `gorm:"index:idx_scheduled_jobs_due,priority:2"`
+	LockedUntil *time.Time
+	LastError   string
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"secure-payment-service/internal/money"
+)
+
+// StandingOrder creates a transfer on every occurrence of its schedule, which
+// is either a Frequency counted from StartAt or a Cron expression evaluated in
+// UTC. It stops after EndAt or once Executions reaches MaxExecutions, when
+// those are set.
+type StandingOrder struct {
+	gorm.Model    `json:"-"`
+	OrderID       string       `gorm:"uniqueIndex" json:"standing_order_id"`
+	FromAccount   string       `gorm:"index" json:"source_account_id"`
+	ToAccount     string       `gorm:"index" json:"destination_account_id"`
+	Amount        money.Amount `json:"amount"`
+	Currency      string       `json:"currency"`
+	Frequency     string       `json:"frequency,omitempty"`
+	Cron          string       `json:"cron,omitempty"`
+	StartAt       time.Time    `json:"start_at"`
+	EndAt         *time.Time   `json:"end_at,omitempty"`
+	MaxExecutions int          `json:"max_executions,omitempty"`
+	Executions    int          `gorm:"not null;default:0" json:"executions"`
+	Status        string       `gorm:"index" json:"status"`
+	// NextRunAt is the occurrence that runs next; it is nil while the order
+	// is not ACTIVE.
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+	// JobID is the scheduled job that will run NextRunAt. Jobs left behind by
+	// a pause or resume no longer match it and do nothing.
+	JobID     string `json:"-"`
+	CreatedBy string `json:"created_by,omitempty"`
+}
+
+// StandingOrderExecution records one occurrence of a standing order and the
+// transfer it created, or why it could not create one.
+type StandingOrderExecution struct {
+	gorm.Model   `json:"-"`
+	OrderID      string    `gorm:"uniqueIndex:idx_standing_order_executions_occurrence,priority:1" json:"standing_order_id"`
+	ScheduledFor time.Time `gorm:"uniqueIndex:idx_standing_order_executions_occurrence,priority:2" json:"scheduled_for"`
+	Status       string    `json:"status"`
+	TransferID   string    `json:"transfer_id,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	ExecutedAt   time.Time `json:"executed_at"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"secure-payment-service/internal/money"
+)
+
+// StatementLine is a ledger entry of an account together with the running
+// balance right after it was booked.
+type StatementLine struct {
+	EntryID      string       `json:"entry_id"`
+	TransferID   string       `json:"transfer_id"`
+	BookedAt     time.Time    `json:"booked_at"`
+	Direction    string       `json:"direction"`
+	Counterparty string       `json:"counterparty"`
+	Currency     string       `json:"-"`
+	Amount       money.Amount `json:"amount"`
+	Balance      money.Amount `json:"balance"`
+}
+
+// CurrencyStatement holds the movements of one currency over the period.
+type CurrencyStatement struct {
+	Currency       string          `json:"currency"`
+	OpeningBalance money.Amount    `json:"opening_balance"`
+	ClosingBalance money.Amount    `json:"closing_balance"`
+	Lines          []StatementLine `json:"lines"`
+}
+
+// Statement covers [From, To) for an account, one section per currency that
+// had a balance or a movement in the period.
+type Statement struct {
+	AccountID   string              `json:"account_id"`
+	From        time.Time           `json:"from"`
+	To          time.Time           `json:"to"`
+	GeneratedAt time.Time           `json:"generated_at"`
+	Currencies  []CurrencyStatement `json:"currencies"`
+}
@@ -1,15 +1,76 @@
 package models
 
 import (
+	"time"
+
 	"gorm.io/gorm"
+
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/money"
 )
 
+// Transfer spells out the gorm.Model fields so CreatedAt and ID can be part of
+// the composite indexes that back the listing endpoint.
 type Transfer struct {
-	gorm.Model
-	TransferID  string `gorm:"uniqueIndex"`
-	FromAccount string
-	ToAccount   string
-	Amount      float64
+	ID          uint      `gorm:"primarykey;index:idx_transfers_from_created,priority:3;index:idx_transfers_to_created,priority:3;index:idx_transfers_created,priority:2"`
+	CreatedAt   time.Time `gorm:"index:idx_transfers_from_created,priority:2;index:idx_transfers_to_created,priority:2;index:idx_transfers_created,priority:1"`
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	TransferID  string         `gorm:"uniqueIndex"`
+	FromAccount string         `gorm:"index:idx_transfers_from_created,priority:1"`
+	ToAccount   string         `gorm:"index:idx_transfers_to_created,priority:1"`
+	Amount      money.Amount
 	Currency    string
-	Status      string
+	Status      string `gorm:"index"`
+	// Version starts at 1 and is incremented by every update. It is exposed
+	// as the ETag of the transfer.
+	Version uint `gorm:"not null;default:1"`
+	// RefundOf links a refund to the transfer it gives money back for, which
+	// keeps the running total in RefundedAmount.
+	RefundOf       string       `gorm:"index"`
+	RefundedAmount money.Amount `gorm:"not null;default:0"`
+	// ExecuteAt is set on future-dated transfers, which stay SCHEDULED until
+	// then.
+	ExecuteAt *time.Time `gorm:"index"`
+	// ParentID groups the legs of a multi-leg transfer, which are created
+	// and settled together.
+	ParentID string `gorm:"index"`
+}
+
+// ScheduledTransferEdit changes a transfer that is still SCHEDULED. Nil fields
+// are left as they are.
+type ScheduledTransferEdit struct {
+	ToAccount *string
+	Amount    *money.Amount
+	ExecuteAt *time.Time
+	// IfMatch lists the versions the caller expects the transfer to be at.
+	IfMatch []uint
+}
+
+// TransferCursor marks the last transfer of a listing page. Listings are
+// ordered newest first by CreatedAt, with ID breaking ties.
+type TransferCursor struct {
+	CreatedAt time.Time
+	ID        uint
+}
+
+// TransferFilter selects transfers for a listing. Zero values mean no filter.
+// Accounts are matched against the side given by AccountRole.
+type TransferFilter struct {
+	Accounts    []string
+	AccountRole enums.AccountRole
+	Statuses    []string
+	Currency    string
+	MinAmount   *money.Amount
+	MaxAmount   *money.Amount
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	After       *TransferCursor
+	Limit       int
+}
+
+// TransferPage is one page of a listing. Next is nil on the last page.
+type TransferPage struct {
+	Transfers []Transfer
+	Next      *TransferCursor
 }
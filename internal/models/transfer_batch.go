@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/money"
+)
+
+// TransferBatch is a bulk upload of transfers. Its rows are created in the
+// background; the row counts are not stored but counted when it is read.
+type TransferBatch struct {
+	gorm.Model  `json:"-"`
+	BatchID     string     `gorm:"uniqueIndex" json:"batch_id"`
+	Format      string     `json:"format"`
+	Status      string     `gorm:"index" json:"status"`
+	TotalRows   int        `json:"total_rows"`
+	PendingRows int        `gorm:"-" json:"pending_rows"`
+	CreatedRows int        `gorm:"-" json:"created_rows"`
+	FailedRows  int        `gorm:"-" json:"failed_rows"`
+	CreatedBy   string     `json:"created_by,omitempty"`
+	SubmittedAt time.Time  `json:"submitted_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// TransferBatchRow is one transfer of a batch and its outcome: the transfer
+// it created, or the code and message of why it could not, with the invalid
+// fields when the row itself was at fault.
+type TransferBatchRow struct {
+	gorm.Model  `json:"-"`
+	BatchID     string                 `gorm:"uniqueIndex:idx_transfer_batch_rows_line,priority:1" json:"-"`
+	Line        int                    `gorm:"uniqueIndex:idx_transfer_batch_rows_line,priority:2" json:"line"`
+	FromAccount string                 `json:"source_account_id,omitempty"`
+	ToAccount   string                 `json:"destination_account_id,omitempty"`
+	Amount      money.Amount           `json:"amount"`
+	Currency    string                 `json:"currency,omitempty"`
+	ExecuteAt   *time.Time             `json:"execute_at,omitempty"`
+	Status      string                 `gorm:"index" json:"status"`
+	TransferID  string                 `json:"transfer_id,omitempty"`
+	ErrorCode   string                 `json:"error_code,omitempty"`
+	Error       string                 `json:"error,omitempty"`
+	Fields      []apperrors.FieldError `gorm:"serializer:json" json:"errors,omitempty"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/money"
+)
+
+// TransferLeg is one destination of a multi-leg transfer.
+type TransferLeg struct {
+	ToAccount string
+	Amount    money.Amount
+}
+
+// TransferGroup is a multi-leg transfer: one payment from a source account
+// split into legs that share ParentID. It is not stored; its total and status
+// are derived from the legs.
+type TransferGroup struct {
+	ParentID    string       `json:"parent_transfer_id"`
+	FromAccount string       `json:"source_account_id"`
+	Amount      money.Amount `json:"amount"`
+	Currency    string       `json:"currency"`
+	Status      string       `json:"status"`
+	Legs        []Transfer   `json:"legs"`
+}
+
+// NewTransferGroup builds the group of legs, which must all share ParentID.
+func NewTransferGroup(legs []Transfer) TransferGroup {
+	group := TransferGroup{Legs: legs}
+	if len(legs) == 0 {
+		return group
+	}
+
+	group.ParentID = legs[0].ParentID
+	group.FromAccount = legs[0].FromAccount
+	group.Currency = legs[0].Currency
+	statuses := make([]enums.TransactionStatus, len(legs))
+	for i, leg := range legs {
+		group.Amount = group.Amount.Add(leg.Amount)
+		statuses[i] = enums.TransactionStatus(leg.Status)
+	}
+	group.Status = enums.GroupStatus(statuses).String()
+	return group
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"gorm.io/gorm"
+
+	"secure-payment-service/internal/enums"
+)
+
+type TransferStatusHistory struct {
+	gorm.Model
+	TransferID string `gorm:"index"`
+	FromStatus string
+	ToStatus   string
+	Source     string
+	Actor      string
+	Reason     string
+}
+
+// StatusChange is a requested status transition together with who asked for
+// it, so the change can be audited.
+type StatusChange struct {
+	Status string
+	Source enums.TransitionSource
+	Actor  string
+	Reason string
+	// IfMatch lists the versions the caller expects the transfer to be at.
+	// Empty means any version.
+	IfMatch []uint
+}
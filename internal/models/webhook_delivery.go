@@ -0,0 +1,13 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// WebhookDelivery records an inbound webhook event that has been accepted, so
+// a replay of the same provider event ID can be rejected.
+type WebhookDelivery struct {
+	gorm.Model
+	Provider string `gorm:"uniqueIndex:idx_webhook_deliveries_event"`
+	EventID  string `gorm:"uniqueIndex:idx_webhook_deliveries_event"`
+}
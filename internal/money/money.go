@@ -0,0 +1,173 @@
+package money
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Scale is the number of decimal places carried by every Amount. Four places
+// cover the finest minor unit of any ISO 4217 currency.
+const Scale = 4
+
+const unit = 10000
+
+// Amount is an exact fixed-point monetary value stored as an integer number
+// of 1/10^Scale units.
+type Amount int64
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+func Parse(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrInvalidAmount
+	}
+
+	negative := false
+	if s[0] == '-' {
+		negative = true
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+	if intPart == "" || (hasFrac && fracPart == "") || !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if len(fracPart) > Scale {
+		return 0, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidAmount, s, Scale)
+	}
+
+	whole, err := strconv.ParseInt(intPart, 10, 64)
+	fracPart += strings.Repeat("0", Scale-len(fracPart))
+	frac, _ := strconv.ParseInt(fracPart, 10, 64)
+	if err != nil || whole > (math.MaxInt64-frac)/unit {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, s)
+	}
+
+	value := Amount(whole*unit + frac)
+	if negative {
+		value = -value
+	}
+	return value, nil
+}
+
+func MustParse(s string) Amount {
+	a, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (a Amount) String() string {
+	sign := ""
+	v := int64(a)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+
+	whole := v / unit
+	frac := v % unit
+	if frac == 0 {
+		return fmt.Sprintf("%s%d", sign, whole)
+	}
+
+	fracStr := strings.TrimRight(fmt.Sprintf("%0*d", Scale, frac), "0")
+	return fmt.Sprintf("%s%d.%s", sign, whole, fracStr)
+}
+
+// StringFixed formats the amount with exactly the given number of decimal
+// places, e.g. "100.50" for two places.
+func (a Amount) StringFixed(places int) string {
+	s := a.String()
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if places <= 0 {
+		return intPart
+	}
+	if len(fracPart) < places {
+		fracPart += strings.Repeat("0", places-len(fracPart))
+	}
+	return intPart + "." + fracPart
+}
+
+func (a Amount) Add(b Amount) Amount {
+	return a + b
+}
+
+func (a Amount) Sub(b Amount) Amount {
+	return a - b
+}
+
+func (a Amount) Neg() Amount {
+	return -a
+}
+
+func (a Amount) IsZero() bool {
+	return a == 0
+}
+
+func (a Amount) IsPositive() bool {
+	return a > 0
+}
+
+func (a Amount) IsNegative() bool {
+	return a < 0
+}
+
+// FitsPrecision reports whether the amount can be expressed in the minor
+// units of the given currency.
+func (a Amount) FitsPrecision(currency string) bool {
+	places, ok := Precision(currency)
+	if !ok {
+		return false
+	}
+
+	step := int64(1)
+	for i := places; i < Scale; i++ {
+		step *= 10
+	}
+	return int64(a)%step == 0
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON accepts both JSON numbers and strings. The literal is parsed
+// directly so no float conversion ever takes place.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	s := string(data)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		unquoted, err := strconv.Unquote(s)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidAmount, s)
+		}
+		s = unquoted
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
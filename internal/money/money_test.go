@@ -0,0 +1,109 @@
+package money_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"secure-payment-service/internal/money"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected money.Amount
+		err      bool
+	}{
+		{"0", 0, false},
+		{"100", 1000000, false},
+		{"100.5", 1005000, false},
+		{"100.50", 1005000, false},
+		{"0.0001", 1, false},
+		{"-12.34", -123400, false},
+		{"", 0, true},
+		{"abc", 0, true},
+		{"1.", 0, true},
+		{".5", 0, true},
+		{"1e3", 0, true},
+		{"0.00001", 0, true},
+		{"99999999999999999999", 0, true},
+		{"922337203685477.5807", math.MaxInt64, false},
+		{"922337203685477.5808", 0, true},
+		{"922337203685477.9999", 0, true},
+		{"922337203685478", 0, true},
+		{"-922337203685477.5807", -math.MaxInt64, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			amount, err := money.Parse(tt.input)
+			if tt.err {
+				assert.Error(t, err)
+				assert.True(t, errors.Is(err, money.ErrInvalidAmount))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, amount)
+			}
+		})
+	}
+}
+
+func TestAmount_String(t *testing.T) {
+	assert.Equal(t, "0", money.Amount(0).String())
+	assert.Equal(t, "100.5", money.MustParse("100.50").String())
+	assert.Equal(t, "-0.25", money.MustParse("-0.25").String())
+	assert.Equal(t, "100.50", money.MustParse("100.5").StringFixed(2))
+	assert.Equal(t, "100", money.MustParse("100.5").StringFixed(0))
+}
+
+func TestAmount_AdditionIsExact(t *testing.T) {
+	sum := money.MustParse("0.1").Add(money.MustParse("0.2"))
+	assert.Equal(t, money.MustParse("0.3"), sum)
+	assert.True(t, sum.Sub(money.MustParse("0.3")).IsZero())
+}
+
+func TestAmount_JSON(t *testing.T) {
+	var payload struct {
+		Amount money.Amount `json:"amount"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": 100.10}`), &payload))
+	assert.Equal(t, money.MustParse("100.10"), payload.Amount)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": "0.3"}`), &payload))
+	assert.Equal(t, money.MustParse("0.3"), payload.Amount)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount": 1.123456}`), &payload))
+	assert.Error(t, json.Unmarshal([]byte(`{"amount": true}`), &payload))
+
+	encoded, err := json.Marshal(struct {
+		Amount money.Amount `json:"amount"`
+	}{money.MustParse("0.3")})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount": 0.3}`, string(encoded))
+}
+
+func TestAmount_FitsPrecision(t *testing.T) {
+	assert.True(t, money.MustParse("10.25").FitsPrecision("USD"))
+	assert.False(t, money.MustParse("10.255").FitsPrecision("USD"))
+	assert.True(t, money.MustParse("1000").FitsPrecision("JPY"))
+	assert.False(t, money.MustParse("1000.5").FitsPrecision("JPY"))
+	assert.True(t, money.MustParse("1.125").FitsPrecision("KWD"))
+	assert.False(t, money.MustParse("1").FitsPrecision("XXX"))
+}
+
+func TestPrecision(t *testing.T) {
+	places, ok := money.Precision("EUR")
+	assert.True(t, ok)
+	assert.Equal(t, 2, places)
+
+	places, ok = money.Precision("JPY")
+	assert.True(t, ok)
+	assert.Equal(t, 0, places)
+
+	_, ok = money.Precision("usd")
+	assert.False(t, ok)
+}
@@ -0,0 +1,94 @@
+package problem
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/logging"
+)
+
+const ContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body. Code is a stable,
+// machine-readable extension member clients can branch on.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+
+	Errors []apperrors.FieldError `json:"errors,omitempty"`
+}
+
+type mapping struct {
+	kind   error
+	status int
+	code   string
+}
+
+// mappings is checked in order; the first category err belongs to decides
+// the response status.
+var mappings = []mapping{
+	{apperrors.ErrNotFound, http.StatusNotFound, "not_found"},
+	{apperrors.ErrValidation, http.StatusBadRequest, apperrors.CodeValidationFailed},
+	{apperrors.ErrInvalidTransition, http.StatusConflict, apperrors.CodeInvalidTransition},
+	{apperrors.ErrConflict, http.StatusConflict, apperrors.CodeConflict},
+	{apperrors.ErrInsufficientFunds, http.StatusUnprocessableEntity, apperrors.CodeInsufficientFunds},
+	{apperrors.ErrPrecondition, http.StatusPreconditionFailed, apperrors.CodeVersionMismatch},
+	{context.DeadlineExceeded, http.StatusGatewayTimeout, apperrors.CodeTimeout},
+}
+
+// New builds the problem for a status and code, using "about:blank" as the
+// type so the title is the HTTP status text.
+func New(c *gin.Context, status int, code, detail string) Problem {
+	return Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: c.Request.URL.Path,
+		Code:     code,
+	}
+}
+
+// Abort writes a problem response and stops the handler chain.
+func Abort(c *gin.Context, status int, code, detail string) {
+	write(c, New(c, status, code, detail))
+}
+
+func write(c *gin.Context, p Problem) {
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
+
+// AbortWithError maps err to a problem response. Errors outside the known
+// categories become a 500 whose detail does not leak internals.
+func AbortWithError(c *gin.Context, err error) {
+	for _, m := range mappings {
+		if errors.Is(err, m.kind) {
+			detail := err.Error()
+			if m.kind == context.DeadlineExceeded {
+				detail = "request timed out"
+			}
+			p := New(c, m.status, apperrors.Code(err, m.code), detail)
+
+			var validationErr *apperrors.ValidationError
+			if errors.As(err, &validationErr) {
+				p.Detail = "the request has invalid fields"
+				p.Errors = validationErr.Fields
+			}
+
+			write(c, p)
+			return
+		}
+	}
+
+	logging.Logger.WithError(err).WithField("path", c.Request.URL.Path).Error("unhandled error")
+	Abort(c, http.StatusInternalServerError, apperrors.CodeInternal, "internal server error")
+}
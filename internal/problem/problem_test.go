@@ -0,0 +1,53 @@
+package problem_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/problem"
+)
+
+func TestAbortWithError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"not_found", apperrors.New(apperrors.ErrNotFound, apperrors.CodeTransferNotFound, "transfer not found"), http.StatusNotFound, apperrors.CodeTransferNotFound},
+		{"wrapped_not_found", fmt.Errorf("loading: %w", apperrors.New(apperrors.ErrNotFound, apperrors.CodeAccountNotFound, "account not found")), http.StatusNotFound, apperrors.CodeAccountNotFound},
+		{"invalid_status", &enums.InvalidStatusError{Value: "DONE"}, http.StatusBadRequest, apperrors.CodeInvalidStatus},
+		{"invalid_transition", &enums.InvalidTransitionError{From: enums.COMPLETED, To: enums.PENDING}, http.StatusConflict, apperrors.CodeInvalidTransition},
+		{"insufficient_funds", apperrors.New(apperrors.ErrInsufficientFunds, apperrors.CodeInsufficientFunds, "insufficient funds"), http.StatusUnprocessableEntity, apperrors.CodeInsufficientFunds},
+		{"conflict_without_code", fmt.Errorf("%w: duplicate", apperrors.ErrConflict), http.StatusConflict, apperrors.CodeConflict},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, apperrors.CodeTimeout},
+		{"unknown", errors.New("connection refused"), http.StatusInternalServerError, apperrors.CodeInternal},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/resource", func(c *gin.Context) {
+				problem.AbortWithError(c, tt.err)
+			})
+
+			resp := httptest.NewRecorder()
+			r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/resource", nil))
+
+			assert.Equal(t, tt.status, resp.Code)
+			assert.Equal(t, problem.ContentType, resp.Header().Get("Content-Type"))
+			assert.Contains(t, resp.Body.String(), fmt.Sprintf(`"code":"%s"`, tt.code))
+			assert.Contains(t, resp.Body.String(), `"instance":"/resource"`)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package recurrence
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Schedule yields the occurrences of a recurring event.
+type Schedule interface {
+	// Next returns the first occurrence strictly after t.
+	Next(t time.Time) time.Time
+}
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+func (f Frequency) String() string {
+	return string(f)
+}
+
+func (f Frequency) IsValid() bool {
+	switch f {
+	case Daily, Weekly, Monthly:
+		return true
+	}
+	return false
+}
+
+// Every returns the schedule that repeats at frequency starting at anchor,
+// which is itself the first occurrence. Monthly occurrences keep the day of
+// month of the anchor and fall on the last day of shorter months, so an anchor
+// on the 31st runs on Feb 28 and then on Mar 31.
+func Every(frequency Frequency, anchor time.Time) (Schedule, error) {
+	if !frequency.IsValid() {
+		return nil, fmt.Errorf("'%s' is not one of DAILY, WEEKLY, MONTHLY", frequency)
+	}
+	return interval{frequency: frequency, anchor: anchor}, nil
+}
+
+type interval struct {
+	frequency Frequency
+	anchor    time.Time
+}
+
+func (s interval) Next(t time.Time) time.Time {
+	if t.Before(s.anchor) {
+		return s.anchor
+	}
+
+	// Estimate how many periods have passed and step forward from there, so
+	// the cost does not grow with the age of the schedule.
+	var n int
+	switch s.frequency {
+	case Daily:
+		n = int(t.Sub(s.anchor).Hours() / 24)
+	case Weekly:
+		n = int(t.Sub(s.anchor).Hours() / (24 * 7))
+	case Monthly:
+		n = (t.Year()-s.anchor.Year())*12 + int(t.Month()) - int(s.anchor.Month())
+	}
+	n = max(n-1, 0)
+
+	for {
+		occurrence := s.occurrence(n)
+		if occurrence.After(t) {
+			return occurrence
+		}
+		n++
+	}
+}
+
+func (s interval) occurrence(n int) time.Time {
+	switch s.frequency {
+	case Daily:
+		return s.anchor.AddDate(0, 0, n)
+	case Weekly:
+		return s.anchor.AddDate(0, 0, 7*n)
+	}
+
+	year, month, day := s.anchor.Date()
+	first := time.Date(year, month+time.Month(n), 1, s.anchor.Hour(), s.anchor.Minute(), s.anchor.Second(), s.anchor.Nanosecond(), s.anchor.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, lastDay)-1)
+}
+
+// cronField is the set of allowed values of one cron field as a bit mask.
+type cronField uint64
+
+func (f cronField) has(v int) bool {
+	return f&(1<<uint(v)) != 0
+}
+
+type cronBounds struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronBounds{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// maxCronSearch bounds the search for the next match so expressions that can
+// never fire, like "0 0 30 2 *", do not loop forever.
+const maxCronSearch = 5 * 366 * 24 * time.Hour
+
+type cron struct {
+	minute, hour, dom, month, dow cronField
+	// domAny and dowAny record a "*" in the day fields. As in classic cron,
+	// when both are restricted a day matches if either one does.
+	domAny, dowAny bool
+	location       *time.Location
+}
+
+// ParseCron parses a standard five field cron expression: minute, hour, day
+// of month, month and day of week (0 or 7 is Sunday). Fields accept "*",
+// values, ranges "a-b", steps "*/n" or "a-b/n" and comma separated lists.
+// Occurrences are computed in loc.
+func ParseCron(expr string, loc *time.Location) (Schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("must have %d fields, got %d", len(cronFields), len(parts))
+	}
+
+	fields := make([]cronField, len(parts))
+	for i, part := range parts {
+		field, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = field
+	}
+
+	dow := fields[4]
+	if dow.has(7) {
+		dow |= 1
+	}
+
+	return cron{
+		minute:   fields[0],
+		hour:     fields[1],
+		dom:      fields[2],
+		month:    fields[3],
+		dow:      dow,
+		domAny:   parts[2] == "*",
+		dowAny:   parts[4] == "*",
+		location: loc,
+	}, nil
+}
+
+func parseCronField(expr string, bounds cronBounds) (cronField, error) {
+	var field cronField
+	for _, item := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			n, err := parseCronNumber(stepExpr)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("%s: invalid step '%s'", bounds.name, stepExpr)
+			}
+			step = n
+		}
+
+		low, high := bounds.min, bounds.max
+		if rangeExpr != "*" {
+			lowExpr, highExpr, isRange := strings.Cut(rangeExpr, "-")
+			var err error
+			if low, err = parseCronNumber(lowExpr); err != nil {
+				return 0, fmt.Errorf("%s: invalid value '%s'", bounds.name, lowExpr)
+			}
+			high = low
+			if isRange {
+				if high, err = parseCronNumber(highExpr); err != nil {
+					return 0, fmt.Errorf("%s: invalid value '%s'", bounds.name, highExpr)
+				}
+			} else if hasStep {
+				high = bounds.max
+			}
+		}
+
+		if low < bounds.min || high > bounds.max || low > high {
+			return 0, fmt.Errorf("%s: '%s' is outside %d-%d", bounds.name, item, bounds.min, bounds.max)
+		}
+		for v := low; v <= high; v += step {
+			field |= 1 << uint(v)
+		}
+	}
+	return field, nil
+}
+
+func parseCronNumber(s string) (int, error) {
+	if s == "" || len(s) > 2 {
+		return 0, fmt.Errorf("invalid number '%s'", s)
+	}
+	n := 0
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid number '%s'", s)
+		}
+		n = n*10 + int(r-'0')
+	}
+	return n, nil
+}
+
+// Next walks forward field by field, skipping whole months, days and hours
+// that cannot match. The zero time is returned when nothing matches within
+// maxCronSearch.
+func (c cron) Next(t time.Time) time.Time {
+	t = t.In(c.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxCronSearch)
+
+	for t.Before(limit) {
+		switch {
+		case !c.month.has(int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.location)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.location)
+		case !c.hour.has(t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.location)
+		case !c.minute.has(t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (c cron) dayMatches(t time.Time) bool {
+	domMatch := c.dom.has(t.Day())
+	dowMatch := c.dow.has(int(t.Weekday()))
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dowMatch
+	case c.dowAny:
+		return domMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,100 @@
+package recurrence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"secure-payment-service/internal/recurrence"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestEvery(t *testing.T) {
+	tests := []struct {
+		name      string
+		frequency recurrence.Frequency
+		anchor    string
+		after     string
+		expected  string
+	}{
+		{"before_anchor", recurrence.Daily, "2024-03-10T09:00:00Z", "2024-01-01T00:00:00Z", "2024-03-10T09:00:00Z"},
+		{"anchor_itself_is_excluded", recurrence.Daily, "2024-03-10T09:00:00Z", "2024-03-10T09:00:00Z", "2024-03-11T09:00:00Z"},
+		{"daily_long_after", recurrence.Daily, "2024-03-10T09:00:00Z", "2025-07-04T12:00:00Z", "2025-07-05T09:00:00Z"},
+		{"weekly", recurrence.Weekly, "2024-03-04T08:30:00Z", "2024-03-12T00:00:00Z", "2024-03-18T08:30:00Z"},
+		{"monthly", recurrence.Monthly, "2024-01-15T10:00:00Z", "2024-05-15T10:00:00Z", "2024-06-15T10:00:00Z"},
+		{"monthly_clamps_to_month_end", recurrence.Monthly, "2024-01-31T10:00:00Z", "2024-02-01T00:00:00Z", "2024-02-29T10:00:00Z"},
+		{"monthly_returns_to_anchor_day", recurrence.Monthly, "2024-01-31T10:00:00Z", "2024-02-29T10:00:00Z", "2024-03-31T10:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := recurrence.Every(tt.frequency, date(tt.anchor))
+			assert.NoError(t, err)
+			assert.Equal(t, date(tt.expected), schedule.Next(date(tt.after)))
+		})
+	}
+}
+
+func TestEvery_InvalidFrequency(t *testing.T) {
+	_, err := recurrence.Every("HOURLY", time.Now())
+	assert.Error(t, err)
+}
+
+func TestParseCron_Next(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		after    string
+		expected string
+	}{
+		{"every_minute", "* * * * *", "2024-03-10T09:00:30Z", "2024-03-10T09:01:00Z"},
+		{"daily_at_nine", "0 9 * * *", "2024-03-10T09:00:00Z", "2024-03-11T09:00:00Z"},
+		{"step", "*/15 * * * *", "2024-03-10T09:16:00Z", "2024-03-10T09:30:00Z"},
+		{"weekdays", "30 8 * * 1-5", "2024-03-08T09:00:00Z", "2024-03-11T08:30:00Z"},
+		{"sunday_as_seven", "0 0 * * 7", "2024-03-10T00:00:00Z", "2024-03-17T00:00:00Z"},
+		{"first_of_quarter", "0 6 1 1,4,7,10 *", "2024-02-15T00:00:00Z", "2024-04-01T06:00:00Z"},
+		{"day_of_month_or_week", "0 0 13 * 5", "2024-09-01T00:00:00Z", "2024-09-06T00:00:00Z"},
+		{"leap_day", "0 0 29 2 *", "2024-03-01T00:00:00Z", "2028-02-29T00:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := recurrence.ParseCron(tt.expr, time.UTC)
+			assert.NoError(t, err)
+			assert.Equal(t, date(tt.expected), schedule.Next(date(tt.after)))
+		})
+	}
+}
+
+func TestParseCron_NeverFires(t *testing.T) {
+	schedule, err := recurrence.ParseCron("0 0 30 2 *", time.UTC)
+	assert.NoError(t, err)
+	assert.True(t, schedule.Next(date("2024-01-01T00:00:00Z")).IsZero())
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"1,,2 * * * *",
+	} {
+		_, err := recurrence.ParseCron(expr, time.UTC)
+		assert.Error(t, err, expr)
+	}
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/money"
+)
+
+type AccountRepository interface {
+	CreateAccount(ctx context.Context, account models.Account) (models.Account, error)
+	GetAccount(ctx context.Context, id string) (models.Account, error)
+	UpdateAccountStatus(ctx context.Context, id string, status enums.AccountStatus) (models.Account, error)
+}
+
+type GormAccountRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAccountRepository(database *gorm.DB) AccountRepository {
+	return &GormAccountRepository{db: database}
+}
+
+func (r *GormAccountRepository) CreateAccount(ctx context.Context, account models.Account) (models.Account, error) {
+	account.Status = enums.AccountActive.String()
+
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&account)
+	if result.Error != nil {
+		return models.Account{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.Account{}, apperrors.New(apperrors.ErrConflict, apperrors.CodeConflict, "account "+account.AccountID+" already exists")
+	}
+
+	return account, nil
+}
+
+func (r *GormAccountRepository) GetAccount(ctx context.Context, id string) (models.Account, error) {
+	var account models.Account
+	err := r.db.WithContext(ctx).Where("account_id = ?", id).First(&account).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return account, errAccountNotFound(err)
+	}
+	return account, err
+}
+
+// UpdateAccountStatus moves an account to status under a row lock. Closing
+// is refused while the account still holds funds or has transfers in flight
+// in either direction. New transfers lock the same row, so none can start
+// between the check and the close.
+func (r *GormAccountRepository) UpdateAccountStatus(ctx context.Context, id string, status enums.AccountStatus) (models.Account, error) {
+	var account models.Account
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("account_id = ?", id).First(&account).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errAccountNotFound(err)
+			}
+			return err
+		}
+
+		if err := enums.ValidateAccountTransition(enums.AccountStatus(account.Status), status); err != nil {
+			return err
+		}
+
+		if status == enums.AccountClosed {
+			if err := ensureEmpty(tx, id); err != nil {
+				return err
+			}
+		}
+
+		account.Status = status.String()
+		return tx.Model(&account).Update("status", account.Status).Error
+	})
+	if err != nil {
+		return models.Account{}, err
+	}
+
+	return account, nil
+}
+
+func ensureEmpty(tx *gorm.DB, id string) error {
+	var funded []string
+	err := tx.Model(&models.LedgerEntry{}).
+		Where("account_id = ?", id).
+		Group("currency").
+		Having("SUM(amount) <> ?", money.Amount(0)).
+		Pluck("currency", &funded).Error
+	if err != nil {
+		return err
+	}
+
+	var held int64
+	err = tx.Model(&models.Hold{}).
+		Where("account_id = ? AND status = ?", id, enums.HoldActive.String()).
+		Count(&held).Error
+	if err != nil {
+		return err
+	}
+
+	// Outgoing transfers in flight hold funds; incoming ones would credit
+	// the account once they settle.
+	var incoming int64
+	err = tx.Model(&models.Transfer{}).
+		Where("to_account = ? AND status IN ?", id, []string{enums.PENDING.String(), enums.PROCESSING.String()}).
+		Count(&incoming).Error
+	if err != nil {
+		return err
+	}
+
+	if len(funded) > 0 || held > 0 || incoming > 0 {
+		return apperrors.New(apperrors.ErrConflict, apperrors.CodeAccountNotEmpty, "account still holds funds or has transfers in flight")
+	}
+	return nil
+}
+
+func errAccountNotFound(cause error) error {
+	return apperrors.Wrap(cause, apperrors.ErrNotFound, apperrors.CodeAccountNotFound, "account not found")
+}
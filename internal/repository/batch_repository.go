@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/models"
+)
+
+// batchInsertSize keeps each insert of rows well below the bind parameter
+// limits of the databases.
+const batchInsertSize = 200
+
+type BatchRepository interface {
+	CreateBatch(ctx context.Context, batch models.TransferBatch, rows []models.TransferBatchRow) (models.TransferBatch, error)
+	GetBatch(ctx context.Context, id string) (models.TransferBatch, error)
+	ListBatchRows(ctx context.Context, id string, status enums.BatchRowStatus, afterLine, limit int) ([]models.TransferBatchRow, error)
+	PendingBatchRows(ctx context.Context, id string, limit int) ([]models.TransferBatchRow, error)
+	StartBatchRow(ctx context.Context, row models.TransferBatchRow) (bool, error)
+	ReleaseBatchRow(ctx context.Context, row models.TransferBatchRow) error
+	FinishBatchRow(ctx context.Context, row models.TransferBatchRow) error
+	FailBatchRows(ctx context.Context, id string, status enums.BatchRowStatus, message string) (int64, error)
+	CompleteBatch(ctx context.Context, id string) error
+}
+
+type GormBatchRepository struct {
+	db *gorm.DB
+}
+
+func NewGormBatchRepository(database *gorm.DB) BatchRepository {
+	return &GormBatchRepository{db: database}
+}
+
+// CreateBatch stores a batch with all its rows. Rows without a status are
+// PENDING; a batch with no PENDING row is COMPLETED right away.
+func (r *GormBatchRepository) CreateBatch(ctx context.Context, batch models.TransferBatch, rows []models.TransferBatchRow) (models.TransferBatch, error) {
+	now := time.Now()
+	batch.Status = enums.BatchCompleted.String()
+	batch.SubmittedAt = now
+	batch.CompletedAt = &now
+	batch.TotalRows = len(rows)
+	batch.PendingRows, batch.CreatedRows, batch.FailedRows = 0, 0, 0
+
+	for i := range rows {
+		rows[i].BatchID = batch.BatchID
+		if rows[i].Status == "" {
+			rows[i].Status = enums.BatchRowPending.String()
+		}
+		switch rows[i].Status {
+		case enums.BatchRowPending.String():
+			batch.PendingRows++
+		case enums.BatchRowFailed.String():
+			batch.FailedRows++
+		}
+	}
+	if batch.PendingRows > 0 {
+		batch.Status = enums.BatchProcessing.String()
+		batch.CompletedAt = nil
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&batch).Error; err != nil {
+			return err
+		}
+		return tx.CreateInBatches(&rows, batchInsertSize).Error
+	})
+	if err != nil {
+		return models.TransferBatch{}, err
+	}
+
+	return batch, nil
+}
+
+// GetBatch returns a batch with its rows counted by outcome. Rows being
+// created count as pending.
+func (r *GormBatchRepository) GetBatch(ctx context.Context, id string) (models.TransferBatch, error) {
+	var batch models.TransferBatch
+	err := r.db.WithContext(ctx).Where("batch_id = ?", id).First(&batch).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return batch, errBatchNotFound(err)
+	}
+	if err != nil {
+		return batch, err
+	}
+
+	var counts []struct {
+		Status string
+		Total  int
+	}
+	err = r.db.WithContext(ctx).Model(&models.TransferBatchRow{}).
+		Select("status, COUNT(*) AS total").
+		Where("batch_id = ?", id).
+		Group("status").
+		Scan(&counts).Error
+	if err != nil {
+		return models.TransferBatch{}, err
+	}
+
+	for _, count := range counts {
+		switch count.Status {
+		case enums.BatchRowCreated.String():
+			batch.CreatedRows += count.Total
+		case enums.BatchRowFailed.String():
+			batch.FailedRows += count.Total
+		default:
+			batch.PendingRows += count.Total
+		}
+	}
+
+	return batch, nil
+}
+
+// ListBatchRows returns the rows of a batch after afterLine in file order,
+// optionally only those with status.
+func (r *GormBatchRepository) ListBatchRows(ctx context.Context, id string, status enums.BatchRowStatus, afterLine, limit int) ([]models.TransferBatchRow, error) {
+	query := r.db.WithContext(ctx).Where("batch_id = ? AND line > ?", id, afterLine)
+	if status != "" {
+		query = query.Where("status = ?", status.String())
+	}
+
+	rows := []models.TransferBatchRow{}
+	if err := query.Order("line").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// PendingBatchRows returns up to limit rows that still need a transfer, in
+// file order.
+func (r *GormBatchRepository) PendingBatchRows(ctx context.Context, id string, limit int) ([]models.TransferBatchRow, error) {
+	return r.ListBatchRows(ctx, id, enums.BatchRowPending, 0, limit)
+}
+
+// StartBatchRow claims a PENDING row by marking it RUNNING. It reports false
+// when the row was already claimed, so its transfer is never created twice.
+func (r *GormBatchRepository) StartBatchRow(ctx context.Context, row models.TransferBatchRow) (bool, error) {
+	return r.moveBatchRow(ctx, row.ID, enums.BatchRowPending, models.TransferBatchRow{Status: enums.BatchRowRunning.String()}, "status")
+}
+
+// ReleaseBatchRow returns a RUNNING row whose transfer could not be created
+// to PENDING so it is tried again.
+func (r *GormBatchRepository) ReleaseBatchRow(ctx context.Context, row models.TransferBatchRow) error {
+	_, err := r.moveBatchRow(ctx, row.ID, enums.BatchRowRunning, models.TransferBatchRow{Status: enums.BatchRowPending.String()}, "status")
+	return err
+}
+
+// FinishBatchRow stores the outcome of a RUNNING row.
+func (r *GormBatchRepository) FinishBatchRow(ctx context.Context, row models.TransferBatchRow) error {
+	_, err := r.moveBatchRow(ctx, row.ID, enums.BatchRowRunning, row, "status", "transfer_id", "error_code", "error", "fields")
+	return err
+}
+
+// FailBatchRows gives up on every row of a batch in status, recording message
+// as their error, and reports how many there were.
+func (r *GormBatchRepository) FailBatchRows(ctx context.Context, id string, status enums.BatchRowStatus, message string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.TransferBatchRow{}).
+		Where("batch_id = ? AND status = ?", id, status.String()).
+		Updates(map[string]interface{}{
+			"status":     enums.BatchRowFailed.String(),
+			"error_code": apperrors.CodeInternal,
+			"error":      message,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// CompleteBatch marks a batch COMPLETED once none of its rows is left
+// PENDING or RUNNING.
+func (r *GormBatchRepository) CompleteBatch(ctx context.Context, id string) error {
+	unfinished := r.db.Model(&models.TransferBatchRow{}).Select("1").
+		Where("batch_id = ? AND status IN ?", id, []string{enums.BatchRowPending.String(), enums.BatchRowRunning.String()})
+
+	return r.db.WithContext(ctx).Model(&models.TransferBatch{}).
+		Where("batch_id = ? AND status = ?", id, enums.BatchProcessing.String()).
+		Where("NOT EXISTS (?)", unfinished).
+		Updates(map[string]interface{}{
+			"status":       enums.BatchCompleted.String(),
+			"completed_at": time.Now(),
+		}).Error
+}
+
+// moveBatchRow writes the columns of values to a row that is still in status
+// from. Values is a struct so the fields column goes through its serializer.
+func (r *GormBatchRepository) moveBatchRow(ctx context.Context, rowID uint, from enums.BatchRowStatus, values models.TransferBatchRow, columns ...string) (bool, error) {
+	values.ID = 0
+	result := r.db.WithContext(ctx).Model(&models.TransferBatchRow{}).
+		Where("id = ? AND status = ?", rowID, from.String()).
+		Select(columns).
+		Updates(values)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func errBatchNotFound(cause error) error {
+	return apperrors.Wrap(cause, apperrors.ErrNotFound, apperrors.CodeBatchNotFound, "batch not found")
+}
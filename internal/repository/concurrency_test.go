@@ -0,0 +1,210 @@
+package repository_test
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/money"
+	"secure-payment-service/internal/repository"
+)
+
+// setupConcurrentDB opens a file database shared by several connections. The
+// in-memory database used elsewhere serializes everything on one connection,
+// which would hide races; _txlock=immediate stands in for FOR UPDATE.
+func setupConcurrentDB(t *testing.T) *gorm.DB {
+	dsn := "file:" + filepath.Join(t.TempDir(), "race.db") + "?_txlock=immediate&_busy_timeout=10000&_journal_mode=WAL"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	assert.NoError(t, err)
+
+	err = db.AutoMigrate(&models.Transfer{}, &models.Account{}, &models.LedgerEntry{}, &models.TransferStatusHistory{}, &models.Hold{})
+	assert.NoError(t, err)
+	openAccounts(t, db, "race_a", "race_b", "race_bank", "race_payee", "race_payer", "race_receiver", "race_spender")
+
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	sqlDB.SetMaxOpenConns(8)
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+func fundAccount(t *testing.T, repo repository.TransferRepository, account, amount string) {
+	transferID, err := repo.CreateTransfer(ctx, "race_bank", account, money.MustParse(amount), "USD")
+	assert.NoError(t, err)
+	assert.NoError(t, repo.UpdateTransfer(ctx, transferID, webhookChange(enums.COMPLETED.String())))
+}
+
+func TestGormRepository_ConcurrentCreateTransferCannotOverspend(t *testing.T) {
+	db := setupConcurrentDB(t)
+	repo := repository.NewGormRepository(db, "race_bank")
+	fundAccount(t, repo, "race_spender", "100")
+
+	const attempts = 25
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	created, rejected := 0, 0
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.CreateTransfer(ctx, "race_spender", "race_payee", money.MustParse("10"), "USD")
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				created++
+			case errors.Is(err, apperrors.ErrInsufficientFunds):
+				rejected++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, created)
+	assert.Equal(t, attempts-10, rejected)
+
+	balances, err := repo.GetAccountBalance(ctx, "race_spender")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.AccountBalance{{
+		Currency: "USD",
+		Ledger:   money.MustParse("100"),
+		Pending:  money.MustParse("100"),
+	}}, balances)
+}
+
+func TestGormRepository_ConcurrentOppositeTransfersDoNotDeadlock(t *testing.T) {
+	db := setupConcurrentDB(t)
+	repo := repository.NewGormRepository(db, "race_bank")
+	fundAccount(t, repo, "race_a", "500")
+	fundAccount(t, repo, "race_b", "500")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		from, to := "race_a", "race_b"
+		if i%2 == 1 {
+			from, to = to, from
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			transferID, err := repo.CreateTransfer(ctx, from, to, money.MustParse("5"), "USD")
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, webhookChange(enums.COMPLETED.String())))
+		}()
+	}
+	wg.Wait()
+
+	for _, account := range []string{"race_a", "race_b"} {
+		balances, err := repo.GetAccountBalance(ctx, account)
+		assert.NoError(t, err)
+		assert.Equal(t, []models.AccountBalance{{
+			Currency:  "USD",
+			Ledger:    money.MustParse("500"),
+			Available: money.MustParse("500"),
+		}}, balances, account)
+	}
+}
+
+func TestGormRepository_ConcurrentCompletionPostsLedgerOnce(t *testing.T) {
+	db := setupConcurrentDB(t)
+	repo := repository.NewGormRepository(db, "race_bank")
+	fundAccount(t, repo, "race_payer", "50")
+
+	transferID, err := repo.CreateTransfer(ctx, "race_payer", "race_receiver", money.MustParse("50"), "USD")
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, webhookChange(enums.COMPLETED.String())))
+		}()
+	}
+	wg.Wait()
+
+	var entries int64
+	assert.NoError(t, db.Model(&models.LedgerEntry{}).Where("transfer_id = ?", transferID).Count(&entries).Error)
+	assert.Equal(t, int64(2), entries)
+
+	balances, err := repo.GetAccountBalance(ctx, "race_receiver")
+	assert.NoError(t, err)
+	assert.Equal(t, money.MustParse("50"), balances[0].Ledger)
+}
+
+// raceStatusChanges applies both changes to a fresh transfer at the same time
+// and checks that exactly one wins, leaving the ledger and the hold as that
+// status requires.
+func raceStatusChanges(t *testing.T, first, second models.StatusChange) {
+	db := setupConcurrentDB(t)
+	repo := repository.NewGormRepository(db, "race_bank")
+
+	for round := 0; round < 10; round++ {
+		fundAccount(t, repo, "race_payer", "10")
+		transferID, err := repo.CreateTransfer(ctx, "race_payer", "race_receiver", money.MustParse("10"), "USD")
+		assert.NoError(t, err)
+
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for i, change := range []models.StatusChange{first, second} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = repo.UpdateTransfer(ctx, transferID, change)
+			}()
+		}
+		wg.Wait()
+
+		rejected := 0
+		for _, err := range errs {
+			if err != nil {
+				assert.ErrorIs(t, err, apperrors.ErrInvalidTransition)
+				rejected++
+			}
+		}
+		assert.Equal(t, 1, rejected)
+
+		transfer, err := repo.GetTransfer(ctx, transferID)
+		assert.NoError(t, err)
+		var entries int64
+		assert.NoError(t, db.Model(&models.LedgerEntry{}).Where("transfer_id = ?", transferID).Count(&entries).Error)
+		var hold models.Hold
+		assert.NoError(t, db.Where("transfer_id = ?", transferID).First(&hold).Error)
+		if transfer.Status == enums.COMPLETED.String() {
+			assert.Equal(t, int64(2), entries)
+			assert.Equal(t, enums.HoldCaptured.String(), hold.Status)
+		} else {
+			assert.Zero(t, entries)
+			assert.Equal(t, enums.HoldReleased.String(), hold.Status)
+		}
+	}
+
+	balances, err := repo.GetAccountBalance(ctx, "race_payer")
+	assert.NoError(t, err)
+	assert.True(t, balances[0].Pending.IsZero())
+}
+
+func TestGormRepository_ConcurrentCompletionAndFailureOnlyOneApplies(t *testing.T) {
+	raceStatusChanges(t, webhookChange(enums.COMPLETED.String()), webhookChange(enums.FAILED.String()))
+}
+
+func TestGormRepository_ConcurrentCompletionAndExpiryOnlyOneApplies(t *testing.T) {
+	raceStatusChanges(t, webhookChange(enums.COMPLETED.String()), models.StatusChange{
+		Status: enums.EXPIRED.String(),
+		Source: enums.SourceMonitor,
+		Reason: "not settled after 10 checks",
+	})
+}
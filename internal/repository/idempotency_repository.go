@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"secure-payment-service/internal/models"
+)
+
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, subject, key, fingerprint string, lockedUntil time.Time) (models.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, subject, key string, responseCode int, contentType string, body []byte) error
+	Release(ctx context.Context, subject, key string) error
+}
+
+type GormIdempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewGormIdempotencyRepository(database *gorm.DB) IdempotencyRepository {
+	return &GormIdempotencyRepository{db: database}
+}
+
+// Reserve claims the key of subject for a new request until lockedUntil.
+// When the key is already known it returns the stored record and false
+// instead, unless the request holding it never finished and its lock has
+// run out: that one is assumed to have crashed and the key is taken over.
+func (r *GormIdempotencyRepository) Reserve(ctx context.Context, subject, key, fingerprint string, lockedUntil time.Time) (models.IdempotencyRecord, bool, error) {
+	record := models.IdempotencyRecord{
+		Subject:        subject,
+		IdempotencyKey: key,
+		Fingerprint:    fingerprint,
+		LockedUntil:    lockedUntil,
+	}
+
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+	if result.Error != nil {
+		return models.IdempotencyRecord{}, false, result.Error
+	}
+
+	if result.RowsAffected == 1 {
+		return record, true, nil
+	}
+
+	var existing models.IdempotencyRecord
+	if err := r.db.WithContext(ctx).Where("subject = ? AND idempotency_key = ?", subject, key).First(&existing).Error; err != nil {
+		return models.IdempotencyRecord{}, false, err
+	}
+
+	if existing.ResponseCode == 0 && existing.LockedUntil.Before(time.Now()) {
+		result := r.db.WithContext(ctx).Model(&models.IdempotencyRecord{}).
+			Where("id = ? AND response_code = 0 AND locked_until < ?", existing.ID, time.Now()).
+			Updates(map[string]interface{}{
+				"fingerprint":  fingerprint,
+				"locked_until": lockedUntil,
+			})
+		if result.Error != nil {
+			return models.IdempotencyRecord{}, false, result.Error
+		}
+		if result.RowsAffected == 1 {
+			existing.Fingerprint = fingerprint
+			existing.LockedUntil = lockedUntil
+			return existing, true, nil
+		}
+	}
+
+	return existing, false, nil
+}
+
+func (r *GormIdempotencyRepository) Complete(ctx context.Context, subject, key string, responseCode int, contentType string, body []byte) error {
+	return r.db.WithContext(ctx).Model(&models.IdempotencyRecord{}).
+		Where("subject = ? AND idempotency_key = ?", subject, key).
+		Updates(map[string]interface{}{
+			"response_code": responseCode,
+			"content_type":  contentType,
+			"response_body": body,
+		}).Error
+}
+
+func (r *GormIdempotencyRepository) Release(ctx context.Context, subject, key string) error {
+	return r.db.WithContext(ctx).Unscoped().Where("subject = ? AND idempotency_key = ?", subject, key).Delete(&models.IdempotencyRecord{}).Error
+}
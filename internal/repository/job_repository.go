@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/models"
+)
+
+type JobRepository interface {
+	Enqueue(ctx context.Context, kind, reference string, runAt time.Time) (string, error)
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.ScheduledJob, error)
+	Reschedule(ctx context.Context, jobID string, attempts int, nextRunAt time.Time, lastError string) error
+	Complete(ctx context.Context, jobID string, attempts int) error
+	Fail(ctx context.Context, jobID string, attempts int, lastError string) error
+}
+
+type GormJobRepository struct {
+	db *gorm.DB
+}
+
+func NewGormJobRepository(database *gorm.DB) JobRepository {
+	return &GormJobRepository{db: database}
+}
+
+func (r *GormJobRepository) Enqueue(ctx context.Context, kind, reference string, runAt time.Time) (string, error) {
+	job := models.ScheduledJob{
+		JobID:     generateUUID(),
+		Kind:      kind,
+		Reference: reference,
+		Status:    enums.JobPending.String(),
+		NextRunAt: runAt,
+	}
+
+	if err := r.db.WithContext(ctx).Create(&job).Error; err != nil {
+		return "", err
+	}
+
+	return job.JobID, nil
+}
+
+// ClaimDue leases up to limit jobs that are due, including running jobs whose
+// lease expired because the worker holding them died. Each job is claimed with
+// a conditional update so concurrent instances never run the same job twice.
+func (r *GormJobRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.ScheduledJob, error) {
+	var candidates []models.ScheduledJob
+	err := r.db.WithContext(ctx).Where(claimableCondition(r.db, now)).
+		Order("next_run_at").
+		Limit(limit).
+		Find(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	lockedUntil := now.Add(lease)
+	claimed := make([]models.ScheduledJob, 0, len(candidates))
+	for _, job := range candidates {
+		result := r.db.WithContext(ctx).Model(&models.ScheduledJob{}).
+			Where("job_id = ?", job.JobID).
+			Where(claimableCondition(r.db, now)).
+			Updates(map[string]interface{}{
+				"status":       enums.JobRunning.String(),
+				"locked_until": lockedUntil,
+			})
+		if result.Error != nil {
+			return claimed, result.Error
+		}
+		if result.RowsAffected == 1 {
+			job.Status = enums.JobRunning.String()
+			job.LockedUntil = &lockedUntil
+			claimed = append(claimed, job)
+		}
+	}
+
+	return claimed, nil
+}
+
+func claimableCondition(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.Where("status = ? AND next_run_at <= ?", enums.JobPending.String(), now).
+		Or("status = ? AND locked_until < ?", enums.JobRunning.String(), now)
+}
+
+func (r *GormJobRepository) Reschedule(ctx context.Context, jobID string, attempts int, nextRunAt time.Time, lastError string) error {
+	return r.finish(ctx, jobID, map[string]interface{}{
+		"status":       enums.JobPending.String(),
+		"attempts":     attempts,
+		"next_run_at":  nextRunAt,
+		"locked_until": nil,
+		"last_error":   lastError,
+	})
+}
+
+func (r *GormJobRepository) Complete(ctx context.Context, jobID string, attempts int) error {
+	return r.finish(ctx, jobID, map[string]interface{}{
+		"status":       enums.JobDone.String(),
+		"attempts":     attempts,
+		"locked_until": nil,
+	})
+}
+
+func (r *GormJobRepository) Fail(ctx context.Context, jobID string, attempts int, lastError string) error {
+	return r.finish(ctx, jobID, map[string]interface{}{
+		"status":       enums.JobFailed.String(),
+		"attempts":     attempts,
+		"locked_until": nil,
+		"last_error":   lastError,
+	})
+}
+
+func (r *GormJobRepository) finish(ctx context.Context, jobID string, updates map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&models.ScheduledJob{}).Where("job_id = ?", jobID).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("job not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/money"
+)
+
+// Models lists every table the service stores.
+var Models = []interface{}{
+	&models.Transfer{}, &models.Account{}, &models.LedgerEntry{}, &models.TransferStatusHistory{},
+	&models.IdempotencyRecord{}, &models.ScheduledJob{}, &models.WebhookDelivery{}, &models.Hold{},
+	&models.StandingOrder{}, &models.StandingOrderExecution{}, &models.TransferBatch{}, &models.TransferBatchRow{},
+}
+
+// Migrate brings the schema up to date. AutoMigrate only adds tables, columns
+// and indexes; the steps around it change what it cannot, and are safe to
+// run on every start.
+func Migrate(db *gorm.DB) error {
+	if err := convertDecimalAmounts(db); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(Models...); err != nil {
+		return err
+	}
+	if err := dropGlobalIdempotencyKeyIndex(db); err != nil {
+		return err
+	}
+	if err := backfillAccounts(db); err != nil {
+		return err
+	}
+	return backfillLedgerEntries(db)
+}
+
+// EnsureSettlementAccounts opens the configured settlement accounts if they
+// do not exist yet, since transfers only move funds between open accounts.
+func EnsureSettlementAccounts(db *gorm.DB, ids ...string) error {
+	return ensureAccounts(db, ids...)
+}
+
+// dropGlobalIdempotencyKeyIndex removes the unique index on the key alone,
+// which predates keys being scoped to the caller and would still make them
+// global.
+func dropGlobalIdempotencyKeyIndex(db *gorm.DB) error {
+	const index = "idx_idempotency_records_idempotency_key"
+	if !db.Migrator().HasIndex(&models.IdempotencyRecord{}, index) {
+		return nil
+	}
+	return db.Migrator().DropIndex(&models.IdempotencyRecord{}, index)
+}
+
+// convertDecimalAmounts rescales amounts stored before money.Amount, when the
+// columns held decimal values, to integer units of 1/10^Scale. It has to run
+// before AutoMigrate, which would change the column type without rescaling.
+func convertDecimalAmounts(db *gorm.DB) error {
+	for _, model := range []interface{}{&models.Transfer{}, &models.LedgerEntry{}} {
+		decimal, err := hasDecimalAmount(db, model)
+		if err != nil {
+			return err
+		}
+		if !decimal {
+			continue
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(model).Unscoped().Where("1 = 1").
+				Update("amount", gorm.Expr(fmt.Sprintf("ROUND(amount * 1e%d)", money.Scale))).Error; err != nil {
+				return err
+			}
+			return tx.Migrator().AlterColumn(model, "Amount")
+		})
+		if err != nil {
+			return fmt.Errorf("converting amounts of %T: %w", model, err)
+		}
+	}
+	return nil
+}
+
+func hasDecimalAmount(db *gorm.DB, model interface{}) (bool, error) {
+	if !db.Migrator().HasTable(model) {
+		return false, nil
+	}
+	columns, err := db.Migrator().ColumnTypes(model)
+	if err != nil {
+		return false, err
+	}
+	for _, column := range columns {
+		if column.Name() != "amount" {
+			continue
+		}
+		switch strings.ToLower(column.DatabaseTypeName()) {
+		case "numeric", "decimal", "real", "double precision", "float", "float4", "float8":
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// backfillAccounts opens the accounts of transfers stored while accounts were
+// still created implicitly, so those transfers can settle and be refunded.
+func backfillAccounts(db *gorm.DB) error {
+	var ids []string
+	for _, column := range []string{"from_account", "to_account"} {
+		var missing []string
+		err := db.Model(&models.Transfer{}).Unscoped().Distinct(column).
+			Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM accounts WHERE accounts.account_id = transfers.%s)", column)).
+			Pluck(column, &missing).Error
+		if err != nil {
+			return err
+		}
+		ids = append(ids, missing...)
+	}
+	return ensureAccounts(db, ids...)
+}
+
+// ensureAccounts creates the accounts that do not exist yet. Inserting with
+// ON CONFLICT DO NOTHING keeps it safe to run on every start.
+func ensureAccounts(db *gorm.DB, ids ...string) error {
+	for _, id := range ids {
+		account := models.Account{AccountID: id, Status: enums.AccountActive.String()}
+		if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&account).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillLedgerEntries posts the debit and credit of transfers completed
+// before the ledger existed. Transfers that already have entries are left alone,
+// so it only does work once.
+func backfillLedgerEntries(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var transfers []models.Transfer
+		return tx.Where("status = ?", enums.COMPLETED.String()).
+			Where("NOT EXISTS (SELECT 1 FROM ledger_entries WHERE ledger_entries.transfer_id = transfers.transfer_id)").
+			FindInBatches(&transfers, 500, func(batch *gorm.DB, _ int) error {
+				for _, transfer := range transfers {
+					if err := postLedgerEntries(tx, transfer, transfer.FromAccount, transfer.ToAccount); err != nil {
+						return fmt.Errorf("backfilling ledger entries of transfer %s: %w", transfer.TransferID, err)
+					}
+				}
+				return nil
+			}).Error
+	})
+}
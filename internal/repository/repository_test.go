@@ -1,24 +1,53 @@
 package repository_test
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"secure-payment-service/internal/apperrors"
 	"secure-payment-service/internal/enums"
 	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/money"
 	"secure-payment-service/internal/repository"
 )
 
+var ctx = context.Background()
+
+// settlementAccounts are the sending side of fixtures that only look at the
+// receiving account, so they are exempt from the funds check.
+var settlementAccounts = []string{
+	"acc_test_from_1", "other_1", "other_2", "other_4", "other_5", "in_src_1", "in_src_2",
+	"acc_outonly", "src_dec_1", "src_dec_2", "src", "src_usd", "src_eur",
+	"ledger_from", "ledger_rev_from", "ledger_from_2", "hist_from",
+}
+
+// fixtureAccounts are opened up front for the tests about transfers, which
+// only move funds between existing accounts.
+var fixtureAccounts = []string{
+	"acc-pending-only", "acc_decimal", "acc_inonly", "acc_multi", "acc_outonly", "acc_test_from_1", "acc_test_to_1",
+	"acct_bank", "dst_eur", "group_bank", "group_buyer", "group_fee", "group_seller", "group_tax",
+	"hist_from", "hist_to", "hold_a", "hold_b", "hold_bank", "hold_c", "hold_d", "hold_e", "hold_f",
+	"in_src_1", "in_src_2", "ledger_from", "ledger_from_2", "ledger_rev_from", "ledger_rev_to", "ledger_to", "ledger_to_2",
+	"my_acc", "my_acc_2", "other_1", "other_2", "other_3", "other_4", "other_5", "other_6", "other_7",
+	"out_dest_1", "out_dest_2", "refund_bank", "refund_payer", "refund_shop", "refund_spender",
+	"sched_bank", "sched_contractor", "sched_employee", "sched_lister", "sched_payer",
+	"src", "src_dec_1", "src_dec_2", "src_eur", "src_usd", "userA", "userB", "userV", "userW", "userX", "userY",
+}
+
 func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared&_journal=MEMORY"), &gorm.Config{})
 	assert.NoError(t, err, "Fallo al abrir la conexión a SQLite en memoria")
 
-	err = db.AutoMigrate(&models.Transfer{})
+	err = db.AutoMigrate(&models.Transfer{}, &models.Account{}, &models.LedgerEntry{}, &models.TransferStatusHistory{}, &models.IdempotencyRecord{}, &models.ScheduledJob{}, &models.WebhookDelivery{}, &models.Hold{}, &models.StandingOrder{}, &models.StandingOrderExecution{}, &models.TransferBatch{}, &models.TransferBatchRow{})
 	assert.NoError(t, err, "Fallo al auto-migrar el esquema de la base de datos")
+	openAccounts(t, db, fixtureAccounts...)
 
 	t.Cleanup(func() {
 		sqlDB, _ := db.DB()
@@ -29,6 +58,17 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	return db
 }
 
+func openAccounts(t *testing.T, db *gorm.DB, ids ...string) {
+	for _, id := range ids {
+		err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Account{AccountID: id, Status: enums.AccountActive.String()}).Error
+		assert.NoError(t, err)
+	}
+}
+
+func webhookChange(status string) models.StatusChange {
+	return models.StatusChange{Status: status, Source: enums.SourceWebhook}
+}
+
 func TestGormRepository(t *testing.T) {
 	mainDB := setupTestDB(t)
 
@@ -37,10 +77,10 @@ func TestGormRepository(t *testing.T) {
 		assert.NoError(t, tx.Error)
 		defer tx.Rollback()
 
-		repo := repository.NewGormRepository(tx)
+		repo := repository.NewGormRepository(tx, settlementAccounts...)
 
 		t.Run("success_creation", func(t *testing.T) {
-			transferID, err := repo.CreateTransfer("acc_test_from_1", "acc_test_to_1", 150.0)
+			transferID, err := repo.CreateTransfer(ctx, "acc_test_from_1", "acc_test_to_1", money.MustParse("150.0"), "USD")
 			assert.NoError(t, err)
 			assert.NotEmpty(t, transferID)
 
@@ -50,7 +90,8 @@ func TestGormRepository(t *testing.T) {
 			assert.Equal(t, transferID, transfer.TransferID)
 			assert.Equal(t, "acc_test_from_1", transfer.FromAccount)
 			assert.Equal(t, "acc_test_to_1", transfer.ToAccount)
-			assert.Equal(t, 150.0, transfer.Amount)
+			assert.Equal(t, "USD", transfer.Currency)
+			assert.Equal(t, money.MustParse("150.0"), transfer.Amount)
 			assert.Equal(t, enums.PENDING.String(), transfer.Status)
 		})
 	})
@@ -67,12 +108,12 @@ func TestGormRepository(t *testing.T) {
 				TransferID:  "transfer-xyz-123",
 				FromAccount: "sender_acc",
 				ToAccount:   "receiver_acc",
-				Amount:      200.0,
+				Amount:      money.MustParse("200.0"),
 				Status:      enums.COMPLETED.String(),
 			}
 			tx.Create(&expectedTransfer)
 
-			foundTransfer, err := repo.GetTransfer("transfer-xyz-123")
+			foundTransfer, err := repo.GetTransfer(ctx, "transfer-xyz-123")
 			assert.NoError(t, err)
 			assert.Equal(t, expectedTransfer.TransferID, foundTransfer.TransferID)
 			assert.Equal(t, expectedTransfer.FromAccount, foundTransfer.FromAccount)
@@ -82,9 +123,19 @@ func TestGormRepository(t *testing.T) {
 		})
 
 		t.Run("not_found", func(t *testing.T) {
-			_, err := repo.GetTransfer("non-existent-transfer-id")
+			_, err := repo.GetTransfer(ctx, "non-existent-transfer-id")
 			assert.Error(t, err)
 			assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+			assert.ErrorIs(t, err, apperrors.ErrNotFound)
+			assert.Equal(t, apperrors.CodeTransferNotFound, apperrors.Code(err, ""))
+		})
+
+		t.Run("cancelled_context", func(t *testing.T) {
+			cancelled, cancel := context.WithCancel(ctx)
+			cancel()
+
+			_, err := repo.GetTransfer(cancelled, "transfer-xyz-123")
+			assert.ErrorIs(t, err, context.Canceled)
 		})
 	})
 
@@ -93,72 +144,556 @@ func TestGormRepository(t *testing.T) {
 		assert.NoError(t, tx.Error)
 		defer tx.Rollback()
 
-		repo := repository.NewGormRepository(tx)
+		repo := repository.NewGormRepository(tx, settlementAccounts...)
 
-		insertTransfer := func(id, from, to string, amount float64, status string) {
-			tx.Create(&models.Transfer{
-				TransferID:  id,
-				FromAccount: from,
-				ToAccount:   to,
-				Amount:      amount,
-				Status:      status,
-			})
+		settleTransfer := func(from, to, amount, currency, status string) string {
+			transferID, err := repo.CreateTransfer(ctx, from, to, money.MustParse(amount), currency)
+			assert.NoError(t, err)
+			if status != enums.PENDING.String() {
+				assert.NoError(t, repo.UpdateTransfer(ctx, transferID, webhookChange(status)))
+			}
+			return transferID
 		}
 
 		t.Run("non_existent_account_returns_not_found_error", func(t *testing.T) {
-			balance, err := repo.GetAccountBalance("acc-empty-001")
+			balances, err := repo.GetAccountBalance(ctx, "acc-empty-001")
 			assert.Error(t, err)
 			assert.Equal(t, "account not found", err.Error())
-			assert.Equal(t, 0.0, balance)
+			assert.Empty(t, balances)
 		})
 
 		t.Run("balance_with_completed_transactions", func(t *testing.T) {
-			insertTransfer("bal-t1", "other_1", "my_acc", 100.0, enums.COMPLETED.String())
-			insertTransfer("bal-t2", "other_2", "my_acc", 50.0, enums.COMPLETED.String())
-			insertTransfer("bal-t3", "my_acc", "other_3", 30.0, enums.COMPLETED.String())
+			settleTransfer("other_1", "my_acc", "100.0", "USD", enums.COMPLETED.String())
+			settleTransfer("other_2", "my_acc", "50.0", "USD", enums.COMPLETED.String())
+			settleTransfer("my_acc", "other_3", "30.0", "USD", enums.COMPLETED.String())
 
-			balance, err := repo.GetAccountBalance("my_acc")
+			balances, err := repo.GetAccountBalance(ctx, "my_acc")
 			assert.NoError(t, err)
-			assert.Equal(t, 120.0, balance)
+			assert.Equal(t, []models.AccountBalance{{Currency: "USD", Ledger: money.MustParse("120.0"), Available: money.MustParse("120.0")}}, balances)
 		})
 
 		t.Run("ignore_pending_and_failed_transactions", func(t *testing.T) {
-			insertTransfer("bal-t4", "other_4", "my_acc_2", 200.0, enums.COMPLETED.String())
-			insertTransfer("bal-t5", "other_5", "my_acc_2", 70.0, enums.PENDING.String())
-			insertTransfer("bal-t6", "my_acc_2", "other_6", 40.0, enums.COMPLETED.String())
-			insertTransfer("bal-t7", "my_acc_2", "other_7", 10.0, enums.FAILED.String())
+			settleTransfer("other_4", "my_acc_2", "200.0", "USD", enums.COMPLETED.String())
+			settleTransfer("other_5", "my_acc_2", "70.0", "USD", enums.PENDING.String())
+			settleTransfer("my_acc_2", "other_6", "40.0", "USD", enums.COMPLETED.String())
+			settleTransfer("my_acc_2", "other_7", "10.0", "USD", enums.FAILED.String())
 
-			balance, err := repo.GetAccountBalance("my_acc_2")
+			balances, err := repo.GetAccountBalance(ctx, "my_acc_2")
 			assert.NoError(t, err)
-			assert.Equal(t, 160.0, balance)
+			assert.Equal(t, []models.AccountBalance{{Currency: "USD", Ledger: money.MustParse("160.0"), Available: money.MustParse("160.0")}}, balances)
 		})
 
 		t.Run("only_inflows", func(t *testing.T) {
-			insertTransfer("bal-t8", "in_src_1", "acc_inonly", 100.0, enums.COMPLETED.String())
-			insertTransfer("bal-t9", "in_src_2", "acc_inonly", 50.0, enums.COMPLETED.String())
-			balance, err := repo.GetAccountBalance("acc_inonly")
+			settleTransfer("in_src_1", "acc_inonly", "100.0", "USD", enums.COMPLETED.String())
+			settleTransfer("in_src_2", "acc_inonly", "50.0", "USD", enums.COMPLETED.String())
+			balances, err := repo.GetAccountBalance(ctx, "acc_inonly")
 			assert.NoError(t, err)
-			assert.Equal(t, 150.0, balance)
+			assert.Equal(t, []models.AccountBalance{{Currency: "USD", Ledger: money.MustParse("150.0"), Available: money.MustParse("150.0")}}, balances)
 		})
 
 		t.Run("only_outflows", func(t *testing.T) {
-			insertTransfer("bal-t10", "acc_outonly", "out_dest_1", 70.0, enums.COMPLETED.String())
-			insertTransfer("bal-t11", "acc_outonly", "out_dest_2", 30.0, enums.COMPLETED.String())
-			balance, err := repo.GetAccountBalance("acc_outonly")
+			settleTransfer("acc_outonly", "out_dest_1", "70.0", "USD", enums.COMPLETED.String())
+			settleTransfer("acc_outonly", "out_dest_2", "30.0", "USD", enums.COMPLETED.String())
+			balances, err := repo.GetAccountBalance(ctx, "acc_outonly")
+			assert.NoError(t, err)
+			assert.Equal(t, []models.AccountBalance{{Currency: "USD", Ledger: money.MustParse("-100.0"), Available: money.MustParse("-100.0")}}, balances)
+		})
+
+		t.Run("decimal_amounts_sum_exactly", func(t *testing.T) {
+			settleTransfer("src_dec_1", "acc_decimal", "0.1", "USD", enums.COMPLETED.String())
+			settleTransfer("src_dec_2", "acc_decimal", "0.2", "USD", enums.COMPLETED.String())
+			balances, err := repo.GetAccountBalance(ctx, "acc_decimal")
 			assert.NoError(t, err)
-			assert.Equal(t, -100.0, balance)
+			assert.Equal(t, []models.AccountBalance{{Currency: "USD", Ledger: money.MustParse("0.3"), Available: money.MustParse("0.3")}}, balances)
 		})
 
-		t.Run("null_float_handling", func(t *testing.T) {
-			insertTransfer("nf-t1", "acc-nf", "dest", 100, enums.COMPLETED.String())
-			balance, err := repo.GetAccountBalance("acc-nf")
+		t.Run("account_with_only_pending_transfers_has_zero_balance", func(t *testing.T) {
+			settleTransfer("src", "acc-pending-only", "50", "USD", enums.PENDING.String())
+			balances, err := repo.GetAccountBalance(ctx, "acc-pending-only")
 			assert.NoError(t, err)
-			assert.Equal(t, -100.0, balance)
+			assert.Empty(t, balances)
+		})
+
+		t.Run("balances_are_reported_per_currency", func(t *testing.T) {
+			settleTransfer("src_usd", "acc_multi", "100.0", "USD", enums.COMPLETED.String())
+			settleTransfer("src_eur", "acc_multi", "80.0", "EUR", enums.COMPLETED.String())
+			settleTransfer("acc_multi", "dst_eur", "30.0", "EUR", enums.COMPLETED.String())
 
-			insertTransfer("nf-t2", "src", "acc-nf-2", 50, enums.COMPLETED.String())
-			balance2, err := repo.GetAccountBalance("acc-nf-2")
+			balances, err := repo.GetAccountBalance(ctx, "acc_multi")
 			assert.NoError(t, err)
-			assert.Equal(t, 50.0, balance2)
+			assert.Equal(t, []models.AccountBalance{
+				{Currency: "EUR", Ledger: money.MustParse("50.0"), Available: money.MustParse("50.0")},
+				{Currency: "USD", Ledger: money.MustParse("100.0"), Available: money.MustParse("100.0")},
+			}, balances)
+		})
+	})
+
+	t.Run("Ledger", func(t *testing.T) {
+		tx := mainDB.Begin()
+		assert.NoError(t, tx.Error)
+		defer tx.Rollback()
+
+		repo := repository.NewGormRepository(tx, settlementAccounts...)
+
+		t.Run("completed_transfer_posts_balanced_entries", func(t *testing.T) {
+			transferID, err := repo.CreateTransfer(ctx, "ledger_from", "ledger_to", money.MustParse("75.0"), "USD")
+			assert.NoError(t, err)
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, webhookChange(enums.COMPLETED.String())))
+
+			var entries []models.LedgerEntry
+			assert.NoError(t, tx.Where("transfer_id = ?", transferID).Order("amount").Find(&entries).Error)
+			assert.Len(t, entries, 2)
+			assert.Equal(t, "ledger_from", entries[0].AccountID)
+			assert.Equal(t, enums.DEBIT.String(), entries[0].Direction)
+			assert.Equal(t, money.MustParse("-75.0"), entries[0].Amount)
+			assert.Equal(t, "ledger_to", entries[1].AccountID)
+			assert.Equal(t, enums.CREDIT.String(), entries[1].Direction)
+			assert.Equal(t, "USD", entries[0].Currency)
+			assert.Equal(t, "USD", entries[1].Currency)
+			assert.Equal(t, money.MustParse("75.0"), entries[1].Amount)
+			assert.True(t, entries[0].Amount.Add(entries[1].Amount).IsZero())
+		})
+
+		t.Run("no_entries_for_pending_or_failed_transfers", func(t *testing.T) {
+			transferID, err := repo.CreateTransfer(ctx, "ledger_from", "ledger_to", money.MustParse("10.0"), "USD")
+			assert.NoError(t, err)
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, webhookChange(enums.FAILED.String())))
+
+			var count int64
+			tx.Model(&models.LedgerEntry{}).Where("transfer_id = ?", transferID).Count(&count)
+			assert.Equal(t, int64(0), count)
+		})
+
+		t.Run("reversal_posts_opposite_entries", func(t *testing.T) {
+			transferID, err := repo.CreateTransfer(ctx, "ledger_rev_from", "ledger_rev_to", money.MustParse("40.0"), "USD")
+			assert.NoError(t, err)
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, webhookChange(enums.COMPLETED.String())))
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, webhookChange(enums.REVERSED.String())))
+
+			var entries []models.LedgerEntry
+			assert.NoError(t, tx.Where("transfer_id = ?", transferID).Find(&entries).Error)
+			assert.Len(t, entries, 4)
+
+			balances, err := repo.GetAccountBalance(ctx, "ledger_rev_from")
+			assert.NoError(t, err)
+			assert.Equal(t, []models.AccountBalance{{Currency: "USD"}}, balances)
+		})
+
+		t.Run("repeated_completion_does_not_post_twice", func(t *testing.T) {
+			transferID, err := repo.CreateTransfer(ctx, "ledger_from_2", "ledger_to_2", money.MustParse("20.0"), "USD")
+			assert.NoError(t, err)
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, webhookChange(enums.COMPLETED.String())))
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, webhookChange(enums.COMPLETED.String())))
+
+			var count int64
+			tx.Model(&models.LedgerEntry{}).Where("transfer_id = ?", transferID).Count(&count)
+			assert.Equal(t, int64(2), count)
+		})
+	})
+
+	t.Run("Holds", func(t *testing.T) {
+		tx := mainDB.Begin()
+		assert.NoError(t, tx.Error)
+		defer tx.Rollback()
+
+		repo := repository.NewGormRepository(tx, "hold_bank")
+
+		fund := func(account, amount string) {
+			transferID, err := repo.CreateTransfer(ctx, "hold_bank", account, money.MustParse(amount), "USD")
+			assert.NoError(t, err)
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, webhookChange(enums.COMPLETED.String())))
+		}
+		holdStatus := func(transferID string) string {
+			var hold models.Hold
+			assert.NoError(t, tx.Where("transfer_id = ?", transferID).First(&hold).Error)
+			return hold.Status
+		}
+
+		t.Run("pending_transfer_reduces_available_balance", func(t *testing.T) {
+			fund("hold_a", "100")
+
+			transferID, err := repo.CreateTransfer(ctx, "hold_a", "hold_b", money.MustParse("60"), "USD")
+			assert.NoError(t, err)
+			assert.Equal(t, enums.HoldActive.String(), holdStatus(transferID))
+
+			balances, err := repo.GetAccountBalance(ctx, "hold_a")
+			assert.NoError(t, err)
+			assert.Equal(t, []models.AccountBalance{{
+				Currency:  "USD",
+				Ledger:    money.MustParse("100"),
+				Pending:   money.MustParse("60"),
+				Available: money.MustParse("40"),
+			}}, balances)
+
+			_, err = repo.CreateTransfer(ctx, "hold_a", "hold_b", money.MustParse("40.01"), "USD")
+			assert.ErrorIs(t, err, apperrors.ErrInsufficientFunds)
+			assert.Equal(t, apperrors.CodeInsufficientFunds, apperrors.Code(err, ""))
+		})
+
+		t.Run("failed_transfer_releases_hold", func(t *testing.T) {
+			fund("hold_c", "50")
+
+			transferID, err := repo.CreateTransfer(ctx, "hold_c", "hold_b", money.MustParse("50"), "USD")
+			assert.NoError(t, err)
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, webhookChange(enums.FAILED.String())))
+			assert.Equal(t, enums.HoldReleased.String(), holdStatus(transferID))
+
+			_, err = repo.CreateTransfer(ctx, "hold_c", "hold_b", money.MustParse("50"), "USD")
+			assert.NoError(t, err)
+		})
+
+		t.Run("cancelled_transfer_releases_hold", func(t *testing.T) {
+			fund("hold_f", "20")
+
+			transferID, err := repo.CreateTransfer(ctx, "hold_f", "hold_b", money.MustParse("20"), "USD")
+			assert.NoError(t, err)
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, models.StatusChange{
+				Status: enums.CANCELLED.String(),
+				Source: enums.SourceAPI,
+				Actor:  "user-1",
+				Reason: "wrong beneficiary",
+			}))
+			assert.Equal(t, enums.HoldReleased.String(), holdStatus(transferID))
+
+			balances, err := repo.GetAccountBalance(ctx, "hold_f")
+			assert.NoError(t, err)
+			assert.Equal(t, money.MustParse("20"), balances[0].Available)
+
+			history, err := repo.GetTransferHistory(ctx, transferID)
+			assert.NoError(t, err)
+			assert.Equal(t, "user-1", history[len(history)-1].Actor)
+			assert.Equal(t, "wrong beneficiary", history[len(history)-1].Reason)
+		})
+
+		t.Run("completed_transfer_captures_hold", func(t *testing.T) {
+			fund("hold_d", "80")
+
+			transferID, err := repo.CreateTransfer(ctx, "hold_d", "hold_b", money.MustParse("30"), "USD")
+			assert.NoError(t, err)
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, webhookChange(enums.COMPLETED.String())))
+			assert.Equal(t, enums.HoldCaptured.String(), holdStatus(transferID))
+
+			balances, err := repo.GetAccountBalance(ctx, "hold_d")
+			assert.NoError(t, err)
+			assert.Equal(t, []models.AccountBalance{{
+				Currency:  "USD",
+				Ledger:    money.MustParse("50"),
+				Available: money.MustParse("50"),
+			}}, balances)
+		})
+
+		t.Run("funds_are_checked_per_currency", func(t *testing.T) {
+			fund("hold_e", "100")
+
+			_, err := repo.CreateTransfer(ctx, "hold_e", "hold_b", money.MustParse("1"), "EUR")
+			assert.ErrorIs(t, err, apperrors.ErrInsufficientFunds)
+		})
+
+		t.Run("settlement_account_may_go_negative", func(t *testing.T) {
+			balances, err := repo.GetAccountBalance(ctx, "hold_bank")
+			assert.NoError(t, err)
+			assert.True(t, balances[0].Available.IsNegative())
+		})
+	})
+
+	t.Run("Refunds", func(t *testing.T) {
+		tx := mainDB.Begin()
+		assert.NoError(t, tx.Error)
+		defer tx.Rollback()
+
+		repo := repository.NewGormRepository(tx, "refund_bank")
+		refundChange := models.StatusChange{Source: enums.SourceAPI, Actor: "support-1", Reason: "duplicate charge"}
+
+		pay := func(from, to, amount string) string {
+			transferID, err := repo.CreateTransfer(ctx, from, to, money.MustParse(amount), "USD")
+			assert.NoError(t, err)
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, webhookChange(enums.COMPLETED.String())))
+			return transferID
+		}
+		balance := func(account string) money.Amount {
+			balances, err := repo.GetAccountBalance(ctx, account)
+			assert.NoError(t, err)
+			return balances[0].Ledger
+		}
+		pay("refund_bank", "refund_payer", "100")
+
+		t.Run("partial_then_full_refund", func(t *testing.T) {
+			originalID := pay("refund_payer", "refund_shop", "60")
+
+			refund, err := repo.RefundTransfer(ctx, originalID, money.MustParse("20"), refundChange)
+			assert.NoError(t, err)
+			assert.Equal(t, originalID, refund.RefundOf)
+			assert.Equal(t, "refund_shop", refund.FromAccount)
+			assert.Equal(t, "refund_payer", refund.ToAccount)
+			assert.Equal(t, enums.COMPLETED.String(), refund.Status)
+
+			original, err := repo.GetTransfer(ctx, originalID)
+			assert.NoError(t, err)
+			assert.Equal(t, enums.PARTIALLY_REFUNDED.String(), original.Status)
+			assert.Equal(t, money.MustParse("20"), original.RefundedAmount)
+			assert.Equal(t, money.MustParse("60"), balance("refund_payer"))
+			assert.Equal(t, money.MustParse("40"), balance("refund_shop"))
+
+			_, err = repo.RefundTransfer(ctx, originalID, money.MustParse("40.01"), refundChange)
+			assert.ErrorIs(t, err, apperrors.ErrConflict)
+			assert.Equal(t, apperrors.CodeRefundExceeds, apperrors.Code(err, ""))
+
+			_, err = repo.RefundTransfer(ctx, originalID, money.MustParse("40"), refundChange)
+			assert.NoError(t, err)
+			original, err = repo.GetTransfer(ctx, originalID)
+			assert.NoError(t, err)
+			assert.Equal(t, enums.REVERSED.String(), original.Status)
+			assert.Equal(t, money.MustParse("100"), balance("refund_payer"))
+
+			_, err = repo.RefundTransfer(ctx, originalID, money.MustParse("1"), refundChange)
+			assert.Equal(t, apperrors.CodeNotRefundable, apperrors.Code(err, ""))
+
+			history, err := repo.GetTransferHistory(ctx, originalID)
+			assert.NoError(t, err)
+			last := history[len(history)-1]
+			assert.Equal(t, enums.PARTIALLY_REFUNDED.String(), last.FromStatus)
+			assert.Equal(t, enums.REVERSED.String(), last.ToStatus)
+			assert.Equal(t, "support-1", last.Actor)
+		})
+
+		t.Run("pending_transfer_is_not_refundable", func(t *testing.T) {
+			transferID, err := repo.CreateTransfer(ctx, "refund_payer", "refund_shop", money.MustParse("5"), "USD")
+			assert.NoError(t, err)
+
+			_, err = repo.RefundTransfer(ctx, transferID, money.MustParse("5"), refundChange)
+			assert.Equal(t, apperrors.CodeNotRefundable, apperrors.Code(err, ""))
+		})
+
+		t.Run("payee_needs_funds", func(t *testing.T) {
+			originalID := pay("refund_payer", "refund_spender", "30")
+			pay("refund_spender", "refund_shop", "30")
+
+			_, err := repo.RefundTransfer(ctx, originalID, money.MustParse("30"), refundChange)
+			assert.ErrorIs(t, err, apperrors.ErrInsufficientFunds)
+		})
+
+		t.Run("reversal_needs_payee_funds", func(t *testing.T) {
+			originalID := pay("refund_payer", "refund_spender", "20")
+			pay("refund_spender", "refund_shop", "20")
+
+			err := repo.UpdateTransfer(ctx, originalID, webhookChange(enums.REVERSED.String()))
+			assert.ErrorIs(t, err, apperrors.ErrInsufficientFunds)
+
+			original, err := repo.GetTransfer(ctx, originalID)
+			assert.NoError(t, err)
+			assert.Equal(t, enums.COMPLETED.String(), original.Status)
+			assert.True(t, original.RefundedAmount.IsZero())
+		})
+
+		t.Run("reversal_after_partial_refund_returns_the_rest", func(t *testing.T) {
+			originalID := pay("refund_payer", "refund_shop", "10")
+			_, err := repo.RefundTransfer(ctx, originalID, money.MustParse("4"), refundChange)
+			assert.NoError(t, err)
+			before := balance("refund_shop")
+
+			assert.NoError(t, repo.UpdateTransfer(ctx, originalID, webhookChange(enums.REVERSED.String())))
+
+			assert.Equal(t, before.Sub(money.MustParse("6")), balance("refund_shop"))
+			original, err := repo.GetTransfer(ctx, originalID)
+			assert.NoError(t, err)
+			assert.Equal(t, money.MustParse("10"), original.RefundedAmount)
+		})
+	})
+
+	t.Run("TransferGroups", func(t *testing.T) {
+		tx := mainDB.Begin()
+		assert.NoError(t, tx.Error)
+		defer tx.Rollback()
+
+		repo := repository.NewGormRepository(tx, "group_bank")
+		fundID, err := repo.CreateTransfer(ctx, "group_bank", "group_buyer", money.MustParse("100"), "USD")
+		assert.NoError(t, err)
+		assert.NoError(t, repo.UpdateTransfer(ctx, fundID, webhookChange(enums.COMPLETED.String())))
+
+		split := func(amounts ...string) []models.Transfer {
+			legs := []models.TransferLeg{}
+			for i, amount := range amounts {
+				legs = append(legs, models.TransferLeg{ToAccount: []string{"group_seller", "group_fee", "group_tax"}[i], Amount: money.MustParse(amount)})
+			}
+			created, err := repo.CreateTransferGroup(ctx, "group_buyer", "USD", legs)
+			assert.NoError(t, err)
+			return created
+		}
+		statuses := func(legs []models.Transfer) []string {
+			group, err := repo.GetTransferGroup(ctx, legs[0].ParentID)
+			assert.NoError(t, err)
+			result := []string{}
+			for _, leg := range group {
+				result = append(result, leg.Status)
+			}
+			return result
+		}
+		available := func(account string) money.Amount {
+			balances, err := repo.GetAccountBalance(ctx, account)
+			assert.NoError(t, err)
+			return balances[0].Available
+		}
+
+		t.Run("no_leg_is_created_without_funds_for_all", func(t *testing.T) {
+			_, err := repo.CreateTransferGroup(ctx, "group_buyer", "USD", []models.TransferLeg{
+				{ToAccount: "group_seller", Amount: money.MustParse("90")},
+				{ToAccount: "group_fee", Amount: money.MustParse("20")},
+			})
+			assert.ErrorIs(t, err, apperrors.ErrInsufficientFunds)
+
+			var count int64
+			assert.NoError(t, tx.Model(&models.Transfer{}).Where("from_account = ?", "group_buyer").Count(&count).Error)
+			assert.Zero(t, count)
+			assert.Equal(t, money.MustParse("100"), available("group_buyer"))
+		})
+
+		t.Run("failing_one_leg_releases_every_hold", func(t *testing.T) {
+			legs := split("50", "30", "20")
+			assert.NotEmpty(t, legs[0].ParentID)
+			for _, leg := range legs {
+				assert.Equal(t, legs[0].ParentID, leg.ParentID)
+				assert.Equal(t, enums.PENDING.String(), leg.Status)
+			}
+			assert.True(t, available("group_buyer").IsZero())
+
+			assert.NoError(t, repo.UpdateTransfer(ctx, legs[0].TransferID, webhookChange(enums.PROCESSING.String())))
+			assert.Equal(t, []string{"PROCESSING", "PROCESSING", "PROCESSING"}, statuses(legs))
+
+			assert.NoError(t, repo.UpdateTransfer(ctx, legs[2].TransferID, models.StatusChange{
+				Status: enums.FAILED.String(),
+				Source: enums.SourceWebhook,
+				Reason: "tax account rejected",
+			}))
+			assert.Equal(t, []string{"FAILED", "FAILED", "FAILED"}, statuses(legs))
+			assert.Equal(t, money.MustParse("100"), available("group_buyer"))
+
+			history, err := repo.GetTransferHistory(ctx, legs[0].TransferID)
+			assert.NoError(t, err)
+			assert.Equal(t, "tax account rejected (with transfer "+legs[2].TransferID+")", history[len(history)-1].Reason)
+		})
+
+		t.Run("completing_one_leg_settles_every_leg", func(t *testing.T) {
+			legs := split("60", "30", "10")
+
+			assert.NoError(t, repo.UpdateTransfer(ctx, legs[1].TransferID, webhookChange(enums.COMPLETED.String())))
+			assert.Equal(t, []string{"COMPLETED", "COMPLETED", "COMPLETED"}, statuses(legs))
+			assert.Equal(t, money.MustParse("60"), available("group_seller"))
+			assert.Equal(t, money.MustParse("30"), available("group_fee"))
+			assert.Equal(t, money.MustParse("10"), available("group_tax"))
+			assert.True(t, available("group_buyer").IsZero())
+
+			// Once settled, the legs are refunded or reversed one by one.
+			assert.NoError(t, repo.UpdateTransfer(ctx, legs[0].TransferID, webhookChange(enums.REVERSED.String())))
+			assert.Equal(t, []string{"REVERSED", "COMPLETED", "COMPLETED"}, statuses(legs))
+		})
+
+		t.Run("unknown_group", func(t *testing.T) {
+			_, err := repo.GetTransferGroup(ctx, "missing")
+			assert.ErrorIs(t, err, apperrors.ErrNotFound)
+			assert.Equal(t, apperrors.CodeGroupNotFound, apperrors.Code(err, ""))
+		})
+	})
+
+	t.Run("ScheduledTransfers", func(t *testing.T) {
+		tx := mainDB.Begin()
+		assert.NoError(t, tx.Error)
+		defer tx.Rollback()
+
+		repo := repository.NewGormRepository(tx, "sched_bank")
+		payday := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+
+		fund := func(account, amount string) {
+			transferID, err := repo.CreateTransfer(ctx, "sched_bank", account, money.MustParse(amount), "USD")
+			assert.NoError(t, err)
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, webhookChange(enums.COMPLETED.String())))
+		}
+		fund("sched_payer", "100")
+
+		t.Run("funds_are_reserved_on_release", func(t *testing.T) {
+			transferID, err := repo.ScheduleTransfer(ctx, "sched_payer", "sched_employee", money.MustParse("80"), "USD", payday)
+			assert.NoError(t, err)
+
+			transfer, err := repo.GetTransfer(ctx, transferID)
+			assert.NoError(t, err)
+			assert.Equal(t, enums.SCHEDULED.String(), transfer.Status)
+			assert.True(t, payday.Equal(*transfer.ExecuteAt))
+
+			balances, err := repo.GetAccountBalance(ctx, "sched_payer")
+			assert.NoError(t, err)
+			assert.Equal(t, money.MustParse("100"), balances[0].Available)
+
+			released, err := repo.ReleaseScheduledTransfer(ctx, transferID, payday.Add(-time.Minute))
+			assert.NoError(t, err)
+			assert.False(t, released, "not due yet")
+
+			released, err = repo.ReleaseScheduledTransfer(ctx, transferID, payday)
+			assert.NoError(t, err)
+			assert.True(t, released)
+
+			transfer, err = repo.GetTransfer(ctx, transferID)
+			assert.NoError(t, err)
+			assert.Equal(t, enums.PENDING.String(), transfer.Status)
+			balances, err = repo.GetAccountBalance(ctx, "sched_payer")
+			assert.NoError(t, err)
+			assert.Equal(t, money.MustParse("20"), balances[0].Available)
+
+			released, err = repo.ReleaseScheduledTransfer(ctx, transferID, payday)
+			assert.NoError(t, err)
+			assert.False(t, released, "already released")
+
+			_, err = repo.UpdateScheduledTransfer(ctx, transferID, models.ScheduledTransferEdit{ExecuteAt: &payday})
+			assert.Equal(t, apperrors.CodeNotEditable, apperrors.Code(err, ""))
+		})
+
+		t.Run("release_without_funds", func(t *testing.T) {
+			transferID, err := repo.ScheduleTransfer(ctx, "sched_payer", "sched_employee", money.MustParse("50"), "USD", payday)
+			assert.NoError(t, err)
+
+			_, err = repo.ReleaseScheduledTransfer(ctx, transferID, payday)
+			assert.ErrorIs(t, err, apperrors.ErrInsufficientFunds)
+
+			transfer, err := repo.GetTransfer(ctx, transferID)
+			assert.NoError(t, err)
+			assert.Equal(t, enums.SCHEDULED.String(), transfer.Status)
+		})
+
+		t.Run("edit_before_release", func(t *testing.T) {
+			transferID, err := repo.ScheduleTransfer(ctx, "sched_payer", "sched_employee", money.MustParse("5"), "USD", payday)
+			assert.NoError(t, err)
+
+			later := payday.Add(24 * time.Hour)
+			amount := money.MustParse("7.5")
+			payee := "sched_contractor"
+			transfer, err := repo.UpdateScheduledTransfer(ctx, transferID, models.ScheduledTransferEdit{ToAccount: &payee, Amount: &amount, ExecuteAt: &later})
+			assert.NoError(t, err)
+			assert.Equal(t, uint(2), transfer.Version)
+
+			stored, err := repo.GetTransfer(ctx, transferID)
+			assert.NoError(t, err)
+			assert.Equal(t, payee, stored.ToAccount)
+			assert.Equal(t, amount, stored.Amount)
+			assert.True(t, later.Equal(*stored.ExecuteAt))
+
+			released, err := repo.ReleaseScheduledTransfer(ctx, transferID, payday)
+			assert.NoError(t, err)
+			assert.False(t, released, "postponed")
+
+			_, err = repo.UpdateScheduledTransfer(ctx, transferID, models.ScheduledTransferEdit{Amount: &amount, IfMatch: []uint{1}})
+			assert.ErrorIs(t, err, apperrors.ErrPrecondition)
+		})
+
+		t.Run("listing_is_soonest_first", func(t *testing.T) {
+			soon, err := repo.ScheduleTransfer(ctx, "sched_lister", "sched_employee", money.MustParse("1"), "USD", payday.Add(-time.Hour))
+			assert.NoError(t, err)
+			later, err := repo.ScheduleTransfer(ctx, "sched_lister", "sched_employee", money.MustParse("1"), "USD", payday.Add(time.Hour))
+			assert.NoError(t, err)
+
+			scheduled, err := repo.ListScheduledTransfers(ctx, []string{"sched_lister"}, 10)
+			assert.NoError(t, err)
+			if assert.Len(t, scheduled, 2) {
+				assert.Equal(t, soon, scheduled[0].TransferID)
+				assert.Equal(t, later, scheduled[1].TransferID)
+			}
 		})
 	})
 
@@ -174,12 +709,12 @@ func TestGormRepository(t *testing.T) {
 				TransferID:  "update-id-456",
 				FromAccount: "userA",
 				ToAccount:   "userB",
-				Amount:      100.0,
+				Amount:      money.MustParse("100.0"),
 				Status:      "PENDING",
 			}
 			tx.Create(&initialTransfer)
 
-			err := repo.UpdateTransfer("update-id-456", enums.COMPLETED.String())
+			err := repo.UpdateTransfer(ctx, "update-id-456", webhookChange(enums.COMPLETED.String()))
 			assert.NoError(t, err)
 
 			var updatedTransfer models.Transfer
@@ -189,7 +724,7 @@ func TestGormRepository(t *testing.T) {
 		})
 
 		t.Run("transfer_not_found", func(t *testing.T) {
-			err := repo.UpdateTransfer("non-existent-update-id", enums.COMPLETED.String())
+			err := repo.UpdateTransfer(ctx, "non-existent-update-id", webhookChange(enums.COMPLETED.String()))
 			assert.Error(t, err)
 			assert.EqualError(t, err, "transfer not found")
 		})
@@ -199,13 +734,912 @@ func TestGormRepository(t *testing.T) {
 				TransferID:  "update-id-789",
 				FromAccount: "userX",
 				ToAccount:   "userY",
-				Amount:      50.0,
+				Amount:      money.MustParse("50.0"),
 				Status:      enums.PENDING.String(),
 			}
 			tx.Create(&initialTransfer)
 
-			err := repo.UpdateTransfer("update-id-789", enums.PENDING.String())
+			err := repo.UpdateTransfer(ctx, "update-id-789", webhookChange(enums.PENDING.String()))
+			assert.NoError(t, err)
+		})
+
+		t.Run("every_update_increments_version", func(t *testing.T) {
+			tx.Create(&models.Transfer{TransferID: "update-version", FromAccount: "userV", ToAccount: "userW", Amount: money.MustParse("5"), Status: enums.PENDING.String()})
+
+			created, err := repo.GetTransfer(ctx, "update-version")
+			assert.NoError(t, err)
+			assert.Equal(t, uint(1), created.Version)
+
+			assert.NoError(t, repo.UpdateTransfer(ctx, "update-version", webhookChange(enums.PROCESSING.String())))
+			assert.NoError(t, repo.UpdateTransfer(ctx, "update-version", webhookChange(enums.COMPLETED.String())))
+
+			updated, err := repo.GetTransfer(ctx, "update-version")
+			assert.NoError(t, err)
+			assert.Equal(t, uint(3), updated.Version)
+		})
+
+		t.Run("if_match_is_checked_against_version", func(t *testing.T) {
+			tx.Create(&models.Transfer{TransferID: "update-if-match", FromAccount: "userV", ToAccount: "userW", Amount: money.MustParse("5"), Status: enums.PENDING.String()})
+
+			change := webhookChange(enums.PROCESSING.String())
+			change.IfMatch = []uint{2}
+			err := repo.UpdateTransfer(ctx, "update-if-match", change)
+			assert.ErrorIs(t, err, apperrors.ErrPrecondition)
+			assert.EqualError(t, err, "transfer is at version 1")
+
+			change.IfMatch = []uint{2, 1}
+			assert.NoError(t, repo.UpdateTransfer(ctx, "update-if-match", change))
+
+			transfer, err := repo.GetTransfer(ctx, "update-if-match")
 			assert.NoError(t, err)
+			assert.Equal(t, enums.PROCESSING.String(), transfer.Status)
+			assert.Equal(t, uint(2), transfer.Version)
 		})
 	})
+
+	t.Run("GetTransferHistory", func(t *testing.T) {
+		tx := mainDB.Begin()
+		assert.NoError(t, tx.Error)
+		defer tx.Rollback()
+
+		repo := repository.NewGormRepository(tx, settlementAccounts...)
+
+		t.Run("records_every_transition", func(t *testing.T) {
+			transferID, err := repo.CreateTransfer(ctx, "hist_from", "hist_to", money.MustParse("10"), "USD")
+			assert.NoError(t, err)
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, models.StatusChange{
+				Status: enums.PROCESSING.String(),
+				Source: enums.SourceWebhook,
+				Actor:  "provider-x",
+			}))
+			assert.NoError(t, repo.UpdateTransfer(ctx, transferID, models.StatusChange{
+				Status: enums.FAILED.String(),
+				Source: enums.SourceWebhook,
+				Actor:  "provider-x",
+				Reason: "insufficient funds at provider",
+			}))
+
+			history, err := repo.GetTransferHistory(ctx, transferID)
+			assert.NoError(t, err)
+			assert.Len(t, history, 3)
+
+			assert.Equal(t, "", history[0].FromStatus)
+			assert.Equal(t, enums.PENDING.String(), history[0].ToStatus)
+			assert.Equal(t, enums.SourceAPI.String(), history[0].Source)
+
+			assert.Equal(t, enums.PENDING.String(), history[1].FromStatus)
+			assert.Equal(t, enums.PROCESSING.String(), history[1].ToStatus)
+			assert.Equal(t, "provider-x", history[1].Actor)
+
+			assert.Equal(t, enums.PROCESSING.String(), history[2].FromStatus)
+			assert.Equal(t, enums.FAILED.String(), history[2].ToStatus)
+			assert.Equal(t, enums.SourceWebhook.String(), history[2].Source)
+			assert.Equal(t, "insufficient funds at provider", history[2].Reason)
+			assert.False(t, history[2].CreatedAt.IsZero())
+		})
+
+		t.Run("transfer_not_found", func(t *testing.T) {
+			_, err := repo.GetTransferHistory(ctx, "non-existent-history-id")
+			assert.EqualError(t, err, "transfer not found")
+		})
+	})
+
+	t.Run("ListTransfers", func(t *testing.T) {
+		tx := mainDB.Begin()
+		assert.NoError(t, tx.Error)
+		defer tx.Rollback()
+
+		repo := repository.NewGormRepository(tx)
+
+		base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		seed := []models.Transfer{
+			{TransferID: "list-1", FromAccount: "list_a", ToAccount: "list_b", Amount: money.MustParse("10"), Currency: "USD", Status: enums.COMPLETED.String(), CreatedAt: base},
+			{TransferID: "list-2", FromAccount: "list_b", ToAccount: "list_a", Amount: money.MustParse("25.50"), Currency: "EUR", Status: enums.PENDING.String(), CreatedAt: base.Add(time.Hour)},
+			{TransferID: "list-3", FromAccount: "list_a", ToAccount: "list_c", Amount: money.MustParse("100"), Currency: "USD", Status: enums.FAILED.String(), CreatedAt: base.Add(2 * time.Hour)},
+			{TransferID: "list-4", FromAccount: "list_c", ToAccount: "list_b", Amount: money.MustParse("5"), Currency: "USD", Status: enums.COMPLETED.String(), CreatedAt: base.Add(2 * time.Hour)},
+		}
+		assert.NoError(t, tx.Create(&seed).Error)
+
+		ids := func(list []models.Transfer) []string {
+			out := make([]string, 0, len(list))
+			for _, tr := range list {
+				out = append(out, tr.TransferID)
+			}
+			return out
+		}
+
+		t.Run("newest_first_with_id_tiebreak", func(t *testing.T) {
+			list, err := repo.ListTransfers(ctx, models.TransferFilter{})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"list-4", "list-3", "list-2", "list-1"}, ids(list))
+		})
+
+		t.Run("account_roles", func(t *testing.T) {
+			accounts := []string{"list_a"}
+
+			list, err := repo.ListTransfers(ctx, models.TransferFilter{Accounts: accounts, AccountRole: enums.RoleSource})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"list-3", "list-1"}, ids(list))
+
+			list, err = repo.ListTransfers(ctx, models.TransferFilter{Accounts: accounts, AccountRole: enums.RoleDestination})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"list-2"}, ids(list))
+
+			list, err = repo.ListTransfers(ctx, models.TransferFilter{Accounts: accounts, AccountRole: enums.RoleAny})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"list-3", "list-2", "list-1"}, ids(list))
+		})
+
+		t.Run("status_currency_and_amount", func(t *testing.T) {
+			minAmount, maxAmount := money.MustParse("5"), money.MustParse("50")
+			list, err := repo.ListTransfers(ctx, models.TransferFilter{
+				Statuses:  []string{enums.COMPLETED.String(), enums.FAILED.String()},
+				Currency:  "USD",
+				MinAmount: &minAmount,
+				MaxAmount: &maxAmount,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"list-4", "list-1"}, ids(list))
+		})
+
+		t.Run("created_range_is_half_open", func(t *testing.T) {
+			from, to := base, base.Add(2*time.Hour)
+			list, err := repo.ListTransfers(ctx, models.TransferFilter{CreatedFrom: &from, CreatedTo: &to})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"list-2", "list-1"}, ids(list))
+		})
+
+		t.Run("cursor_continues_after_tie", func(t *testing.T) {
+			first, err := repo.ListTransfers(ctx, models.TransferFilter{Limit: 1})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"list-4"}, ids(first))
+
+			rest, err := repo.ListTransfers(ctx, models.TransferFilter{
+				After: &models.TransferCursor{CreatedAt: first[0].CreatedAt, ID: first[0].ID},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"list-3", "list-2", "list-1"}, ids(rest))
+		})
+	})
+
+	t.Run("GetStatementEntries", func(t *testing.T) {
+		tx := mainDB.Begin()
+		assert.NoError(t, tx.Error)
+		defer tx.Rollback()
+
+		repo := repository.NewGormRepository(tx)
+
+		march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		assert.NoError(t, tx.Create(&[]models.Account{{AccountID: "stmt_a"}, {AccountID: "stmt_b"}}).Error)
+		assert.NoError(t, tx.Create(&[]models.Transfer{
+			{TransferID: "stmt-t1", FromAccount: "stmt_b", ToAccount: "stmt_a", Amount: money.MustParse("100"), Currency: "USD", Status: enums.COMPLETED.String()},
+			{TransferID: "stmt-t2", FromAccount: "stmt_a", ToAccount: "stmt_b", Amount: money.MustParse("30"), Currency: "USD", Status: enums.COMPLETED.String()},
+			{TransferID: "stmt-t3", FromAccount: "stmt_b", ToAccount: "stmt_a", Amount: money.MustParse("5"), Currency: "USD", Status: enums.COMPLETED.String()},
+		}).Error)
+		entry := func(id, transferID, direction, amount string, at time.Time) models.LedgerEntry {
+			e := models.LedgerEntry{EntryID: id, TransferID: transferID, AccountID: "stmt_a", Direction: direction, Amount: money.MustParse(amount), Currency: "USD"}
+			e.CreatedAt = at
+			return e
+		}
+		assert.NoError(t, tx.Create(&[]models.LedgerEntry{
+			entry("stmt-e1", "stmt-t1", enums.CREDIT.String(), "100", march.Add(-time.Hour)),
+			entry("stmt-e2", "stmt-t2", enums.DEBIT.String(), "-30", march.Add(time.Hour)),
+			entry("stmt-e3", "stmt-t3", enums.CREDIT.String(), "5", march.AddDate(0, 1, 0)),
+		}).Error)
+
+		t.Run("opening_balance_and_period_lines", func(t *testing.T) {
+			opening, lines, err := repo.GetStatementEntries(ctx, "stmt_a", march, march.AddDate(0, 1, 0))
+			assert.NoError(t, err)
+			assert.Equal(t, []models.Balance{{Currency: "USD", Balance: money.MustParse("100")}}, opening)
+			assert.Len(t, lines, 1)
+			assert.Equal(t, "stmt-e2", lines[0].EntryID)
+			assert.Equal(t, "stmt-t2", lines[0].TransferID)
+			assert.Equal(t, "stmt_b", lines[0].Counterparty)
+			assert.Equal(t, money.MustParse("-30"), lines[0].Amount)
+			assert.True(t, march.Add(time.Hour).Equal(lines[0].BookedAt))
+		})
+
+		t.Run("credit_counterparty_is_the_source", func(t *testing.T) {
+			_, lines, err := repo.GetStatementEntries(ctx, "stmt_a", march.AddDate(0, 1, 0), march.AddDate(0, 2, 0))
+			assert.NoError(t, err)
+			assert.Len(t, lines, 1)
+			assert.Equal(t, "stmt_b", lines[0].Counterparty)
+			assert.Equal(t, enums.CREDIT.String(), lines[0].Direction)
+		})
+
+		t.Run("reversal_counterparty_is_the_other_account", func(t *testing.T) {
+			// A reversal is booked under the original transfer with the
+			// accounts swapped, so the direction does not tell the sides apart.
+			assert.NoError(t, tx.Create(&[]models.Account{{AccountID: "stmt_payer"}, {AccountID: "stmt_payee"}}).Error)
+			transfers := repository.NewGormRepository(tx, "stmt_payer")
+			transferID, err := transfers.CreateTransfer(ctx, "stmt_payer", "stmt_payee", money.MustParse("20"), "USD")
+			assert.NoError(t, err)
+			assert.NoError(t, transfers.UpdateTransfer(ctx, transferID, webhookChange(enums.COMPLETED.String())))
+			assert.NoError(t, transfers.UpdateTransfer(ctx, transferID, webhookChange(enums.REVERSED.String())))
+
+			for account, counterparty := range map[string]string{"stmt_payer": "stmt_payee", "stmt_payee": "stmt_payer"} {
+				_, lines, err := repo.GetStatementEntries(ctx, account, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+				assert.NoError(t, err)
+				assert.Len(t, lines, 2)
+				for _, line := range lines {
+					assert.Equal(t, counterparty, line.Counterparty, "%s %s", account, line.Direction)
+				}
+			}
+		})
+
+		t.Run("unknown_account", func(t *testing.T) {
+			_, _, err := repo.GetStatementEntries(ctx, "stmt_missing", march, march.AddDate(0, 1, 0))
+			assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		})
+	})
+}
+
+func TestGormIdempotencyRepository(t *testing.T) {
+	mainDB := setupTestDB(t)
+
+	tx := mainDB.Begin()
+	assert.NoError(t, tx.Error)
+	defer tx.Rollback()
+
+	repo := repository.NewGormIdempotencyRepository(tx)
+	lockedUntil := time.Now().Add(time.Minute)
+
+	t.Run("first_reservation_wins", func(t *testing.T) {
+		record, reserved, err := repo.Reserve(ctx, "user-1", "key-1", "fingerprint-a", lockedUntil)
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+		assert.Equal(t, "key-1", record.IdempotencyKey)
+
+		record, reserved, err = repo.Reserve(ctx, "user-1", "key-1", "fingerprint-b", lockedUntil)
+		assert.NoError(t, err)
+		assert.False(t, reserved)
+		assert.Equal(t, "fingerprint-a", record.Fingerprint)
+		assert.Equal(t, 0, record.ResponseCode)
+	})
+
+	t.Run("keys_are_scoped_per_subject", func(t *testing.T) {
+		_, reserved, err := repo.Reserve(ctx, "user-1", "key-4", "fingerprint-a", lockedUntil)
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+
+		record, reserved, err := repo.Reserve(ctx, "user-2", "key-4", "fingerprint-b", lockedUntil)
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+		assert.Equal(t, "user-2", record.Subject)
+	})
+
+	t.Run("completed_response_is_returned", func(t *testing.T) {
+		_, reserved, err := repo.Reserve(ctx, "user-1", "key-2", "fingerprint-a", lockedUntil)
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+		assert.NoError(t, repo.Complete(ctx, "user-1", "key-2", 201, "application/json", []byte(`{"transfer_id":"abc"}`)))
+
+		record, reserved, err := repo.Reserve(ctx, "user-1", "key-2", "fingerprint-a", lockedUntil)
+		assert.NoError(t, err)
+		assert.False(t, reserved)
+		assert.Equal(t, 201, record.ResponseCode)
+		assert.Equal(t, "application/json", record.ContentType)
+		assert.Equal(t, `{"transfer_id":"abc"}`, string(record.ResponseBody))
+	})
+
+	t.Run("released_key_can_be_reserved_again", func(t *testing.T) {
+		_, reserved, err := repo.Reserve(ctx, "user-1", "key-3", "fingerprint-a", lockedUntil)
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+		assert.NoError(t, repo.Release(ctx, "user-1", "key-3"))
+
+		_, reserved, err = repo.Reserve(ctx, "user-1", "key-3", "fingerprint-a", lockedUntil)
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+	})
+
+	t.Run("expired_request_in_flight_gives_up_its_key", func(t *testing.T) {
+		_, reserved, err := repo.Reserve(ctx, "user-1", "key-5", "fingerprint-a", time.Now().Add(-time.Second))
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+
+		record, reserved, err := repo.Reserve(ctx, "user-1", "key-5", "fingerprint-b", lockedUntil)
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+		assert.Equal(t, "fingerprint-b", record.Fingerprint)
+
+		_, reserved, err = repo.Reserve(ctx, "user-1", "key-5", "fingerprint-b", lockedUntil)
+		assert.NoError(t, err)
+		assert.False(t, reserved)
+	})
+}
+
+func TestMigrate_ScopesIdempotencyKeysToTheCaller(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&models.IdempotencyRecord{}))
+	assert.NoError(t, db.Exec("CREATE UNIQUE INDEX idx_idempotency_records_idempotency_key ON idempotency_records(idempotency_key)").Error)
+
+	assert.NoError(t, repository.Migrate(db))
+	assert.NoError(t, repository.Migrate(db))
+
+	repo := repository.NewGormIdempotencyRepository(db)
+	for _, subject := range []string{"user-1", "user-2"} {
+		_, reserved, err := repo.Reserve(ctx, subject, "shared-key", "fingerprint", time.Now().Add(time.Minute))
+		assert.NoError(t, err)
+		assert.True(t, reserved, subject)
+	}
+}
+
+func TestGormWebhookDeliveryRepository(t *testing.T) {
+	mainDB := setupTestDB(t)
+
+	tx := mainDB.Begin()
+	assert.NoError(t, tx.Error)
+	defer tx.Rollback()
+
+	repo := repository.NewGormWebhookDeliveryRepository(tx)
+
+	t.Run("duplicate_event_is_not_recorded", func(t *testing.T) {
+		recorded, err := repo.Record(ctx, "acme", "evt_1")
+		assert.NoError(t, err)
+		assert.True(t, recorded)
+
+		recorded, err = repo.Record(ctx, "acme", "evt_1")
+		assert.NoError(t, err)
+		assert.False(t, recorded)
+	})
+
+	t.Run("event_ids_are_scoped_per_provider", func(t *testing.T) {
+		recorded, err := repo.Record(ctx, "other", "evt_1")
+		assert.NoError(t, err)
+		assert.True(t, recorded)
+	})
+
+	t.Run("forgotten_event_can_be_recorded_again", func(t *testing.T) {
+		_, err := repo.Record(ctx, "acme", "evt_2")
+		assert.NoError(t, err)
+		assert.NoError(t, repo.Forget(ctx, "acme", "evt_2"))
+
+		recorded, err := repo.Record(ctx, "acme", "evt_2")
+		assert.NoError(t, err)
+		assert.True(t, recorded)
+	})
+}
+
+func TestGormJobRepository(t *testing.T) {
+	mainDB := setupTestDB(t)
+
+	tx := mainDB.Begin()
+	assert.NoError(t, tx.Error)
+	defer tx.Rollback()
+
+	repo := repository.NewGormJobRepository(tx)
+	now := time.Now()
+
+	t.Run("claims_only_due_jobs", func(t *testing.T) {
+		dueID, err := repo.Enqueue(ctx, "test_job", "ref-due", now.Add(-time.Second))
+		assert.NoError(t, err)
+		_, err = repo.Enqueue(ctx, "test_job", "ref-future", now.Add(time.Hour))
+		assert.NoError(t, err)
+
+		claimed, err := repo.ClaimDue(ctx, now, 10, time.Minute)
+		assert.NoError(t, err)
+		assert.Len(t, claimed, 1)
+		assert.Equal(t, dueID, claimed[0].JobID)
+		assert.Equal(t, enums.JobRunning.String(), claimed[0].Status)
+
+		again, err := repo.ClaimDue(ctx, now, 10, time.Minute)
+		assert.NoError(t, err)
+		assert.Empty(t, again)
+
+		assert.NoError(t, repo.Complete(ctx, dueID, 1))
+	})
+
+	t.Run("reclaims_jobs_with_expired_lease", func(t *testing.T) {
+		jobID, err := repo.Enqueue(ctx, "lease_job", "ref-lease", now.Add(-time.Minute))
+		assert.NoError(t, err)
+
+		claimed, err := repo.ClaimDue(ctx, now, 10, time.Second)
+		assert.NoError(t, err)
+		assert.Len(t, claimed, 1)
+
+		reclaimed, err := repo.ClaimDue(ctx, now.Add(time.Minute), 10, time.Second)
+		assert.NoError(t, err)
+		assert.Len(t, reclaimed, 1)
+		assert.Equal(t, jobID, reclaimed[0].JobID)
+
+		assert.NoError(t, repo.Complete(ctx, jobID, 1))
+	})
+
+	t.Run("reschedule_and_fail_persist_state", func(t *testing.T) {
+		jobID, err := repo.Enqueue(ctx, "retry_job", "ref-retry", now.Add(-time.Second))
+		assert.NoError(t, err)
+		_, err = repo.ClaimDue(ctx, now, 10, time.Minute)
+		assert.NoError(t, err)
+
+		assert.NoError(t, repo.Reschedule(ctx, jobID, 1, now.Add(time.Hour), "still pending"))
+
+		var job models.ScheduledJob
+		assert.NoError(t, tx.Where("job_id = ?", jobID).First(&job).Error)
+		assert.Equal(t, enums.JobPending.String(), job.Status)
+		assert.Equal(t, 1, job.Attempts)
+		assert.Equal(t, "still pending", job.LastError)
+		assert.Nil(t, job.LockedUntil)
+
+		assert.NoError(t, repo.Fail(ctx, jobID, 5, "gave up"))
+		assert.NoError(t, tx.Where("job_id = ?", jobID).First(&job).Error)
+		assert.Equal(t, enums.JobFailed.String(), job.Status)
+		assert.Equal(t, 5, job.Attempts)
+	})
+
+	t.Run("unknown_job", func(t *testing.T) {
+		assert.EqualError(t, repo.Complete(ctx, "missing-job", 1), "job not found")
+	})
+}
+
+func TestGormAccountRepository(t *testing.T) {
+	mainDB := setupTestDB(t)
+
+	tx := mainDB.Begin()
+	assert.NoError(t, tx.Error)
+	defer tx.Rollback()
+
+	repo := repository.NewGormAccountRepository(tx)
+	transfers := repository.NewGormRepository(tx, "acct_bank")
+
+	open := func(id, currency string) {
+		_, err := repo.CreateAccount(ctx, models.Account{AccountID: id, Owner: "owner-1", Currency: currency})
+		assert.NoError(t, err)
+	}
+
+	t.Run("open_and_get", func(t *testing.T) {
+		account, err := repo.CreateAccount(ctx, models.Account{
+			AccountID: "acct_open",
+			Owner:     "owner-1",
+			Currency:  "EUR",
+			Metadata:  map[string]string{"kyc": "passed"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, enums.AccountActive.String(), account.Status)
+
+		found, err := repo.GetAccount(ctx, "acct_open")
+		assert.NoError(t, err)
+		assert.Equal(t, "owner-1", found.Owner)
+		assert.Equal(t, "EUR", found.Currency)
+		assert.Equal(t, map[string]string{"kyc": "passed"}, found.Metadata)
+
+		balances, err := transfers.GetAccountBalance(ctx, "acct_open")
+		assert.NoError(t, err)
+		assert.Empty(t, balances)
+	})
+
+	t.Run("duplicate_account", func(t *testing.T) {
+		open("acct_dup", "")
+		_, err := repo.CreateAccount(ctx, models.Account{AccountID: "acct_dup", Owner: "owner-2"})
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+	})
+
+	t.Run("unknown_account", func(t *testing.T) {
+		_, err := repo.GetAccount(ctx, "acct_missing")
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		_, err = repo.UpdateAccountStatus(ctx, "acct_missing", enums.AccountFrozen)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+
+	t.Run("frozen_account_rejects_transfers_until_unfrozen", func(t *testing.T) {
+		open("acct_frozen", "")
+
+		account, err := repo.UpdateAccountStatus(ctx, "acct_frozen", enums.AccountFrozen)
+		assert.NoError(t, err)
+		assert.Equal(t, enums.AccountFrozen.String(), account.Status)
+
+		_, err = transfers.CreateTransfer(ctx, "acct_bank", "acct_frozen", money.MustParse("10"), "USD")
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+		assert.Equal(t, apperrors.CodeAccountFrozen, apperrors.Code(err, ""))
+
+		_, err = repo.UpdateAccountStatus(ctx, "acct_frozen", enums.AccountClosed)
+		assert.ErrorIs(t, err, apperrors.ErrInvalidTransition)
+
+		_, err = repo.UpdateAccountStatus(ctx, "acct_frozen", enums.AccountActive)
+		assert.NoError(t, err)
+		_, err = transfers.CreateTransfer(ctx, "acct_bank", "acct_frozen", money.MustParse("10"), "USD")
+		assert.NoError(t, err)
+	})
+
+	t.Run("close_requires_empty_account", func(t *testing.T) {
+		open("acct_close", "")
+		transferID, err := transfers.CreateTransfer(ctx, "acct_bank", "acct_close", money.MustParse("25"), "USD")
+		assert.NoError(t, err)
+		assert.NoError(t, transfers.UpdateTransfer(ctx, transferID, webhookChange(enums.COMPLETED.String())))
+
+		_, err = repo.UpdateAccountStatus(ctx, "acct_close", enums.AccountClosed)
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+		assert.Equal(t, apperrors.CodeAccountNotEmpty, apperrors.Code(err, ""))
+
+		transferID, err = transfers.CreateTransfer(ctx, "acct_close", "acct_bank", money.MustParse("25"), "USD")
+		assert.NoError(t, err)
+		_, err = repo.UpdateAccountStatus(ctx, "acct_close", enums.AccountClosed)
+		assert.Equal(t, apperrors.CodeAccountNotEmpty, apperrors.Code(err, ""), "funds on hold")
+
+		assert.NoError(t, transfers.UpdateTransfer(ctx, transferID, webhookChange(enums.COMPLETED.String())))
+		account, err := repo.UpdateAccountStatus(ctx, "acct_close", enums.AccountClosed)
+		assert.NoError(t, err)
+		assert.Equal(t, enums.AccountClosed.String(), account.Status)
+
+		_, err = transfers.CreateTransfer(ctx, "acct_bank", "acct_close", money.MustParse("1"), "USD")
+		assert.Equal(t, apperrors.CodeAccountClosed, apperrors.Code(err, ""))
+		_, err = repo.UpdateAccountStatus(ctx, "acct_close", enums.AccountActive)
+		assert.ErrorIs(t, err, apperrors.ErrInvalidTransition)
+	})
+
+	t.Run("close_waits_for_incoming_transfers", func(t *testing.T) {
+		open("acct_incoming", "")
+		transferID, err := transfers.CreateTransfer(ctx, "acct_bank", "acct_incoming", money.MustParse("5"), "USD")
+		assert.NoError(t, err)
+
+		_, err = repo.UpdateAccountStatus(ctx, "acct_incoming", enums.AccountClosed)
+		assert.Equal(t, apperrors.CodeAccountNotEmpty, apperrors.Code(err, ""), "pending credit")
+
+		assert.NoError(t, transfers.UpdateTransfer(ctx, transferID, webhookChange(enums.FAILED.String())))
+		account, err := repo.UpdateAccountStatus(ctx, "acct_incoming", enums.AccountClosed)
+		assert.NoError(t, err)
+		assert.Equal(t, enums.AccountClosed.String(), account.Status)
+	})
+
+	t.Run("transfers_require_open_accounts", func(t *testing.T) {
+		_, err := transfers.CreateTransfer(ctx, "acct_bank", "acct_never_opened", money.MustParse("5"), "USD")
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Equal(t, apperrors.CodeAccountNotFound, apperrors.Code(err, ""))
+
+		_, err = repo.GetAccount(ctx, "acct_never_opened")
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+
+	t.Run("currency_is_enforced", func(t *testing.T) {
+		open("acct_eur", "EUR")
+
+		_, err := transfers.CreateTransfer(ctx, "acct_bank", "acct_eur", money.MustParse("10"), "USD")
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+		assert.Equal(t, apperrors.CodeInvalidCurrency, apperrors.Code(err, ""))
+
+		_, err = transfers.CreateTransfer(ctx, "acct_bank", "acct_eur", money.MustParse("10"), "EUR")
+		assert.NoError(t, err)
+	})
+}
+
+func TestGormStandingOrderRepository(t *testing.T) {
+	mainDB := setupTestDB(t)
+
+	tx := mainDB.Begin()
+	assert.NoError(t, tx.Error)
+	defer tx.Rollback()
+
+	repo := repository.NewGormStandingOrderRepository(tx)
+
+	first := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 1, 0)
+	create := func(id string) models.StandingOrder {
+		order, err := repo.CreateStandingOrder(ctx, models.StandingOrder{
+			OrderID:     id,
+			FromAccount: "so_payer",
+			ToAccount:   "so_payee",
+			Amount:      money.MustParse("50"),
+			Currency:    "USD",
+			Frequency:   "MONTHLY",
+			StartAt:     first,
+			NextRunAt:   &first,
+			JobID:       "job-1",
+		})
+		assert.NoError(t, err)
+		return order
+	}
+
+	t.Run("create_and_get", func(t *testing.T) {
+		create("so_create")
+
+		order, err := repo.GetStandingOrder(ctx, "so_create")
+		assert.NoError(t, err)
+		assert.Equal(t, enums.StandingOrderActive.String(), order.Status)
+		assert.True(t, first.Equal(*order.NextRunAt))
+		assert.Equal(t, "job-1", order.JobID)
+
+		_, err = repo.GetStandingOrder(ctx, "so_missing")
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+
+	t.Run("execution_advances_order", func(t *testing.T) {
+		create("so_run")
+
+		execution, started, err := repo.StartExecution(ctx, "so_run", first)
+		assert.NoError(t, err)
+		assert.True(t, started)
+
+		_, started, err = repo.StartExecution(ctx, "so_run", first)
+		assert.NoError(t, err)
+		assert.False(t, started, "an occurrence is claimed only once")
+
+		execution.Status = enums.ExecutionCreated.String()
+		execution.TransferID = "tr-1"
+		order, err := repo.FinishExecution(ctx, execution, &second)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, order.Executions)
+		assert.True(t, second.Equal(*order.NextRunAt))
+
+		// Finishing the same occurrence again neither counts nor advances.
+		order, err = repo.FinishExecution(ctx, execution, &second)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, order.Executions)
+
+		executions, err := repo.ListExecutions(ctx, "so_run", 10)
+		assert.NoError(t, err)
+		assert.Len(t, executions, 1)
+		assert.Equal(t, "tr-1", executions[0].TransferID)
+		assert.Equal(t, enums.ExecutionCreated.String(), executions[0].Status)
+	})
+
+	t.Run("last_execution_finishes_order", func(t *testing.T) {
+		create("so_last")
+
+		execution, _, err := repo.StartExecution(ctx, "so_last", first)
+		assert.NoError(t, err)
+		execution.Status = enums.ExecutionFailed.String()
+		execution.Error = "insufficient funds"
+
+		order, err := repo.FinishExecution(ctx, execution, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, enums.StandingOrderFinished.String(), order.Status)
+		assert.Nil(t, order.NextRunAt)
+		assert.Empty(t, order.JobID)
+	})
+
+	t.Run("abandoned_execution_can_be_claimed_again", func(t *testing.T) {
+		create("so_abandon")
+
+		execution, _, err := repo.StartExecution(ctx, "so_abandon", first)
+		assert.NoError(t, err)
+		assert.NoError(t, repo.AbandonExecution(ctx, execution))
+
+		_, started, err := repo.StartExecution(ctx, "so_abandon", first)
+		assert.NoError(t, err)
+		assert.True(t, started)
+	})
+
+	t.Run("pause_and_resume", func(t *testing.T) {
+		create("so_pause")
+
+		order, err := repo.UpdateStandingOrderStatus(ctx, "so_pause", enums.StandingOrderPaused, nil, "")
+		assert.NoError(t, err)
+		assert.Equal(t, enums.StandingOrderPaused.String(), order.Status)
+		assert.Nil(t, order.NextRunAt)
+
+		handedOver, err := repo.SetStandingOrderJob(ctx, "so_pause", "", "job-2")
+		assert.NoError(t, err)
+		assert.False(t, handedOver, "paused orders get no job")
+
+		order, err = repo.UpdateStandingOrderStatus(ctx, "so_pause", enums.StandingOrderActive, &second, "job-3")
+		assert.NoError(t, err)
+		assert.Equal(t, enums.StandingOrderActive.String(), order.Status)
+		assert.Equal(t, "job-3", order.JobID)
+
+		handedOver, err = repo.SetStandingOrderJob(ctx, "so_pause", "job-1", "job-4")
+		assert.NoError(t, err)
+		assert.False(t, handedOver, "a stale job cannot take the order over")
+
+		handedOver, err = repo.SetStandingOrderJob(ctx, "so_pause", "job-3", "job-4")
+		assert.NoError(t, err)
+		assert.True(t, handedOver)
+	})
+
+	t.Run("cancelled_order_stays_cancelled", func(t *testing.T) {
+		create("so_cancel")
+
+		_, err := repo.UpdateStandingOrderStatus(ctx, "so_cancel", enums.StandingOrderCancelled, nil, "")
+		assert.NoError(t, err)
+
+		_, err = repo.UpdateStandingOrderStatus(ctx, "so_cancel", enums.StandingOrderActive, &second, "job-2")
+		assert.ErrorIs(t, err, apperrors.ErrInvalidTransition)
+	})
+
+	t.Run("list_by_account", func(t *testing.T) {
+		orders, err := repo.ListStandingOrders(ctx, []string{"so_payee"}, 2)
+		assert.NoError(t, err)
+		assert.Len(t, orders, 2)
+		assert.Equal(t, "so_cancel", orders[0].OrderID)
+
+		orders, err = repo.ListStandingOrders(ctx, []string{"nobody"}, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, orders)
+	})
+}
+
+func TestGormBatchRepository(t *testing.T) {
+	mainDB := setupTestDB(t)
+
+	tx := mainDB.Begin()
+	assert.NoError(t, tx.Error)
+	defer tx.Rollback()
+
+	repo := repository.NewGormBatchRepository(tx)
+
+	create := func(id string, rows ...models.TransferBatchRow) models.TransferBatch {
+		batch, err := repo.CreateBatch(ctx, models.TransferBatch{BatchID: id, Format: "text/csv", CreatedBy: "user-1"}, rows)
+		assert.NoError(t, err)
+		return batch
+	}
+	row := func(line int) models.TransferBatchRow {
+		return models.TransferBatchRow{Line: line, FromAccount: "b_payer", ToAccount: "b_payee", Amount: money.MustParse("5"), Currency: "USD"}
+	}
+	invalid := models.TransferBatchRow{
+		Line:      4,
+		Status:    enums.BatchRowFailed.String(),
+		ErrorCode: apperrors.CodeValidationFailed,
+		Fields:    []apperrors.FieldError{{Field: "amount", Code: apperrors.CodeInvalidAmount, Message: "invalid amount"}},
+	}
+
+	t.Run("create_and_get", func(t *testing.T) {
+		batch := create("batch_create", row(2), row(3), invalid)
+		assert.Equal(t, enums.BatchProcessing.String(), batch.Status)
+		assert.Equal(t, 3, batch.TotalRows)
+
+		batch, err := repo.GetBatch(ctx, "batch_create")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, batch.PendingRows)
+		assert.Equal(t, 1, batch.FailedRows)
+		assert.Nil(t, batch.CompletedAt)
+
+		rows, err := repo.ListBatchRows(ctx, "batch_create", enums.BatchRowFailed, 0, 10)
+		assert.NoError(t, err)
+		assert.Len(t, rows, 1)
+		assert.Equal(t, invalid.Fields, rows[0].Fields)
+
+		_, err = repo.GetBatch(ctx, "batch_missing")
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+
+	t.Run("batch_without_pending_rows_is_completed", func(t *testing.T) {
+		batch := create("batch_invalid", invalid)
+		assert.Equal(t, enums.BatchCompleted.String(), batch.Status)
+		assert.NotNil(t, batch.CompletedAt)
+	})
+
+	t.Run("rows_are_processed_once", func(t *testing.T) {
+		create("batch_run", row(2), row(3))
+
+		pending, err := repo.PendingBatchRows(ctx, "batch_run", 1)
+		assert.NoError(t, err)
+		assert.Len(t, pending, 1)
+		assert.Equal(t, 2, pending[0].Line)
+
+		started, err := repo.StartBatchRow(ctx, pending[0])
+		assert.NoError(t, err)
+		assert.True(t, started)
+		started, err = repo.StartBatchRow(ctx, pending[0])
+		assert.NoError(t, err)
+		assert.False(t, started, "a row is claimed only once")
+
+		pending[0].Status = enums.BatchRowCreated.String()
+		pending[0].TransferID = "tr-1"
+		assert.NoError(t, repo.FinishBatchRow(ctx, pending[0]))
+
+		pending, err = repo.PendingBatchRows(ctx, "batch_run", 10)
+		assert.NoError(t, err)
+		assert.Len(t, pending, 1)
+		assert.Equal(t, 3, pending[0].Line)
+
+		_, err = repo.StartBatchRow(ctx, pending[0])
+		assert.NoError(t, err)
+		assert.NoError(t, repo.ReleaseBatchRow(ctx, pending[0]))
+
+		assert.NoError(t, repo.CompleteBatch(ctx, "batch_run"))
+		batch, err := repo.GetBatch(ctx, "batch_run")
+		assert.NoError(t, err)
+		assert.Equal(t, enums.BatchProcessing.String(), batch.Status, "a released row is still pending")
+
+		_, err = repo.StartBatchRow(ctx, pending[0])
+		assert.NoError(t, err)
+		failed, err := repo.FailBatchRows(ctx, "batch_run", enums.BatchRowRunning, "interrupted")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), failed)
+
+		assert.NoError(t, repo.CompleteBatch(ctx, "batch_run"))
+		batch, err = repo.GetBatch(ctx, "batch_run")
+		assert.NoError(t, err)
+		assert.Equal(t, enums.BatchCompleted.String(), batch.Status)
+		assert.Equal(t, 1, batch.CreatedRows)
+		assert.Equal(t, 1, batch.FailedRows)
+		assert.Zero(t, batch.PendingRows)
+
+		rows, err := repo.ListBatchRows(ctx, "batch_run", "", 0, 10)
+		assert.NoError(t, err)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, "tr-1", rows[0].TransferID)
+		assert.Equal(t, "interrupted", rows[1].Error)
+
+		rows, err = repo.ListBatchRows(ctx, "batch_run", "", 2, 10)
+		assert.NoError(t, err)
+		assert.Len(t, rows, 1)
+		assert.Equal(t, 3, rows[0].Line)
+	})
+}
+
+func TestMigrate_ConvertsDecimalAmounts(t *testing.T) {
+	type Transfer struct {
+		gorm.Model
+		TransferID  string `gorm:"uniqueIndex"`
+		FromAccount string
+		ToAccount   string
+		Amount      float64
+		Currency    string
+		Status      string
+	}
+	type LedgerEntry struct {
+		gorm.Model
+		TransferID string `gorm:"index"`
+		AccountID  string `gorm:"index"`
+		Direction  string
+		Amount     float64
+	}
+
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&Transfer{}, &LedgerEntry{}))
+	assert.NoError(t, db.Create(&Transfer{TransferID: "legacy", FromAccount: "acc_a", ToAccount: "acc_b", Amount: 12.34, Currency: "USD", Status: "COMPLETED"}).Error)
+	assert.NoError(t, db.Create(&LedgerEntry{TransferID: "legacy", AccountID: "acc_a", Direction: "DEBIT", Amount: -12.34}).Error)
+
+	assert.NoError(t, repository.Migrate(db))
+	assert.NoError(t, repository.Migrate(db))
+
+	var transfer models.Transfer
+	assert.NoError(t, db.Where("transfer_id = ?", "legacy").First(&transfer).Error)
+	assert.Equal(t, money.MustParse("12.34"), transfer.Amount)
+
+	var entry models.LedgerEntry
+	assert.NoError(t, db.Where("transfer_id = ? AND account_id = ?", "legacy", "acc_a").First(&entry).Error)
+	assert.Equal(t, money.MustParse("-12.34"), entry.Amount)
+}
+
+func TestMigrate_BackfillsLedgerEntriesOfCompletedTransfers(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(repository.Models...))
+
+	amount := money.MustParse("25.5")
+	for _, transfer := range []models.Transfer{
+		{TransferID: "completed", FromAccount: "acc_a", ToAccount: "acc_b", Amount: amount, Currency: "USD", Status: enums.COMPLETED.String()},
+		{TransferID: "pending", FromAccount: "acc_a", ToAccount: "acc_b", Amount: amount, Currency: "USD", Status: enums.PENDING.String()},
+		{TransferID: "failed", FromAccount: "acc_a", ToAccount: "acc_b", Amount: amount, Currency: "USD", Status: enums.FAILED.String()},
+	} {
+		assert.NoError(t, db.Create(&transfer).Error)
+	}
+
+	assert.NoError(t, repository.Migrate(db))
+	assert.NoError(t, repository.Migrate(db))
+
+	var entries []models.LedgerEntry
+	assert.NoError(t, db.Order("id").Find(&entries).Error)
+	assert.Len(t, entries, 2)
+	for _, entry := range entries {
+		assert.Equal(t, "completed", entry.TransferID)
+		assert.Equal(t, "USD", entry.Currency)
+	}
+	assert.Equal(t, "acc_a", entries[0].AccountID)
+	assert.Equal(t, amount.Neg(), entries[0].Amount)
+	assert.Equal(t, "acc_b", entries[1].AccountID)
+	assert.Equal(t, amount, entries[1].Amount)
+
+	balances, err := repository.NewGormRepository(db).GetAccountBalance(ctx, "acc_b")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.AccountBalance{{Currency: "USD", Ledger: amount, Available: amount}}, balances)
+}
+
+func TestMigrate_OpensAccountsOfExistingTransfers(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(repository.Models...))
+	assert.NoError(t, db.Create(&models.Transfer{TransferID: "legacy", FromAccount: "legacy_from", ToAccount: "legacy_to", Amount: money.MustParse("5"), Currency: "USD", Status: enums.PENDING.String()}).Error)
+
+	assert.NoError(t, repository.Migrate(db))
+
+	accounts := repository.NewGormAccountRepository(db)
+	for _, id := range []string{"legacy_from", "legacy_to"} {
+		account, err := accounts.GetAccount(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, enums.AccountActive.String(), account.Status)
+	}
 }
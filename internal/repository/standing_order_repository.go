@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/models"
+)
+
+type StandingOrderRepository interface {
+	CreateStandingOrder(ctx context.Context, order models.StandingOrder) (models.StandingOrder, error)
+	GetStandingOrder(ctx context.Context, id string) (models.StandingOrder, error)
+	ListStandingOrders(ctx context.Context, accounts []string, limit int) ([]models.StandingOrder, error)
+	UpdateStandingOrderStatus(ctx context.Context, id string, status enums.StandingOrderStatus, nextRunAt *time.Time, jobID string) (models.StandingOrder, error)
+	SetStandingOrderJob(ctx context.Context, id, currentJobID, jobID string) (bool, error)
+	StartExecution(ctx context.Context, id string, scheduledFor time.Time) (models.StandingOrderExecution, bool, error)
+	AbandonExecution(ctx context.Context, execution models.StandingOrderExecution) error
+	FinishExecution(ctx context.Context, execution models.StandingOrderExecution, nextRunAt *time.Time) (models.StandingOrder, error)
+	ListExecutions(ctx context.Context, id string, limit int) ([]models.StandingOrderExecution, error)
+}
+
+type GormStandingOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewGormStandingOrderRepository(database *gorm.DB) StandingOrderRepository {
+	return &GormStandingOrderRepository{db: database}
+}
+
+// CreateStandingOrder stores an ACTIVE order. The caller picks OrderID so it
+// can enqueue the first job before the order exists.
+func (r *GormStandingOrderRepository) CreateStandingOrder(ctx context.Context, order models.StandingOrder) (models.StandingOrder, error) {
+	order.Status = enums.StandingOrderActive.String()
+	if err := r.db.WithContext(ctx).Create(&order).Error; err != nil {
+		return models.StandingOrder{}, err
+	}
+	return order, nil
+}
+
+func (r *GormStandingOrderRepository) GetStandingOrder(ctx context.Context, id string) (models.StandingOrder, error) {
+	var order models.StandingOrder
+	err := r.db.WithContext(ctx).Where("order_id = ?", id).First(&order).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return order, errStandingOrderNotFound(err)
+	}
+	return order, err
+}
+
+// ListStandingOrders returns orders newest first, optionally restricted to
+// those touching one of accounts.
+func (r *GormStandingOrderRepository) ListStandingOrders(ctx context.Context, accounts []string, limit int) ([]models.StandingOrder, error) {
+	query := r.db.WithContext(ctx).Model(&models.StandingOrder{})
+	if len(accounts) > 0 {
+		query = query.Where("from_account IN ? OR to_account IN ?", accounts, accounts)
+	}
+
+	orders := []models.StandingOrder{}
+	if err := query.Order("id DESC").Limit(limit).Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// UpdateStandingOrderStatus moves an order to status under a row lock and sets
+// the occurrence it runs next and the job that will run it.
+func (r *GormStandingOrderRepository) UpdateStandingOrderStatus(ctx context.Context, id string, status enums.StandingOrderStatus, nextRunAt *time.Time, jobID string) (models.StandingOrder, error) {
+	var order models.StandingOrder
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if order, err = lockStandingOrder(tx, id); err != nil {
+			return err
+		}
+
+		if err := enums.ValidateStandingOrderTransition(enums.StandingOrderStatus(order.Status), status); err != nil {
+			return err
+		}
+
+		order.Status = status.String()
+		order.NextRunAt = nextRunAt
+		order.JobID = jobID
+		return tx.Model(&order).Updates(map[string]interface{}{
+			"status":      order.Status,
+			"next_run_at": order.NextRunAt,
+			"job_id":      order.JobID,
+		}).Error
+	})
+	if err != nil {
+		return models.StandingOrder{}, err
+	}
+
+	return order, nil
+}
+
+// SetStandingOrderJob hands an ACTIVE order over from currentJobID to jobID.
+// It reports false when the order was paused, cancelled or given another job
+// meanwhile.
+func (r *GormStandingOrderRepository) SetStandingOrderJob(ctx context.Context, id, currentJobID, jobID string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.StandingOrder{}).
+		Where("order_id = ? AND job_id = ? AND status = ?", id, currentJobID, enums.StandingOrderActive.String()).
+		Update("job_id", jobID)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// StartExecution claims one occurrence of an order by recording it as
+// RUNNING. When the occurrence was already claimed, the existing record is
+// returned with false so a transfer is never created twice for it.
+func (r *GormStandingOrderRepository) StartExecution(ctx context.Context, id string, scheduledFor time.Time) (models.StandingOrderExecution, bool, error) {
+	execution := models.StandingOrderExecution{
+		OrderID:      id,
+		ScheduledFor: scheduledFor,
+		Status:       enums.ExecutionRunning.String(),
+		ExecutedAt:   time.Now(),
+	}
+
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&execution)
+	if result.Error != nil {
+		return models.StandingOrderExecution{}, false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return execution, true, nil
+	}
+
+	var existing models.StandingOrderExecution
+	err := r.db.WithContext(ctx).Where("order_id = ? AND scheduled_for = ?", id, scheduledFor).First(&existing).Error
+	return existing, false, err
+}
+
+// AbandonExecution drops a claim whose transfer could not be created so the
+// occurrence can be retried.
+func (r *GormStandingOrderRepository) AbandonExecution(ctx context.Context, execution models.StandingOrderExecution) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&models.StandingOrderExecution{}, execution.ID).Error
+}
+
+// FinishExecution stores the outcome of a RUNNING occurrence and counts it.
+// If the order is still ACTIVE and waiting for that occurrence, it moves on to
+// nextRunAt, or is FINISHED when there is none.
+func (r *GormStandingOrderRepository) FinishExecution(ctx context.Context, execution models.StandingOrderExecution, nextRunAt *time.Time) (models.StandingOrder, error) {
+	var order models.StandingOrder
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if order, err = lockStandingOrder(tx, execution.OrderID); err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.StandingOrderExecution{}).
+			Where("id = ? AND status = ?", execution.ID, enums.ExecutionRunning.String()).
+			Updates(map[string]interface{}{
+				"status":      execution.Status,
+				"transfer_id": execution.TransferID,
+				"error":       execution.Error,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		updates := map[string]interface{}{}
+		if result.RowsAffected == 1 {
+			order.Executions++
+			updates["executions"] = order.Executions
+		}
+		if order.Status == enums.StandingOrderActive.String() && order.NextRunAt != nil && order.NextRunAt.Equal(execution.ScheduledFor) {
+			order.NextRunAt = nextRunAt
+			updates["next_run_at"] = nextRunAt
+			if nextRunAt == nil {
+				order.Status = enums.StandingOrderFinished.String()
+				order.JobID = ""
+				updates["status"] = order.Status
+				updates["job_id"] = order.JobID
+			}
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+		return tx.Model(&order).Updates(updates).Error
+	})
+	if err != nil {
+		return models.StandingOrder{}, err
+	}
+
+	return order, nil
+}
+
+// ListExecutions returns the executions of an order, latest occurrence first.
+func (r *GormStandingOrderRepository) ListExecutions(ctx context.Context, id string, limit int) ([]models.StandingOrderExecution, error) {
+	executions := []models.StandingOrderExecution{}
+	err := r.db.WithContext(ctx).Where("order_id = ?", id).Order("scheduled_for DESC").Limit(limit).Find(&executions).Error
+	if err != nil {
+		return nil, err
+	}
+	return executions, nil
+}
+
+func lockStandingOrder(tx *gorm.DB, id string) (models.StandingOrder, error) {
+	var order models.StandingOrder
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_id = ?", id).First(&order).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return order, errStandingOrderNotFound(err)
+	}
+	return order, err
+}
+
+func errStandingOrderNotFound(cause error) error {
+	return apperrors.Wrap(cause, apperrors.ErrNotFound, apperrors.CodeOrderNotFound, "standing order not found")
+}
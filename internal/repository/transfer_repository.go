@@ -1,54 +1,203 @@
 package repository
 
 import (
-	"database/sql"
+	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"secure-payment-service/internal/apperrors"
 	"secure-payment-service/internal/enums"
 	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/money"
 )
 
 type TransferRepository interface {
-	CreateTransfer(from, to string, amount float64) (string, error)
-	GetTransfer(id string) (models.Transfer, error)
-	GetAccountBalance(id string) (float64, error)
-	UpdateTransfer(id, status string) error
+	CreateTransfer(ctx context.Context, from, to string, amount money.Amount, currency string) (string, error)
+	GetTransfer(ctx context.Context, id string) (models.Transfer, error)
+	GetAccountBalance(ctx context.Context, id string) ([]models.AccountBalance, error)
+	UpdateTransfer(ctx context.Context, id string, change models.StatusChange) error
+	GetTransferHistory(ctx context.Context, id string) ([]models.TransferStatusHistory, error)
+	ListTransfers(ctx context.Context, filter models.TransferFilter) ([]models.Transfer, error)
+	GetStatementEntries(ctx context.Context, id string, from, to time.Time) ([]models.Balance, []models.StatementLine, error)
+	RefundTransfer(ctx context.Context, id string, amount money.Amount, change models.StatusChange) (models.Transfer, error)
+	ScheduleTransfer(ctx context.Context, from, to string, amount money.Amount, currency string, executeAt time.Time) (string, error)
+	ReleaseScheduledTransfer(ctx context.Context, id string, now time.Time) (bool, error)
+	UpdateScheduledTransfer(ctx context.Context, id string, edit models.ScheduledTransferEdit) (models.Transfer, error)
+	ListScheduledTransfers(ctx context.Context, accounts []string, limit int) ([]models.Transfer, error)
+	CreateTransferGroup(ctx context.Context, from, currency string, legs []models.TransferLeg) ([]models.Transfer, error)
+	GetTransferGroup(ctx context.Context, parentID string) ([]models.Transfer, error)
 }
 
 type GormRepository struct {
-	db *gorm.DB
+	db         *gorm.DB
+	settlement map[string]bool
 }
 
-func NewGormRepository(database *gorm.DB) TransferRepository {
-	return &GormRepository{db: database}
+// NewGormRepository builds the transfer repository. Settlement accounts are
+// where funds enter and leave the system, so they skip the funds check and
+// their balance may go below zero.
+func NewGormRepository(database *gorm.DB, settlementAccounts ...string) TransferRepository {
+	settlement := make(map[string]bool, len(settlementAccounts))
+	for _, id := range settlementAccounts {
+		settlement[id] = true
+	}
+	return &GormRepository{db: database, settlement: settlement}
 }
 
 func generateUUID() string {
 	return uuid.New().String()
 }
 
-func (r *GormRepository) CreateTransfer(from, to string, amount float64) (string, error) {
+func (r *GormRepository) CreateTransfer(ctx context.Context, from, to string, amount money.Amount, currency string) (string, error) {
 	transfer := models.Transfer{
 		TransferID:  generateUUID(),
 		FromAccount: from,
 		ToAccount:   to,
 		Amount:      amount,
+		Currency:    currency,
 		Status:      enums.PENDING.String(),
+		Version:     1,
 	}
 
-	if err := r.db.Create(&transfer).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.reserveFunds(tx, transfer); err != nil {
+			return err
+		}
+		if err := tx.Create(&transfer).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.TransferStatusHistory{
+			TransferID: transfer.TransferID,
+			ToStatus:   transfer.Status,
+			Source:     enums.SourceAPI.String(),
+		}).Error
+	})
+	if err != nil {
 		return "", err
 	}
 
 	return transfer.TransferID, nil
 }
 
-func (r *GormRepository) GetTransfer(id string) (models.Transfer, error) {
+// CreateTransferGroup creates one PENDING transfer per leg, all sharing a new
+// ParentID, in a single transaction: if any leg cannot reserve its funds, no
+// leg is created. Every account is locked up front so the legs are checked
+// against each other's holds.
+func (r *GormRepository) CreateTransferGroup(ctx context.Context, from, currency string, legs []models.TransferLeg) ([]models.Transfer, error) {
+	parentID := generateUUID()
+	transfers := make([]models.Transfer, len(legs))
+	accounts := []string{from}
+	for i, leg := range legs {
+		transfers[i] = models.Transfer{
+			TransferID:  generateUUID(),
+			FromAccount: from,
+			ToAccount:   leg.ToAccount,
+			Amount:      leg.Amount,
+			Currency:    currency,
+			Status:      enums.PENDING.String(),
+			Version:     1,
+			ParentID:    parentID,
+		}
+		accounts = append(accounts, leg.ToAccount)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockAccounts(tx, accounts...); err != nil {
+			return err
+		}
+		for i := range transfers {
+			if err := r.reserveFunds(tx, transfers[i]); err != nil {
+				return err
+			}
+			if err := tx.Create(&transfers[i]).Error; err != nil {
+				return err
+			}
+			err := tx.Create(&models.TransferStatusHistory{
+				TransferID: transfers[i].TransferID,
+				ToStatus:   transfers[i].Status,
+				Source:     enums.SourceAPI.String(),
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// GetTransferGroup returns the legs of a multi-leg transfer in the order they
+// were given.
+func (r *GormRepository) GetTransferGroup(ctx context.Context, parentID string) ([]models.Transfer, error) {
+	var legs []models.Transfer
+	if err := r.db.WithContext(ctx).Where("parent_id = ?", parentID).Order("id").Find(&legs).Error; err != nil {
+		return nil, err
+	}
+	if len(legs) == 0 {
+		return nil, apperrors.New(apperrors.ErrNotFound, apperrors.CodeGroupNotFound, "transfer group not found")
+	}
+	return legs, nil
+}
+
+// reserveFunds checks that both accounts may take part in transfer and puts
+// its amount on hold in the source account. Settlement accounts skip the
+// funds check.
+func (r *GormRepository) reserveFunds(tx *gorm.DB, transfer models.Transfer) error {
+	from, to := transfer.FromAccount, transfer.ToAccount
+	accounts, err := lockAccounts(tx, from, to)
+	if err != nil {
+		return err
+	}
+	for _, id := range []string{from, to} {
+		if err := checkCanTransact(accounts[id], transfer.Currency); err != nil {
+			return err
+		}
+	}
+	if err := r.checkFunds(tx, from, transfer.Currency, transfer.Amount); err != nil {
+		return err
+	}
+	return tx.Create(&models.Hold{
+		TransferID: transfer.TransferID,
+		AccountID:  from,
+		Status:     enums.HoldActive.String(),
+		Amount:     transfer.Amount,
+		Currency:   transfer.Currency,
+	}).Error
+}
+
+// checkFunds reports insufficient funds unless account has amount available
+// in currency. Settlement accounts always pass. The account must be locked.
+func (r *GormRepository) checkFunds(tx *gorm.DB, account, currency string, amount money.Amount) error {
+	if r.settlement[account] {
+		return nil
+	}
+	available, err := availableBalance(tx, account, currency)
+	if err != nil {
+		return err
+	}
+	if available.Sub(amount).IsNegative() {
+		return apperrors.New(apperrors.ErrInsufficientFunds, apperrors.CodeInsufficientFunds, "insufficient funds in account "+account)
+	}
+	return nil
+}
+
+func (r *GormRepository) GetTransfer(ctx context.Context, id string) (models.Transfer, error) {
 	var transfer models.Transfer
-	result := r.db.Where("transfer_id = ?", id).First(&transfer)
+	result := r.db.WithContext(ctx).Where("transfer_id = ?", id).First(&transfer)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return transfer, errTransferNotFound(result.Error)
+	}
 	if result.Error != nil {
 		return transfer, result.Error
 	}
@@ -56,51 +205,720 @@ func (r *GormRepository) GetTransfer(id string) (models.Transfer, error) {
 	return transfer, nil
 }
 
-func (r *GormRepository) GetAccountBalance(id string) (float64, error) {
-	var balanceIn sql.NullFloat64
-	var balanceOut sql.NullFloat64
+// GetAccountBalance reports, per currency, the ledger balance, the funds held
+// by transfers in flight and what remains available.
+func (r *GormRepository) GetAccountBalance(ctx context.Context, id string) ([]models.AccountBalance, error) {
+	if err := r.accountExists(ctx, id); err != nil {
+		return nil, err
+	}
 
-	var count int64
-	r.db.Model(&models.Transfer{}).
-		Where("from_account = ? OR to_account = ?", id, id).
-		Count(&count)
+	ledger := []models.Balance{}
+	err := r.db.WithContext(ctx).Model(&models.LedgerEntry{}).
+		Select("currency, CAST(SUM(amount) AS BIGINT) as balance").
+		Where("account_id = ?", id).
+		Group("currency").
+		Scan(&ledger).Error
+	if err != nil {
+		return nil, err
+	}
 
-	if count == 0 {
-		return 0, errors.New("account not found")
+	held := []models.Balance{}
+	err = r.db.WithContext(ctx).Model(&models.Hold{}).
+		Select("currency, CAST(SUM(amount) AS BIGINT) as balance").
+		Where("account_id = ? AND status = ?", id, enums.HoldActive.String()).
+		Group("currency").
+		Scan(&held).Error
+	if err != nil {
+		return nil, err
 	}
 
-	resultInErr := r.db.Model(&models.Transfer{}).
-		Select("sum(amount)").
-		Where("to_account = ? AND status = ?", id, enums.COMPLETED.String()).
-		Row().Scan(&balanceIn)
+	byCurrency := map[string]*models.AccountBalance{}
+	entry := func(currency string) *models.AccountBalance {
+		if byCurrency[currency] == nil {
+			byCurrency[currency] = &models.AccountBalance{Currency: currency}
+		}
+		return byCurrency[currency]
+	}
+	for _, b := range ledger {
+		entry(b.Currency).Ledger = b.Balance
+	}
+	for _, b := range held {
+		entry(b.Currency).Pending = b.Balance
+	}
 
-	if resultInErr != nil && resultInErr != sql.ErrNoRows {
-		return 0, resultInErr
+	balances := make([]models.AccountBalance, 0, len(byCurrency))
+	for _, b := range byCurrency {
+		b.Available = b.Ledger.Sub(b.Pending)
+		balances = append(balances, *b)
 	}
+	slices.SortFunc(balances, func(a, b models.AccountBalance) int {
+		return strings.Compare(a.Currency, b.Currency)
+	})
+
+	return balances, nil
+}
 
-	resultOutErr := r.db.Model(&models.Transfer{}).
-		Select("sum(amount)").
-		Where("from_account = ? AND status = ?", id, enums.COMPLETED.String()).
-		Row().Scan(&balanceOut)
+// UpdateTransfer moves a transfer to the status of change. The transition is
+// checked against the locked row, so of two racing updates the second sees
+// the status the first left and is rejected if the state machine forbids it;
+// re-delivering the current status is a no-op. The legs of a multi-leg
+// transfer move together until they settle: the change is applied to every
+// leg still in the status the transfer was in, so they are all completed, or
+// all released, in one transaction.
+func (r *GormRepository) UpdateTransfer(ctx context.Context, id string, change models.StatusChange) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		transfer, siblings, err := lockTransferLegs(tx, id)
+		if err != nil {
+			return err
+		}
+		accounts := []string{transfer.FromAccount, transfer.ToAccount}
+		for _, leg := range siblings {
+			accounts = append(accounts, leg.FromAccount, leg.ToAccount)
+		}
+		if _, err := lockAccounts(tx, accounts...); err != nil {
+			return err
+		}
 
-	if resultOutErr != nil && resultOutErr != sql.ErrNoRows {
-		return 0, resultOutErr
+		if len(change.IfMatch) > 0 && !slices.Contains(change.IfMatch, transfer.Version) {
+			return NewVersionMismatchError(transfer.Version)
+		}
+		if transfer.Status == change.Status {
+			return nil
+		}
+
+		if err := r.applyStatusChange(tx, transfer, change); err != nil {
+			return err
+		}
+		if !settlesWithGroup(transfer.Status) {
+			return nil
+		}
+		legChange := change
+		legChange.Reason = "with transfer " + transfer.TransferID
+		if change.Reason != "" {
+			legChange.Reason = change.Reason + " (" + legChange.Reason + ")"
+		}
+		for _, leg := range siblings {
+			if leg.Status != transfer.Status {
+				continue
+			}
+			if err := r.applyStatusChange(tx, leg, legChange); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// settlesWithGroup reports whether a leg in status still moves together with
+// the other legs of its group.
+func settlesWithGroup(status string) bool {
+	return status == enums.PENDING.String() || status == enums.PROCESSING.String()
+}
+
+// lockTransferLegs locks the transfer with id and, while it settles with its
+// group, the other legs of the group too. A group is always locked whole and
+// in id order, so updates of two of its legs cannot deadlock.
+func lockTransferLegs(tx *gorm.DB, id string) (models.Transfer, []models.Transfer, error) {
+	var transfer models.Transfer
+	if err := tx.Where("transfer_id = ?", id).First(&transfer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transfer, nil, errTransferNotFound(err)
+		}
+		return transfer, nil, err
 	}
 
-	calculatedBalance := balanceIn.Float64 - balanceOut.Float64
+	query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("transfer_id = ?", id)
+	if transfer.ParentID != "" && settlesWithGroup(transfer.Status) {
+		query = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("parent_id = ?", transfer.ParentID)
+	}
+	var locked []models.Transfer
+	if err := query.Order("id").Find(&locked).Error; err != nil {
+		return transfer, nil, err
+	}
 
-	return calculatedBalance, nil
+	// Read again under the lock: the first read may be stale.
+	var siblings []models.Transfer
+	for _, leg := range locked {
+		if leg.ID == transfer.ID {
+			transfer = leg
+		} else {
+			siblings = append(siblings, leg)
+		}
+	}
+	return transfer, siblings, nil
 }
 
-func (r *GormRepository) UpdateTransfer(id, status string) error {
-	result := r.db.Model(&models.Transfer{}).Where("transfer_id = ?", id).Update("status", status)
+// applyStatusChange updates one locked transfer, records the change in its
+// history and settles its hold and ledger entries accordingly. A reversal
+// takes the money back from the payee, who must still have it available.
+func (r *GormRepository) applyStatusChange(tx *gorm.DB, transfer models.Transfer, change models.StatusChange) error {
+	if err := enums.ValidateTransition(enums.TransactionStatus(transfer.Status), enums.TransactionStatus(change.Status)); err != nil {
+		return err
+	}
+
+	// The version guard only matters where FOR UPDATE is not available;
+	// with the row locked it always matches.
+	previousStatus := transfer.Status
+	result := tx.Model(&models.Transfer{}).
+		Where("id = ? AND version = ?", transfer.ID, transfer.Version).
+		Updates(map[string]interface{}{
+			"status":  change.Status,
+			"version": gorm.Expr("version + 1"),
+		})
 	if result.Error != nil {
 		return result.Error
 	}
-
 	if result.RowsAffected == 0 {
-		return errors.New("transfer not found")
+		return apperrors.New(apperrors.ErrConflict, apperrors.CodeConflict, "transfer was modified concurrently")
+	}
+
+	history := models.TransferStatusHistory{
+		TransferID: transfer.TransferID,
+		FromStatus: previousStatus,
+		ToStatus:   change.Status,
+		Source:     change.Source.String(),
+		Actor:      change.Actor,
+		Reason:     change.Reason,
+	}
+	if err := tx.Create(&history).Error; err != nil {
+		return err
+	}
+
+	switch {
+	case change.Status == enums.COMPLETED.String():
+		if err := settleHold(tx, transfer.TransferID, enums.HoldCaptured); err != nil {
+			return err
+		}
+		return postLedgerEntries(tx, transfer, transfer.FromAccount, transfer.ToAccount)
+	case change.Status == enums.REVERSED.String():
+		// Only the part not refunded yet is still to be given back.
+		remainder := transfer
+		remainder.Amount = transfer.Amount.Sub(transfer.RefundedAmount)
+		if err := r.checkFunds(tx, transfer.ToAccount, transfer.Currency, remainder.Amount); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Transfer{}).Where("id = ?", transfer.ID).Update("refunded_amount", transfer.Amount).Error; err != nil {
+			return err
+		}
+		return postLedgerEntries(tx, remainder, transfer.ToAccount, transfer.FromAccount)
+	case change.Status == enums.FAILED.String(), change.Status == enums.CANCELLED.String(), change.Status == enums.EXPIRED.String():
+		return settleHold(tx, transfer.TransferID, enums.HoldReleased)
+	}
+
+	return nil
+}
+
+// RefundTransfer books a refund of amount against a completed transfer as a
+// new, already completed transfer in the opposite direction, linked through
+// RefundOf. The original becomes PARTIALLY_REFUNDED, or REVERSED once its
+// refunds add up to its amount.
+func (r *GormRepository) RefundTransfer(ctx context.Context, id string, amount money.Amount, change models.StatusChange) (models.Transfer, error) {
+	var refund models.Transfer
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var original models.Transfer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("transfer_id = ?", id).First(&original).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errTransferNotFound(err)
+			}
+			return err
+		}
+		accounts, err := lockAccounts(tx, original.FromAccount, original.ToAccount)
+		if err != nil {
+			return err
+		}
+
+		if len(change.IfMatch) > 0 && !slices.Contains(change.IfMatch, original.Version) {
+			return NewVersionMismatchError(original.Version)
+		}
+		if !enums.TransactionStatus(original.Status).IsRefundable() {
+			return NewNotRefundableError(original.Status)
+		}
+		remaining := original.Amount.Sub(original.RefundedAmount)
+		if remaining.Sub(amount).IsNegative() {
+			return NewRefundExceedsError(remaining, original.Currency)
+		}
+
+		// The payee of the original pays the refund.
+		payer, payee := original.ToAccount, original.FromAccount
+		for _, account := range []string{payer, payee} {
+			if err := checkCanTransact(accounts[account], original.Currency); err != nil {
+				return err
+			}
+		}
+		if err := r.checkFunds(tx, payer, original.Currency, amount); err != nil {
+			return err
+		}
+
+		refund = models.Transfer{
+			TransferID:  generateUUID(),
+			FromAccount: payer,
+			ToAccount:   payee,
+			Amount:      amount,
+			Currency:    original.Currency,
+			Status:      enums.COMPLETED.String(),
+			Version:     1,
+			RefundOf:    original.TransferID,
+		}
+		if err := tx.Create(&refund).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.TransferStatusHistory{
+			TransferID: refund.TransferID,
+			ToStatus:   refund.Status,
+			Source:     change.Source.String(),
+			Actor:      change.Actor,
+			Reason:     change.Reason,
+		}).Error; err != nil {
+			return err
+		}
+		if err := postLedgerEntries(tx, refund, payer, payee); err != nil {
+			return err
+		}
+
+		refunded := original.RefundedAmount.Add(amount)
+		status := enums.PARTIALLY_REFUNDED.String()
+		if refunded == original.Amount {
+			status = enums.REVERSED.String()
+		}
+		result := tx.Model(&models.Transfer{}).
+			Where("id = ? AND version = ?", original.ID, original.Version).
+			Updates(map[string]interface{}{
+				"status":          status,
+				"refunded_amount": refunded,
+				"version":         gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.New(apperrors.ErrConflict, apperrors.CodeConflict, "transfer was modified concurrently")
+		}
+
+		return tx.Create(&models.TransferStatusHistory{
+			TransferID: original.TransferID,
+			FromStatus: original.Status,
+			ToStatus:   status,
+			Source:     change.Source.String(),
+			Actor:      change.Actor,
+			Reason:     change.Reason,
+		}).Error
+	})
+	if err != nil {
+		return models.Transfer{}, err
+	}
+
+	return refund, nil
+}
+
+// ScheduleTransfer stores a future-dated transfer as SCHEDULED. Accounts are
+// checked now so obvious mistakes surface early, but funds are only reserved
+// when the transfer is released.
+func (r *GormRepository) ScheduleTransfer(ctx context.Context, from, to string, amount money.Amount, currency string, executeAt time.Time) (string, error) {
+	transfer := models.Transfer{
+		TransferID:  generateUUID(),
+		FromAccount: from,
+		ToAccount:   to,
+		Amount:      amount,
+		Currency:    currency,
+		Status:      enums.SCHEDULED.String(),
+		Version:     1,
+		ExecuteAt:   &executeAt,
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		accounts, err := lockAccounts(tx, from, to)
+		if err != nil {
+			return err
+		}
+		for _, id := range []string{from, to} {
+			if err := checkCanTransact(accounts[id], currency); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(&transfer).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.TransferStatusHistory{
+			TransferID: transfer.TransferID,
+			ToStatus:   transfer.Status,
+			Source:     enums.SourceAPI.String(),
+		}).Error
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return transfer.TransferID, nil
+}
+
+// ReleaseScheduledTransfer moves a due SCHEDULED transfer to PENDING and
+// reserves its funds. It reports false, without error, when the transfer was
+// cancelled, already released or postponed past now.
+func (r *GormRepository) ReleaseScheduledTransfer(ctx context.Context, id string, now time.Time) (bool, error) {
+	released := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var transfer models.Transfer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("transfer_id = ?", id).First(&transfer).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errTransferNotFound(err)
+			}
+			return err
+		}
+		if transfer.Status != enums.SCHEDULED.String() || transfer.ExecuteAt == nil || transfer.ExecuteAt.After(now) {
+			return nil
+		}
+
+		if err := r.reserveFunds(tx, transfer); err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.Transfer{}).
+			Where("id = ? AND version = ?", transfer.ID, transfer.Version).
+			Updates(map[string]interface{}{
+				"status":  enums.PENDING.String(),
+				"version": gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.New(apperrors.ErrConflict, apperrors.CodeConflict, "transfer was modified concurrently")
+		}
+
+		released = true
+		return tx.Create(&models.TransferStatusHistory{
+			TransferID: transfer.TransferID,
+			FromStatus: transfer.Status,
+			ToStatus:   enums.PENDING.String(),
+			Source:     enums.SourceScheduler.String(),
+		}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return released, nil
+}
+
+// UpdateScheduledTransfer applies edit to a transfer that has not run yet.
+func (r *GormRepository) UpdateScheduledTransfer(ctx context.Context, id string, edit models.ScheduledTransferEdit) (models.Transfer, error) {
+	var transfer models.Transfer
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("transfer_id = ?", id).First(&transfer).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errTransferNotFound(err)
+			}
+			return err
+		}
+
+		if len(edit.IfMatch) > 0 && !slices.Contains(edit.IfMatch, transfer.Version) {
+			return NewVersionMismatchError(transfer.Version)
+		}
+		if transfer.Status != enums.SCHEDULED.String() {
+			return NewNotEditableError(transfer.Status)
+		}
+
+		updates := map[string]interface{}{"version": gorm.Expr("version + 1")}
+		if edit.ToAccount != nil {
+			accounts, err := lockAccounts(tx, *edit.ToAccount)
+			if err != nil {
+				return err
+			}
+			if err := checkCanTransact(accounts[*edit.ToAccount], transfer.Currency); err != nil {
+				return err
+			}
+			updates["to_account"] = *edit.ToAccount
+			transfer.ToAccount = *edit.ToAccount
+		}
+		if edit.Amount != nil {
+			updates["amount"] = *edit.Amount
+			transfer.Amount = *edit.Amount
+		}
+		if edit.ExecuteAt != nil {
+			updates["execute_at"] = *edit.ExecuteAt
+			transfer.ExecuteAt = edit.ExecuteAt
+		}
+
+		result := tx.Model(&models.Transfer{}).Where("id = ? AND version = ?", transfer.ID, transfer.Version).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.New(apperrors.ErrConflict, apperrors.CodeConflict, "transfer was modified concurrently")
+		}
+		transfer.Version++
+		return nil
+	})
+	if err != nil {
+		return models.Transfer{}, err
+	}
+
+	return transfer, nil
+}
+
+// ListScheduledTransfers returns transfers still waiting to run, soonest
+// first, optionally restricted to those touching one of accounts.
+func (r *GormRepository) ListScheduledTransfers(ctx context.Context, accounts []string, limit int) ([]models.Transfer, error) {
+	query := r.db.WithContext(ctx).Model(&models.Transfer{}).Where("status = ?", enums.SCHEDULED.String())
+	if len(accounts) > 0 {
+		query = query.Where("from_account IN ? OR to_account IN ?", accounts, accounts)
+	}
+
+	transfers := []models.Transfer{}
+	if err := query.Order("execute_at").Order("id").Limit(limit).Find(&transfers).Error; err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+func (r *GormRepository) GetTransferHistory(ctx context.Context, id string) ([]models.TransferStatusHistory, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Transfer{}).Where("transfer_id = ?", id).Count(&count).Error; err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, errTransferNotFound(nil)
+	}
+
+	history := []models.TransferStatusHistory{}
+	if err := r.db.WithContext(ctx).Where("transfer_id = ?", id).Order("id").Find(&history).Error; err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// ListTransfers returns the transfers matching filter, newest first. Rows with
+// the same created_at are ordered by id so cursors stay stable.
+func (r *GormRepository) ListTransfers(ctx context.Context, filter models.TransferFilter) ([]models.Transfer, error) {
+	query := r.db.WithContext(ctx).Model(&models.Transfer{})
+
+	if len(filter.Accounts) > 0 {
+		switch filter.AccountRole {
+		case enums.RoleSource:
+			query = query.Where("from_account IN ?", filter.Accounts)
+		case enums.RoleDestination:
+			query = query.Where("to_account IN ?", filter.Accounts)
+		default:
+			query = query.Where("(from_account IN ? OR to_account IN ?)", filter.Accounts, filter.Accounts)
+		}
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.Currency != "" {
+		query = query.Where("currency = ?", filter.Currency)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("amount >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("amount <= ?", *filter.MaxAmount)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+	if filter.After != nil {
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))",
+			filter.After.CreatedAt, filter.After.CreatedAt, filter.After.ID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	list := []models.Transfer{}
+	if err := query.Order("created_at DESC").Order("id DESC").Find(&list).Error; err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// GetStatementEntries returns the balances of an account just before from and
+// its ledger entries booked in [from, to), oldest first. Running balances are
+// left for the caller to compute.
+func (r *GormRepository) GetStatementEntries(ctx context.Context, id string, from, to time.Time) ([]models.Balance, []models.StatementLine, error) {
+	if err := r.accountExists(ctx, id); err != nil {
+		return nil, nil, err
+	}
+
+	opening := []models.Balance{}
+	err := r.db.WithContext(ctx).Model(&models.LedgerEntry{}).
+		Select("currency, CAST(SUM(amount) AS BIGINT) as balance").
+		Where("account_id = ? AND created_at < ?", id, from).
+		Group("currency").
+		Order("currency").
+		Scan(&opening).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lines := []models.StatementLine{}
+	err = r.db.WithContext(ctx).Model(&models.LedgerEntry{}).
+		Select(`ledger_entries.entry_id, ledger_entries.transfer_id, ledger_entries.created_at AS booked_at,
+			ledger_entries.direction, ledger_entries.currency, ledger_entries.amount,
+			CASE WHEN transfers.from_account = ledger_entries.account_id THEN transfers.to_account ELSE transfers.from_account END AS counterparty`).
+		Joins("JOIN transfers ON transfers.transfer_id = ledger_entries.transfer_id").
+		Where("ledger_entries.account_id = ? AND ledger_entries.created_at >= ? AND ledger_entries.created_at < ?", id, from, to).
+		Order("ledger_entries.created_at").
+		Order("ledger_entries.id").
+		Scan(&lines).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return opening, lines, nil
+}
+
+func (r *GormRepository) accountExists(ctx context.Context, id string) error {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Account{}).Where("account_id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return apperrors.New(apperrors.ErrNotFound, apperrors.CodeAccountNotFound, "account not found")
+	}
+	return nil
+}
+
+// NewVersionMismatchError reports that a conditional update found the
+// transfer at a different version than the caller expected.
+func NewVersionMismatchError(current uint) error {
+	return apperrors.New(apperrors.ErrPrecondition, apperrors.CodeVersionMismatch, fmt.Sprintf("transfer is at version %d", current))
+}
+
+// NewNotRefundableError reports a refund requested for a transfer that never
+// completed or was already reversed.
+func NewNotRefundableError(status string) error {
+	return apperrors.New(apperrors.ErrConflict, apperrors.CodeNotRefundable, "transfer is "+status+" and cannot be refunded")
+}
+
+// NewRefundExceedsError reports a refund larger than what is left of the
+// original amount.
+func NewRefundExceedsError(remaining money.Amount, currency string) error {
+	places, _ := money.Precision(currency)
+	return apperrors.New(apperrors.ErrConflict, apperrors.CodeRefundExceeds, fmt.Sprintf("at most %s %s can still be refunded", remaining.StringFixed(places), currency))
+}
+
+// NewNotEditableError reports an edit of a transfer that is no longer
+// scheduled.
+func NewNotEditableError(status string) error {
+	return apperrors.New(apperrors.ErrConflict, apperrors.CodeNotEditable, "transfer is "+status+" and can no longer be changed")
+}
+
+func errTransferNotFound(cause error) error {
+	if cause == nil {
+		return apperrors.New(apperrors.ErrNotFound, apperrors.CodeTransferNotFound, "transfer not found")
 	}
+	return apperrors.Wrap(cause, apperrors.ErrNotFound, apperrors.CodeTransferNotFound, "transfer not found")
+}
+
+// lockAccounts takes a row lock on every account with SELECT ... FOR UPDATE,
+// always in account_id order so two transfers between the same accounts in
+// opposite directions cannot deadlock. The lock is held until the surrounding
+// transaction ends, which makes the funds check and the hold or ledger writes
+// that follow atomic.
+//
+// SQLite has no row locks and the driver drops the clause; open it with
+// _txlock=immediate so every transaction takes the database write lock
+// up front instead.
+func lockAccounts(tx *gorm.DB, ids ...string) (map[string]models.Account, error) {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	accounts := make(map[string]models.Account, len(sorted))
+	for _, id := range slices.Compact(sorted) {
+		var account models.Account
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("account_id = ?", id).First(&account).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errAccountNotFound(err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		accounts[id] = account
+	}
+	return accounts, nil
+}
 
+// checkCanTransact rejects new transfers touching an account that is frozen,
+// closed or restricted to another currency.
+func checkCanTransact(account models.Account, currency string) error {
+	switch enums.AccountStatus(account.Status) {
+	case enums.AccountFrozen:
+		return apperrors.New(apperrors.ErrConflict, apperrors.CodeAccountFrozen, "account "+account.AccountID+" is frozen")
+	case enums.AccountClosed:
+		return apperrors.New(apperrors.ErrConflict, apperrors.CodeAccountClosed, "account "+account.AccountID+" is closed")
+	}
+	if account.Currency != "" && account.Currency != currency {
+		return apperrors.New(apperrors.ErrValidation, apperrors.CodeInvalidCurrency, fmt.Sprintf("account %s only holds %s", account.AccountID, account.Currency))
+	}
 	return nil
 }
+
+// availableBalance is the ledger balance of an account in one currency minus
+// the funds still held by its transfers in flight.
+func availableBalance(tx *gorm.DB, accountID, currency string) (money.Amount, error) {
+	var ledger, held money.Amount
+	err := tx.Model(&models.LedgerEntry{}).
+		Select("COALESCE(CAST(SUM(amount) AS BIGINT), 0)").
+		Where("account_id = ? AND currency = ?", accountID, currency).
+		Scan(&ledger).Error
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Model(&models.Hold{}).
+		Select("COALESCE(CAST(SUM(amount) AS BIGINT), 0)").
+		Where("account_id = ? AND currency = ? AND status = ?", accountID, currency, enums.HoldActive.String()).
+		Scan(&held).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return ledger.Sub(held), nil
+}
+
+// settleHold moves the active hold of a transfer to its final status.
+// Transfers created before holds existed have none, which is not an error.
+func settleHold(tx *gorm.DB, transferID string, status enums.HoldStatus) error {
+	return tx.Model(&models.Hold{}).
+		Where("transfer_id = ? AND status = ?", transferID, enums.HoldActive.String()).
+		Update("status", status.String()).Error
+}
+
+func postLedgerEntries(tx *gorm.DB, transfer models.Transfer, debitAccount, creditAccount string) error {
+	entries := []models.LedgerEntry{
+		{
+			EntryID:    generateUUID(),
+			TransferID: transfer.TransferID,
+			AccountID:  debitAccount,
+			Direction:  enums.DEBIT.String(),
+			Amount:     transfer.Amount.Neg(),
+			Currency:   transfer.Currency,
+		},
+		{
+			EntryID:    generateUUID(),
+			TransferID: transfer.TransferID,
+			AccountID:  creditAccount,
+			Direction:  enums.CREDIT.String(),
+			Amount:     transfer.Amount,
+			Currency:   transfer.Currency,
+		},
+	}
+
+	var total money.Amount
+	for _, entry := range entries {
+		total = total.Add(entry.Amount)
+	}
+	if !total.IsZero() {
+		return errors.New("unbalanced ledger entries")
+	}
+
+	return tx.Create(&entries).Error
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"secure-payment-service/internal/models"
+)
+
+type WebhookDeliveryRepository interface {
+	Record(ctx context.Context, provider, eventID string) (bool, error)
+	Forget(ctx context.Context, provider, eventID string) error
+}
+
+type GormWebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewGormWebhookDeliveryRepository(database *gorm.DB) WebhookDeliveryRepository {
+	return &GormWebhookDeliveryRepository{db: database}
+}
+
+// Record stores the delivery and reports false when the provider already sent
+// an event with the same ID.
+func (r *GormWebhookDeliveryRepository) Record(ctx context.Context, provider, eventID string) (bool, error) {
+	delivery := models.WebhookDelivery{
+		Provider: provider,
+		EventID:  eventID,
+	}
+
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&delivery)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *GormWebhookDeliveryRepository) Forget(ctx context.Context, provider, eventID string) error {
+	return r.db.WithContext(ctx).Unscoped().
+		Where("provider = ? AND event_id = ?", provider, eventID).
+		Delete(&models.WebhookDelivery{}).Error
+}
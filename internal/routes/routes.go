@@ -1,18 +1,44 @@
 package routes
 
 import (
+	"secure-payment-service/internal/auth"
 	"secure-payment-service/internal/controller"
+	"secure-payment-service/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc, transferCtrl *controller.TransferController) {
+func SetupRoutes(router *gin.Engine, authMiddleware, idempotencyMiddleware, webhookMiddleware gin.HandlerFunc, transferCtrl *controller.TransferController, accountCtrl *controller.AccountController, standingOrderCtrl *controller.StandingOrderController, batchCtrl *controller.BatchController) {
 	v1 := router.Group("/api/v1")
 	v1.Use(authMiddleware)
-	v1.POST("/transfer", transferCtrl.CreateTransfer)
-	v1.GET("/transfer/:id", transferCtrl.GetTransfer)
-	v1.GET("/account/:id/balance", transferCtrl.GetAccountBalance)
+	v1.POST("/transfer", middleware.RequireScope(auth.ScopeTransfersWrite), idempotencyMiddleware, transferCtrl.CreateTransfer)
+	v1.GET("/transfers", middleware.RequireScope(auth.ScopeTransfersRead), transferCtrl.ListTransfers)
+	v1.GET("/transfers/scheduled", middleware.RequireScope(auth.ScopeTransfersRead), transferCtrl.ListScheduledTransfers)
+	v1.POST("/transfers/batch", middleware.RequireScope(auth.ScopeTransfersWrite), idempotencyMiddleware, batchCtrl.CreateBatch)
+	v1.GET("/transfers/batch/:id", middleware.RequireScope(auth.ScopeTransfersRead), batchCtrl.GetBatch)
+	v1.GET("/transfers/batch/:id/rows", middleware.RequireScope(auth.ScopeTransfersRead), batchCtrl.ListBatchRows)
+	v1.POST("/transfers/group", middleware.RequireScope(auth.ScopeTransfersWrite), idempotencyMiddleware, transferCtrl.CreateTransferGroup)
+	v1.GET("/transfers/group/:id", middleware.RequireScope(auth.ScopeTransfersRead), transferCtrl.GetTransferGroup)
+	v1.GET("/transfer/:id", middleware.RequireScope(auth.ScopeTransfersRead), transferCtrl.GetTransfer)
+	v1.PATCH("/transfer/:id", middleware.RequireScope(auth.ScopeTransfersWrite), transferCtrl.UpdateScheduledTransfer)
+	v1.POST("/transfer/:id/cancel", middleware.RequireScope(auth.ScopeTransfersWrite), transferCtrl.CancelTransfer)
+	v1.POST("/transfer/:id/refund", middleware.RequireScope(auth.ScopeTransfersWrite), idempotencyMiddleware, transferCtrl.RefundTransfer)
+	v1.GET("/transfer/:id/history", middleware.RequireScope(auth.ScopeTransfersRead), transferCtrl.GetTransferHistory)
+	v1.POST("/standing-order", middleware.RequireScope(auth.ScopeTransfersWrite), idempotencyMiddleware, standingOrderCtrl.CreateStandingOrder)
+	v1.GET("/standing-orders", middleware.RequireScope(auth.ScopeTransfersRead), standingOrderCtrl.ListStandingOrders)
+	v1.GET("/standing-order/:id", middleware.RequireScope(auth.ScopeTransfersRead), standingOrderCtrl.GetStandingOrder)
+	v1.GET("/standing-order/:id/executions", middleware.RequireScope(auth.ScopeTransfersRead), standingOrderCtrl.ListExecutions)
+	v1.POST("/standing-order/:id/pause", middleware.RequireScope(auth.ScopeTransfersWrite), standingOrderCtrl.PauseStandingOrder)
+	v1.POST("/standing-order/:id/resume", middleware.RequireScope(auth.ScopeTransfersWrite), standingOrderCtrl.ResumeStandingOrder)
+	v1.POST("/standing-order/:id/cancel", middleware.RequireScope(auth.ScopeTransfersWrite), standingOrderCtrl.CancelStandingOrder)
+	v1.GET("/account/:id/balance", middleware.RequireScope(auth.ScopeBalancesRead), transferCtrl.GetAccountBalance)
+	v1.GET("/account/:id/statement", middleware.RequireScope(auth.ScopeBalancesRead), transferCtrl.GetAccountStatement)
+	v1.POST("/account", middleware.RequireScope(auth.ScopeAccountsWrite), idempotencyMiddleware, accountCtrl.OpenAccount)
+	v1.GET("/account/:id", middleware.RequireScope(auth.ScopeAccountsRead), accountCtrl.GetAccount)
+	v1.POST("/account/:id/freeze", middleware.RequireScope(auth.ScopeAccountsAdmin), accountCtrl.FreezeAccount)
+	v1.POST("/account/:id/unfreeze", middleware.RequireScope(auth.ScopeAccountsAdmin), accountCtrl.UnfreezeAccount)
+	v1.POST("/account/:id/close", middleware.RequireScope(auth.ScopeAccountsWrite), accountCtrl.CloseAccount)
 
-	router.POST("/api/v1/webhook", transferCtrl.UpdateTransfer)
+	router.POST("/api/v1/webhook", webhookMiddleware, transferCtrl.UpdateTransfer)
 	router.GET("/metrics", controller.PrometheusHandler())
 }
@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff computes exponential retry delays capped at Max. Jitter spreads each
+// delay by up to that fraction in either direction so retries of many jobs do
+// not line up.
+type Backoff struct {
+	Base   time.Duration
+	Max    time.Duration
+	Jitter float64
+}
+
+func (b Backoff) Delay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := b.Base
+	for i := 1; i < attempt && delay < b.Max; i++ {
+		delay *= 2
+	}
+	if b.Max > 0 && delay > b.Max {
+		delay = b.Max
+	}
+
+	if b.Jitter > 0 {
+		spread := float64(delay) * b.Jitter
+		delay += time.Duration(spread * (2*rand.Float64() - 1))
+	}
+
+	return delay
+}
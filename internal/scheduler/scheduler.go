@@ -0,0 +1,180 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"secure-payment-service/internal/config"
+	"secure-payment-service/internal/logging"
+	"secure-payment-service/internal/metrics"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/repository"
+)
+
+// Handler runs jobs of one kind. Handle reports done=false (or an error) when
+// the job should be retried later. Exhausted is called once a job has used up
+// its attempts and decides what happens to the underlying entity.
+type Handler interface {
+	Handle(ctx context.Context, job models.ScheduledJob) (bool, error)
+	Exhausted(ctx context.Context, job models.ScheduledJob) error
+}
+
+type Scheduler struct {
+	repo     repository.JobRepository
+	cfg      config.SchedulerConfig
+	backoff  Backoff
+	handlers map[string]Handler
+	queue    chan models.ScheduledJob
+	busy     atomic.Int64
+	wg       sync.WaitGroup
+	now      func() time.Time
+}
+
+func New(repo repository.JobRepository, cfg config.SchedulerConfig) *Scheduler {
+	return &Scheduler{
+		repo: repo,
+		cfg:  cfg,
+		backoff: Backoff{
+			Base:   cfg.BackoffBase,
+			Max:    cfg.BackoffMax,
+			Jitter: cfg.BackoffJitter,
+		},
+		handlers: map[string]Handler{},
+		queue:    make(chan models.ScheduledJob, cfg.Workers),
+		now:      time.Now,
+	}
+}
+
+func (s *Scheduler) Register(kind string, handler Handler) {
+	s.handlers[kind] = handler
+}
+
+// Start launches the worker pool and the polling loop. They stop when ctx is
+// cancelled; Wait blocks until in-flight jobs have finished.
+func (s *Scheduler) Start(ctx context.Context) {
+	for i := 0; i < s.cfg.Workers; i++ {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			for job := range s.queue {
+				s.process(ctx, job)
+				s.busy.Add(-1)
+			}
+		}()
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer close(s.queue)
+
+		ticker := time.NewTicker(s.cfg.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			s.poll(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) poll(ctx context.Context) {
+	free := s.cfg.Workers - int(s.busy.Load())
+	if free <= 0 {
+		return
+	}
+
+	jobs, err := s.repo.ClaimDue(ctx, s.now(), free, s.cfg.Lease)
+	if err != nil {
+		logging.Logger.WithError(err).Error("failed to claim scheduled jobs")
+	}
+
+	for _, job := range jobs {
+		s.busy.Add(1)
+		s.queue <- job
+	}
+}
+
+// RunDue claims and runs every due job synchronously. It is meant for tests
+// and one-off maintenance, the server uses Start.
+func (s *Scheduler) RunDue(ctx context.Context) (int, error) {
+	jobs, err := s.repo.ClaimDue(ctx, s.now(), s.cfg.Workers, s.cfg.Lease)
+	for _, job := range jobs {
+		s.process(ctx, job)
+	}
+	return len(jobs), err
+}
+
+func (s *Scheduler) process(ctx context.Context, job models.ScheduledJob) {
+	log := logging.Logger.WithFields(logrus.Fields{
+		"job_id":    job.JobID,
+		"kind":      job.Kind,
+		"reference": job.Reference,
+	})
+
+	// Outcomes are persisted even when shutdown cancels ctx mid-job, otherwise
+	// the job would sit in RUNNING until its lease expires.
+	persistCtx := context.WithoutCancel(ctx)
+
+	handler, ok := s.handlers[job.Kind]
+	if !ok {
+		log.Error("no handler registered for scheduled job")
+		s.record(log, job.Kind, "unknown_kind", s.repo.Fail(persistCtx, job.JobID, job.Attempts, "no handler registered"))
+		return
+	}
+
+	// A handler must not outlive its lease, or another worker may pick the
+	// job up while it is still running.
+	jobCtx, cancel := context.WithTimeout(ctx, s.cfg.Lease)
+	defer cancel()
+
+	job.Attempts++
+	done, err := handler.Handle(jobCtx, job)
+	if err == nil && done {
+		s.record(log, job.Kind, "done", s.repo.Complete(persistCtx, job.JobID, job.Attempts))
+		return
+	}
+
+	lastError := ""
+	if err != nil {
+		lastError = err.Error()
+		log = log.WithError(err)
+	}
+
+	maxAttempts := job.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = s.cfg.MaxAttempts
+	}
+
+	if job.Attempts >= maxAttempts {
+		log.WithField("attempts", job.Attempts).Warn("scheduled job reached max attempts")
+		if exhaustedErr := handler.Exhausted(jobCtx, job); exhaustedErr != nil {
+			log.WithError(exhaustedErr).Error("max attempts action failed")
+			lastError = exhaustedErr.Error()
+		}
+		s.record(log, job.Kind, "exhausted", s.repo.Fail(persistCtx, job.JobID, job.Attempts, lastError))
+		return
+	}
+
+	nextRunAt := s.now().Add(s.backoff.Delay(job.Attempts))
+	s.record(log, job.Kind, "retry", s.repo.Reschedule(persistCtx, job.JobID, job.Attempts, nextRunAt, lastError))
+}
+
+func (s *Scheduler) record(log *logrus.Entry, kind, result string, err error) {
+	metrics.ScheduledJobsTotal.WithLabelValues(kind, result).Inc()
+	if err != nil {
+		log.WithError(err).Error("failed to persist scheduled job outcome")
+	}
+}
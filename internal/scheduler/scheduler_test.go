@@ -0,0 +1,194 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"secure-payment-service/internal/config"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/repository"
+	"secure-payment-service/internal/scheduler"
+)
+
+type fakeHandler struct {
+	mu          sync.Mutex
+	results     []bool
+	err         error
+	calls       int
+	exhausted   []string
+	running     int
+	maxRunning  int
+	handleDelay time.Duration
+}
+
+func (h *fakeHandler) Handle(_ context.Context, _ models.ScheduledJob) (bool, error) {
+	h.mu.Lock()
+	h.running++
+	if h.running > h.maxRunning {
+		h.maxRunning = h.running
+	}
+	done := true
+	if h.calls < len(h.results) {
+		done = h.results[h.calls]
+	}
+	h.calls++
+	h.mu.Unlock()
+
+	time.Sleep(h.handleDelay)
+
+	h.mu.Lock()
+	h.running--
+	h.mu.Unlock()
+	return done, h.err
+}
+
+func (h *fakeHandler) Exhausted(_ context.Context, job models.ScheduledJob) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.exhausted = append(h.exhausted, job.Reference)
+	return nil
+}
+
+func setupScheduler(t *testing.T, workers int) (*gorm.DB, repository.JobRepository, *scheduler.Scheduler) {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&models.ScheduledJob{}))
+
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	repo := repository.NewGormJobRepository(db)
+	sched := scheduler.New(repo, config.SchedulerConfig{
+		Workers:      workers,
+		PollInterval: 10 * time.Millisecond,
+		Lease:        time.Minute,
+		MaxAttempts:  3,
+		BackoffBase:  time.Millisecond,
+		BackoffMax:   time.Millisecond,
+	})
+	return db, repo, sched
+}
+
+func findJob(t *testing.T, db *gorm.DB, jobID string) models.ScheduledJob {
+	var job models.ScheduledJob
+	assert.NoError(t, db.Where("job_id = ?", jobID).First(&job).Error)
+	return job
+}
+
+func TestScheduler_CompletesJobs(t *testing.T) {
+	db, repo, sched := setupScheduler(t, 2)
+	handler := &fakeHandler{}
+	sched.Register("test", handler)
+
+	jobID, err := repo.Enqueue(context.Background(), "test", "ref-1", time.Now().Add(-time.Second))
+	assert.NoError(t, err)
+
+	ran, err := sched.RunDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ran)
+
+	job := findJob(t, db, jobID)
+	assert.Equal(t, enums.JobDone.String(), job.Status)
+	assert.Equal(t, 1, job.Attempts)
+}
+
+func TestScheduler_RetriesWithBackoff(t *testing.T) {
+	db, repo, sched := setupScheduler(t, 1)
+	handler := &fakeHandler{results: []bool{false}}
+	sched.Register("test", handler)
+
+	jobID, err := repo.Enqueue(context.Background(), "test", "ref-1", time.Now().Add(-time.Second))
+	assert.NoError(t, err)
+
+	_, err = sched.RunDue(context.Background())
+	assert.NoError(t, err)
+
+	job := findJob(t, db, jobID)
+	assert.Equal(t, enums.JobPending.String(), job.Status)
+	assert.Equal(t, 1, job.Attempts)
+	assert.True(t, job.NextRunAt.After(time.Now().Add(-time.Second)))
+}
+
+func TestScheduler_RunsMaxAttemptsActionWhenExhausted(t *testing.T) {
+	db, repo, sched := setupScheduler(t, 1)
+	handler := &fakeHandler{results: []bool{false, false, false}, err: errors.New("provider unavailable")}
+	sched.Register("test", handler)
+
+	jobID, err := repo.Enqueue(context.Background(), "test", "ref-exhausted", time.Now().Add(-time.Second))
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		time.Sleep(5 * time.Millisecond)
+		_, err = sched.RunDue(context.Background())
+		assert.NoError(t, err)
+	}
+
+	job := findJob(t, db, jobID)
+	assert.Equal(t, enums.JobFailed.String(), job.Status)
+	assert.Equal(t, 3, job.Attempts)
+	assert.Equal(t, "provider unavailable", job.LastError)
+	assert.Equal(t, []string{"ref-exhausted"}, handler.exhausted)
+}
+
+func TestScheduler_FailsJobsWithoutHandler(t *testing.T) {
+	db, repo, sched := setupScheduler(t, 1)
+
+	jobID, err := repo.Enqueue(context.Background(), "unknown", "ref-1", time.Now().Add(-time.Second))
+	assert.NoError(t, err)
+
+	_, err = sched.RunDue(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, enums.JobFailed.String(), findJob(t, db, jobID).Status)
+}
+
+func TestScheduler_BoundsConcurrentWorkers(t *testing.T) {
+	db, repo, sched := setupScheduler(t, 2)
+	handler := &fakeHandler{handleDelay: 20 * time.Millisecond}
+	sched.Register("test", handler)
+
+	for i := 0; i < 6; i++ {
+		_, err := repo.Enqueue(context.Background(), "test", "ref", time.Now().Add(-time.Second))
+		assert.NoError(t, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sched.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		var pending int64
+		db.Model(&models.ScheduledJob{}).Where("status <> ?", enums.JobDone.String()).Count(&pending)
+		return pending == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	sched.Wait()
+
+	assert.Equal(t, 6, handler.calls)
+	assert.LessOrEqual(t, handler.maxRunning, 2)
+}
+
+func TestBackoff_Delay(t *testing.T) {
+	backoff := scheduler.Backoff{Base: time.Second, Max: 10 * time.Second}
+
+	assert.Equal(t, time.Second, backoff.Delay(1))
+	assert.Equal(t, 2*time.Second, backoff.Delay(2))
+	assert.Equal(t, 4*time.Second, backoff.Delay(3))
+	assert.Equal(t, 10*time.Second, backoff.Delay(10))
+
+	jittered := scheduler.Backoff{Base: time.Second, Max: time.Minute, Jitter: 0.5}
+	for i := 0; i < 50; i++ {
+		delay := jittered.Delay(1)
+		assert.GreaterOrEqual(t, delay, 500*time.Millisecond)
+		assert.LessOrEqual(t, delay, 1500*time.Millisecond)
+	}
+}
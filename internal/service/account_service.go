@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/metrics"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/repository"
+	"secure-payment-service/internal/transfers"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	OpenAccount         = "open_account"
+	GetAccount          = "get_account"
+	UpdateAccountStatus = "update_account_status"
+)
+
+type AccountService interface {
+	OpenAccount(ctx context.Context, req transfers.OpenAccountRequest) (models.Account, error)
+	GetAccount(ctx context.Context, id string) (models.Account, error)
+	FreezeAccount(ctx context.Context, id string) (models.Account, error)
+	UnfreezeAccount(ctx context.Context, id string) (models.Account, error)
+	CloseAccount(ctx context.Context, id string) (models.Account, error)
+}
+
+type AccountServiceImpl struct {
+	repo repository.AccountRepository
+}
+
+func NewAccountService(repo repository.AccountRepository) AccountService {
+	return &AccountServiceImpl{repo: repo}
+}
+
+func (s *AccountServiceImpl) OpenAccount(ctx context.Context, req transfers.OpenAccountRequest) (models.Account, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(OpenAccount, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	if err := req.Validate(); err != nil {
+		metrics.ServiceOperationsTotal.WithLabelValues(OpenAccount, StatusFailure).Inc()
+		return models.Account{}, err
+	}
+
+	account := models.Account{
+		AccountID: req.AccountID,
+		Owner:     strings.TrimSpace(req.Owner),
+		Currency:  strings.ToUpper(req.Currency),
+		Metadata:  req.Metadata,
+	}
+	if account.AccountID == "" {
+		account.AccountID = "acc-" + uuid.New().String()
+	}
+
+	account, err := s.repo.CreateAccount(ctx, account)
+	if err != nil {
+		statusLabel := StatusFailure
+		if errors.Is(err, apperrors.ErrConflict) {
+			statusLabel = StatusConflict
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(OpenAccount, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(OpenAccount, statusLabel).Observe(0)
+		return models.Account{}, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(OpenAccount, StatusSuccess).Inc()
+	return account, nil
+}
+
+func (s *AccountServiceImpl) GetAccount(ctx context.Context, id string) (models.Account, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(GetAccount, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	account, err := s.repo.GetAccount(ctx, id)
+	if err != nil {
+		statusLabel := StatusFailure
+		if errors.Is(err, apperrors.ErrNotFound) {
+			statusLabel = StatusNotFound
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(GetAccount, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(GetAccount, statusLabel).Observe(0)
+		return models.Account{}, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(GetAccount, StatusSuccess).Inc()
+	return account, nil
+}
+
+func (s *AccountServiceImpl) FreezeAccount(ctx context.Context, id string) (models.Account, error) {
+	return s.updateStatus(ctx, id, enums.AccountFrozen)
+}
+
+func (s *AccountServiceImpl) UnfreezeAccount(ctx context.Context, id string) (models.Account, error) {
+	return s.updateStatus(ctx, id, enums.AccountActive)
+}
+
+func (s *AccountServiceImpl) CloseAccount(ctx context.Context, id string) (models.Account, error) {
+	return s.updateStatus(ctx, id, enums.AccountClosed)
+}
+
+func (s *AccountServiceImpl) updateStatus(ctx context.Context, id string, status enums.AccountStatus) (models.Account, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(UpdateAccountStatus, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	account, err := s.repo.UpdateAccountStatus(ctx, id, status)
+	if err != nil {
+		statusLabel := StatusFailure
+		switch {
+		case errors.Is(err, apperrors.ErrInvalidTransition), errors.Is(err, apperrors.ErrConflict):
+			statusLabel = StatusConflict
+		case errors.Is(err, apperrors.ErrNotFound):
+			statusLabel = StatusNotFound
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(UpdateAccountStatus, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(UpdateAccountStatus, statusLabel).Observe(0)
+		return models.Account{}, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(UpdateAccountStatus, StatusSuccess).Inc()
+	return account, nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/logging"
+	"secure-payment-service/internal/models"
+)
+
+const ProcessBatchJob = "transfer_batch"
+
+// BatchExecutor is the scheduled job handler that creates the transfers of a
+// bulk upload.
+type BatchExecutor struct {
+	batchService BatchService
+}
+
+func NewBatchExecutor(svc BatchService) *BatchExecutor {
+	return &BatchExecutor{batchService: svc}
+}
+
+func (e *BatchExecutor) Handle(ctx context.Context, job models.ScheduledJob) (bool, error) {
+	err := e.batchService.ProcessBatch(ctx, job.Reference)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		// The batch failed to save after its job was enqueued.
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Exhausted gives up on the rows left, so the batch does not stay PROCESSING
+// forever and its owner sees which rows to submit again.
+func (e *BatchExecutor) Exhausted(ctx context.Context, job models.ScheduledJob) error {
+	logging.Logger.WithFields(logrus.Fields{
+		"batch_id": job.Reference,
+		"attempts": job.Attempts,
+	}).Warn("batch could not be processed, failing its remaining rows")
+
+	return e.batchService.AbandonBatch(ctx, job.Reference)
+}
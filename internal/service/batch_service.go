@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/logging"
+	"secure-payment-service/internal/metrics"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/repository"
+	"secure-payment-service/internal/transfers"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	CreateBatch   = "create_batch"
+	GetBatch      = "get_batch"
+	ListBatchRows = "list_batch_rows"
+	ProcessBatch  = "process_batch"
+	AbandonBatch  = "abandon_batch"
+)
+
+// batchChunkSize is how many rows ProcessBatch loads and hands to its
+// workers at a time.
+const batchChunkSize = 100
+
+const (
+	interruptedRowError = "creation was interrupted; check the account's transfers before submitting this row again"
+	abandonedRowError   = "could not be processed after repeated errors; submit this row again"
+)
+
+type BatchService interface {
+	CreateBatch(ctx context.Context, format string, rows []transfers.BatchRow, createdBy string) (models.TransferBatch, error)
+	GetBatch(ctx context.Context, id string) (models.TransferBatch, error)
+	ListBatchRows(ctx context.Context, id string, status enums.BatchRowStatus, afterLine, limit int) ([]models.TransferBatchRow, error)
+	ProcessBatch(ctx context.Context, id string) error
+	AbandonBatch(ctx context.Context, id string) error
+}
+
+// BatchServiceImpl stores bulk uploads and creates their transfers in the
+// background, through the TransferService like any other client would, with
+// at most workers transfers of a batch in flight at once.
+type BatchServiceImpl struct {
+	repo            repository.BatchRepository
+	jobs            repository.JobRepository
+	transferService TransferService
+	workers         int
+}
+
+func NewBatchService(repo repository.BatchRepository, jobs repository.JobRepository, transferService TransferService, workers int) BatchService {
+	return &BatchServiceImpl{repo: repo, jobs: jobs, transferService: transferService, workers: workers}
+}
+
+// CreateBatch stores the parsed rows of an upload. Rows that failed to parse
+// or validate are FAILED from the start; the others are left to the
+// ProcessBatchJob.
+func (s *BatchServiceImpl) CreateBatch(ctx context.Context, format string, rows []transfers.BatchRow, createdBy string) (models.TransferBatch, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(CreateBatch, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	batch, err := s.create(ctx, format, rows, createdBy)
+	if err != nil {
+		metrics.ServiceOperationsTotal.WithLabelValues(CreateBatch, StatusFailure).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(CreateBatch, StatusFailure).Observe(0)
+		return models.TransferBatch{}, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(CreateBatch, StatusSuccess).Inc()
+	return batch, nil
+}
+
+func (s *BatchServiceImpl) create(ctx context.Context, format string, rows []transfers.BatchRow, createdBy string) (models.TransferBatch, error) {
+	batch := models.TransferBatch{
+		BatchID:   uuid.New().String(),
+		Format:    format,
+		CreatedBy: createdBy,
+	}
+
+	stored := make([]models.TransferBatchRow, len(rows))
+	pending := false
+	for i, row := range rows {
+		stored[i] = models.TransferBatchRow{
+			Line:        row.Line,
+			FromAccount: row.Request.FromAccount,
+			ToAccount:   row.Request.ToAccount,
+			Amount:      row.Request.Amount,
+			Currency:    row.Request.Currency,
+			ExecuteAt:   row.Request.ExecuteAt,
+		}
+		if row.Err != nil {
+			failRow(&stored[i], row.Err)
+		} else {
+			pending = true
+		}
+	}
+
+	// The job goes first so the batch is never stored without one. If the
+	// batch then fails to save, the job finds nothing to process.
+	if pending {
+		if _, err := s.jobs.Enqueue(ctx, ProcessBatchJob, batch.BatchID, time.Now()); err != nil {
+			return models.TransferBatch{}, err
+		}
+	}
+
+	return s.repo.CreateBatch(ctx, batch, stored)
+}
+
+func (s *BatchServiceImpl) GetBatch(ctx context.Context, id string) (models.TransferBatch, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(GetBatch, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	batch, err := s.repo.GetBatch(ctx, id)
+	if err != nil {
+		statusLabel := StatusFailure
+		if errors.Is(err, apperrors.ErrNotFound) {
+			statusLabel = StatusNotFound
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(GetBatch, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(GetBatch, statusLabel).Observe(0)
+		return models.TransferBatch{}, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(GetBatch, StatusSuccess).Inc()
+	return batch, nil
+}
+
+func (s *BatchServiceImpl) ListBatchRows(ctx context.Context, id string, status enums.BatchRowStatus, afterLine, limit int) ([]models.TransferBatchRow, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(ListBatchRows, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	rows, err := s.repo.ListBatchRows(ctx, id, status, afterLine, limit)
+	if err != nil {
+		metrics.ServiceOperationsTotal.WithLabelValues(ListBatchRows, StatusFailure).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(ListBatchRows, StatusFailure).Observe(0)
+		return nil, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(ListBatchRows, StatusSuccess).Inc()
+	return rows, nil
+}
+
+// ProcessBatch creates the transfers of the PENDING rows of a batch, chunk by
+// chunk. Once half the time left on ctx is used up it enqueues another job
+// for the remaining rows, so large batches never outlive a job's lease.
+// Returning an error retries the whole step, which picks up where the
+// previous attempt stopped.
+func (s *BatchServiceImpl) ProcessBatch(ctx context.Context, id string) error {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(ProcessBatch, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	err := s.process(ctx, id)
+	if err != nil {
+		statusLabel := StatusFailure
+		if errors.Is(err, apperrors.ErrNotFound) {
+			statusLabel = StatusNotFound
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(ProcessBatch, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(ProcessBatch, statusLabel).Observe(0)
+		return err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(ProcessBatch, StatusSuccess).Inc()
+	return nil
+}
+
+func (s *BatchServiceImpl) process(ctx context.Context, id string) error {
+	batch, err := s.repo.GetBatch(ctx, id)
+	if err != nil {
+		return err
+	}
+	if batch.Status != enums.BatchProcessing.String() {
+		return nil
+	}
+
+	log := logging.Logger.WithField("batch_id", id)
+
+	// Rows still RUNNING were left by an attempt that stopped between
+	// claiming them and recording their transfer. The transfer may exist, so
+	// they are not created again.
+	interrupted, err := s.repo.FailBatchRows(ctx, id, enums.BatchRowRunning, interruptedRowError)
+	if err != nil {
+		return err
+	}
+	if interrupted > 0 {
+		log.WithField("rows", interrupted).Warn("batch rows were interrupted, not retrying them")
+	}
+
+	var stopAt time.Time
+	if deadline, ok := ctx.Deadline(); ok {
+		stopAt = time.Now().Add(time.Until(deadline) / 2)
+	}
+
+	for {
+		rows, err := s.repo.PendingBatchRows(ctx, id, batchChunkSize)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return s.repo.CompleteBatch(ctx, id)
+		}
+
+		if err := s.createTransfers(ctx, rows); err != nil {
+			return err
+		}
+
+		if !stopAt.IsZero() && time.Now().After(stopAt) {
+			_, err := s.jobs.Enqueue(ctx, ProcessBatchJob, id, time.Now())
+			return err
+		}
+	}
+}
+
+// createTransfers creates the transfers of rows on at most s.workers
+// goroutines. After the first error no further row is started, and that
+// error is returned once the rows in flight are done.
+func (s *BatchServiceImpl) createTransfers(ctx context.Context, rows []models.TransferBatchRow) error {
+	work := make(chan models.TransferBatchRow)
+	failures := make(chan error, len(rows))
+
+	var wg sync.WaitGroup
+	for range min(s.workers, len(rows)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for row := range work {
+				if err := s.createTransfer(ctx, row); err != nil {
+					failures <- err
+				}
+			}
+		}()
+	}
+
+	for _, row := range rows {
+		if len(failures) > 0 || ctx.Err() != nil {
+			break
+		}
+		work <- row
+	}
+	close(work)
+	wg.Wait()
+	close(failures)
+
+	if err := <-failures; err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// createTransfer creates the transfer of one row. Failures that retrying
+// cannot fix, like an invalid row or missing funds, are recorded on the row;
+// anything else releases it so it is retried.
+func (s *BatchServiceImpl) createTransfer(ctx context.Context, row models.TransferBatchRow) error {
+	started, err := s.repo.StartBatchRow(ctx, row)
+	if err != nil || !started {
+		return err
+	}
+
+	transferID, err := s.transferService.CreateTransfer(ctx, transfers.TransferRequest{
+		FromAccount: row.FromAccount,
+		ToAccount:   row.ToAccount,
+		Amount:      row.Amount,
+		Currency:    row.Currency,
+		ExecuteAt:   row.ExecuteAt,
+	})
+	switch {
+	case err == nil:
+		row.Status = enums.BatchRowCreated.String()
+		row.TransferID = transferID
+	case errors.Is(err, apperrors.ErrValidation), errors.Is(err, apperrors.ErrConflict),
+		errors.Is(err, apperrors.ErrInsufficientFunds), errors.Is(err, apperrors.ErrNotFound):
+		failRow(&row, err)
+	default:
+		if releaseErr := s.repo.ReleaseBatchRow(context.WithoutCancel(ctx), row); releaseErr != nil {
+			logging.Logger.WithError(releaseErr).WithFields(logrus.Fields{
+				"batch_id": row.BatchID,
+				"line":     row.Line,
+			}).Error("failed to release batch row")
+		}
+		return err
+	}
+
+	return s.repo.FinishBatchRow(context.WithoutCancel(ctx), row)
+}
+
+// AbandonBatch fails every row of a batch that has no outcome yet, so the
+// batch completes and its owner can submit those rows again.
+func (s *BatchServiceImpl) AbandonBatch(ctx context.Context, id string) error {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(AbandonBatch, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	err := s.abandon(ctx, id)
+	if err != nil {
+		metrics.ServiceOperationsTotal.WithLabelValues(AbandonBatch, StatusFailure).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(AbandonBatch, StatusFailure).Observe(0)
+		return err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(AbandonBatch, StatusSuccess).Inc()
+	return nil
+}
+
+func (s *BatchServiceImpl) abandon(ctx context.Context, id string) error {
+	if _, err := s.repo.FailBatchRows(ctx, id, enums.BatchRowRunning, interruptedRowError); err != nil {
+		return err
+	}
+	if _, err := s.repo.FailBatchRows(ctx, id, enums.BatchRowPending, abandonedRowError); err != nil {
+		return err
+	}
+	return s.repo.CompleteBatch(ctx, id)
+}
+
+// failRow records err as the outcome of row, with the invalid fields when err
+// is an *apperrors.ValidationError.
+func failRow(row *models.TransferBatchRow, err error) {
+	row.Status = enums.BatchRowFailed.String()
+	row.ErrorCode = apperrors.Code(err, apperrors.CodeInternal)
+	row.Error = err.Error()
+
+	var invalid *apperrors.ValidationError
+	if errors.As(err, &invalid) {
+		row.Fields = invalid.Fields
+	}
+}
@@ -4,7 +4,12 @@
 package service
 
 import (
+	"context"
+	"time"
+
+	"secure-payment-service/internal/enums"
 	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/money"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -37,8 +42,8 @@ func (_m *MockTransferRepository) EXPECT() *MockTransferRepository_Expecter {
 }
 
 // CreateTransfer provides a mock function for the type MockTransferRepository
-func (_mock *MockTransferRepository) CreateTransfer(from string, to string, amount float64) (string, error) {
-	ret := _mock.Called(from, to, amount)
+func (_mock *MockTransferRepository) CreateTransfer(ctx context.Context, from string, to string, amount money.Amount, currency string) (string, error) {
+	ret := _mock.Called(ctx, from, to, amount, currency)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateTransfer")
@@ -46,16 +51,16 @@ func (_mock *MockTransferRepository) CreateTransfer(from string, to string, amou
 
 	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, string, float64) (string, error)); ok {
-		return returnFunc(from, to, amount)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, money.Amount, string) (string, error)); ok {
+		return returnFunc(ctx, from, to, amount, currency)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, string, float64) string); ok {
-		r0 = returnFunc(from, to, amount)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, money.Amount, string) string); ok {
+		r0 = returnFunc(ctx, from, to, amount, currency)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, string, float64) error); ok {
-		r1 = returnFunc(from, to, amount)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, money.Amount, string) error); ok {
+		r1 = returnFunc(ctx, from, to, amount, currency)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -68,31 +73,43 @@ type MockTransferRepository_CreateTransfer_Call struct {
 }
 
 // CreateTransfer is a helper method to define mock.On call
+//   - ctx context.Context
 //   - from string
 //   - to string
-//   - amount float64
-func (_e *MockTransferRepository_Expecter) CreateTransfer(from interface{}, to interface{}, amount interface{}) *MockTransferRepository_CreateTransfer_Call {
-	return &MockTransferRepository_CreateTransfer_Call{Call: _e.mock.On("CreateTransfer", from, to, amount)}
+//   - amount money.Amount
+//   - currency string
+func (_e *MockTransferRepository_Expecter) CreateTransfer(ctx interface{}, from interface{}, to interface{}, amount interface{}, currency interface{}) *MockTransferRepository_CreateTransfer_Call {
+	return &MockTransferRepository_CreateTransfer_Call{Call: _e.mock.On("CreateTransfer", ctx, from, to, amount, currency)}
 }
 
-func (_c *MockTransferRepository_CreateTransfer_Call) Run(run func(from string, to string, amount float64)) *MockTransferRepository_CreateTransfer_Call {
+func (_c *MockTransferRepository_CreateTransfer_Call) Run(run func(ctx context.Context, from string, to string, amount money.Amount, currency string)) *MockTransferRepository_CreateTransfer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
 		}
 		var arg1 string
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 float64
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(float64)
+			arg2 = args[2].(string)
+		}
+		var arg3 money.Amount
+		if args[3] != nil {
+			arg3 = args[3].(money.Amount)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
@@ -103,31 +120,113 @@ func (_c *MockTransferRepository_CreateTransfer_Call) Return(s string, err error
 	return _c
 }
 
-func (_c *MockTransferRepository_CreateTransfer_Call) RunAndReturn(run func(from string, to string, amount float64) (string, error)) *MockTransferRepository_CreateTransfer_Call {
+func (_c *MockTransferRepository_CreateTransfer_Call) RunAndReturn(run func(ctx context.Context, from string, to string, amount money.Amount, currency string) (string, error)) *MockTransferRepository_CreateTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTransferGroup provides a mock function for the type MockTransferRepository
+func (_mock *MockTransferRepository) CreateTransferGroup(ctx context.Context, from string, currency string, legs []models.TransferLeg) ([]models.Transfer, error) {
+	ret := _mock.Called(ctx, from, currency, legs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTransferGroup")
+	}
+
+	var r0 []models.Transfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []models.TransferLeg) ([]models.Transfer, error)); ok {
+		return returnFunc(ctx, from, currency, legs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []models.TransferLeg) []models.Transfer); ok {
+		r0 = returnFunc(ctx, from, currency, legs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Transfer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, []models.TransferLeg) error); ok {
+		r1 = returnFunc(ctx, from, currency, legs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransferRepository_CreateTransferGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTransferGroup'
+type MockTransferRepository_CreateTransferGroup_Call struct {
+	*mock.Call
+}
+
+// CreateTransferGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from string
+//   - currency string
+//   - legs []models.TransferLeg
+func (_e *MockTransferRepository_Expecter) CreateTransferGroup(ctx interface{}, from interface{}, currency interface{}, legs interface{}) *MockTransferRepository_CreateTransferGroup_Call {
+	return &MockTransferRepository_CreateTransferGroup_Call{Call: _e.mock.On("CreateTransferGroup", ctx, from, currency, legs)}
+}
+
+func (_c *MockTransferRepository_CreateTransferGroup_Call) Run(run func(ctx context.Context, from string, currency string, legs []models.TransferLeg)) *MockTransferRepository_CreateTransferGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []models.TransferLeg
+		if args[3] != nil {
+			arg3 = args[3].([]models.TransferLeg)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferRepository_CreateTransferGroup_Call) Return(transfers []models.Transfer, err error) *MockTransferRepository_CreateTransferGroup_Call {
+	_c.Call.Return(transfers, err)
+	return _c
+}
+
+func (_c *MockTransferRepository_CreateTransferGroup_Call) RunAndReturn(run func(ctx context.Context, from string, currency string, legs []models.TransferLeg) ([]models.Transfer, error)) *MockTransferRepository_CreateTransferGroup_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetAccountBalance provides a mock function for the type MockTransferRepository
-func (_mock *MockTransferRepository) GetAccountBalance(id string) (float64, error) {
-	ret := _mock.Called(id)
+func (_mock *MockTransferRepository) GetAccountBalance(ctx context.Context, id string) ([]models.AccountBalance, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAccountBalance")
 	}
 
-	var r0 float64
+	var r0 []models.AccountBalance
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (float64, error)); ok {
-		return returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]models.AccountBalance, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) float64); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []models.AccountBalance); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Get(0).(float64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccountBalance)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -140,37 +239,131 @@ type MockTransferRepository_GetAccountBalance_Call struct {
 }
 
 // GetAccountBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockTransferRepository_Expecter) GetAccountBalance(ctx interface{}, id interface{}) *MockTransferRepository_GetAccountBalance_Call {
+	return &MockTransferRepository_GetAccountBalance_Call{Call: _e.mock.On("GetAccountBalance", ctx, id)}
+}
+
+func (_c *MockTransferRepository_GetAccountBalance_Call) Run(run func(ctx context.Context, id string)) *MockTransferRepository_GetAccountBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferRepository_GetAccountBalance_Call) Return(accountBalances []models.AccountBalance, err error) *MockTransferRepository_GetAccountBalance_Call {
+	_c.Call.Return(accountBalances, err)
+	return _c
+}
+
+func (_c *MockTransferRepository_GetAccountBalance_Call) RunAndReturn(run func(ctx context.Context, id string) ([]models.AccountBalance, error)) *MockTransferRepository_GetAccountBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStatementEntries provides a mock function for the type MockTransferRepository
+func (_mock *MockTransferRepository) GetStatementEntries(ctx context.Context, id string, from time.Time, to time.Time) ([]models.Balance, []models.StatementLine, error) {
+	ret := _mock.Called(ctx, id, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatementEntries")
+	}
+
+	var r0 []models.Balance
+	var r1 []models.StatementLine
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]models.Balance, []models.StatementLine, error)); ok {
+		return returnFunc(ctx, id, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []models.Balance); ok {
+		r0 = returnFunc(ctx, id, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Balance)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) []models.StatementLine); ok {
+		r1 = returnFunc(ctx, id, from, to)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]models.StatementLine)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r2 = returnFunc(ctx, id, from, to)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockTransferRepository_GetStatementEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatementEntries'
+type MockTransferRepository_GetStatementEntries_Call struct {
+	*mock.Call
+}
+
+// GetStatementEntries is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id string
-func (_e *MockTransferRepository_Expecter) GetAccountBalance(id interface{}) *MockTransferRepository_GetAccountBalance_Call {
-	return &MockTransferRepository_GetAccountBalance_Call{Call: _e.mock.On("GetAccountBalance", id)}
+//   - from time.Time
+//   - to time.Time
+func (_e *MockTransferRepository_Expecter) GetStatementEntries(ctx interface{}, id interface{}, from interface{}, to interface{}) *MockTransferRepository_GetStatementEntries_Call {
+	return &MockTransferRepository_GetStatementEntries_Call{Call: _e.mock.On("GetStatementEntries", ctx, id, from, to)}
 }
 
-func (_c *MockTransferRepository_GetAccountBalance_Call) Run(run func(id string)) *MockTransferRepository_GetAccountBalance_Call {
+func (_c *MockTransferRepository_GetStatementEntries_Call) Run(run func(ctx context.Context, id string, from time.Time, to time.Time)) *MockTransferRepository_GetStatementEntries_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTransferRepository_GetAccountBalance_Call) Return(f float64, err error) *MockTransferRepository_GetAccountBalance_Call {
-	_c.Call.Return(f, err)
+func (_c *MockTransferRepository_GetStatementEntries_Call) Return(balances []models.Balance, statementLines []models.StatementLine, err error) *MockTransferRepository_GetStatementEntries_Call {
+	_c.Call.Return(balances, statementLines, err)
 	return _c
 }
 
-func (_c *MockTransferRepository_GetAccountBalance_Call) RunAndReturn(run func(id string) (float64, error)) *MockTransferRepository_GetAccountBalance_Call {
+func (_c *MockTransferRepository_GetStatementEntries_Call) RunAndReturn(run func(ctx context.Context, id string, from time.Time, to time.Time) ([]models.Balance, []models.StatementLine, error)) *MockTransferRepository_GetStatementEntries_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetTransfer provides a mock function for the type MockTransferRepository
-func (_mock *MockTransferRepository) GetTransfer(id string) (models.Transfer, error) {
-	ret := _mock.Called(id)
+func (_mock *MockTransferRepository) GetTransfer(ctx context.Context, id string) (models.Transfer, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTransfer")
@@ -178,16 +371,16 @@ func (_mock *MockTransferRepository) GetTransfer(id string) (models.Transfer, er
 
 	var r0 models.Transfer
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (models.Transfer, error)); ok {
-		return returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.Transfer, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) models.Transfer); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.Transfer); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Get(0).(models.Transfer)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -200,19 +393,25 @@ type MockTransferRepository_GetTransfer_Call struct {
 }
 
 // GetTransfer is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id string
-func (_e *MockTransferRepository_Expecter) GetTransfer(id interface{}) *MockTransferRepository_GetTransfer_Call {
-	return &MockTransferRepository_GetTransfer_Call{Call: _e.mock.On("GetTransfer", id)}
+func (_e *MockTransferRepository_Expecter) GetTransfer(ctx interface{}, id interface{}) *MockTransferRepository_GetTransfer_Call {
+	return &MockTransferRepository_GetTransfer_Call{Call: _e.mock.On("GetTransfer", ctx, id)}
 }
 
-func (_c *MockTransferRepository_GetTransfer_Call) Run(run func(id string)) *MockTransferRepository_GetTransfer_Call {
+func (_c *MockTransferRepository_GetTransfer_Call) Run(run func(ctx context.Context, id string)) *MockTransferRepository_GetTransfer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -223,45 +422,124 @@ func (_c *MockTransferRepository_GetTransfer_Call) Return(transfer models.Transf
 	return _c
 }
 
-func (_c *MockTransferRepository_GetTransfer_Call) RunAndReturn(run func(id string) (models.Transfer, error)) *MockTransferRepository_GetTransfer_Call {
+func (_c *MockTransferRepository_GetTransfer_Call) RunAndReturn(run func(ctx context.Context, id string) (models.Transfer, error)) *MockTransferRepository_GetTransfer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateTransfer provides a mock function for the type MockTransferRepository
-func (_mock *MockTransferRepository) UpdateTransfer(id string, status string) error {
-	ret := _mock.Called(id, status)
+// GetTransferGroup provides a mock function for the type MockTransferRepository
+func (_mock *MockTransferRepository) GetTransferGroup(ctx context.Context, parentID string) ([]models.Transfer, error) {
+	ret := _mock.Called(ctx, parentID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateTransfer")
+		panic("no return value specified for GetTransferGroup")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = returnFunc(id, status)
+	var r0 []models.Transfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]models.Transfer, error)); ok {
+		return returnFunc(ctx, parentID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []models.Transfer); ok {
+		r0 = returnFunc(ctx, parentID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Transfer)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockTransferRepository_UpdateTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTransfer'
-type MockTransferRepository_UpdateTransfer_Call struct {
+// MockTransferRepository_GetTransferGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransferGroup'
+type MockTransferRepository_GetTransferGroup_Call struct {
 	*mock.Call
 }
 
-// UpdateTransfer is a helper method to define mock.On call
+// GetTransferGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - parentID string
+func (_e *MockTransferRepository_Expecter) GetTransferGroup(ctx interface{}, parentID interface{}) *MockTransferRepository_GetTransferGroup_Call {
+	return &MockTransferRepository_GetTransferGroup_Call{Call: _e.mock.On("GetTransferGroup", ctx, parentID)}
+}
+
+func (_c *MockTransferRepository_GetTransferGroup_Call) Run(run func(ctx context.Context, parentID string)) *MockTransferRepository_GetTransferGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferRepository_GetTransferGroup_Call) Return(transfers []models.Transfer, err error) *MockTransferRepository_GetTransferGroup_Call {
+	_c.Call.Return(transfers, err)
+	return _c
+}
+
+func (_c *MockTransferRepository_GetTransferGroup_Call) RunAndReturn(run func(ctx context.Context, parentID string) ([]models.Transfer, error)) *MockTransferRepository_GetTransferGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransferHistory provides a mock function for the type MockTransferRepository
+func (_mock *MockTransferRepository) GetTransferHistory(ctx context.Context, id string) ([]models.TransferStatusHistory, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransferHistory")
+	}
+
+	var r0 []models.TransferStatusHistory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]models.TransferStatusHistory, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []models.TransferStatusHistory); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TransferStatusHistory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransferRepository_GetTransferHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransferHistory'
+type MockTransferRepository_GetTransferHistory_Call struct {
+	*mock.Call
+}
+
+// GetTransferHistory is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id string
-//   - status string
-func (_e *MockTransferRepository_Expecter) UpdateTransfer(id interface{}, status interface{}) *MockTransferRepository_UpdateTransfer_Call {
-	return &MockTransferRepository_UpdateTransfer_Call{Call: _e.mock.On("UpdateTransfer", id, status)}
+func (_e *MockTransferRepository_Expecter) GetTransferHistory(ctx interface{}, id interface{}) *MockTransferRepository_GetTransferHistory_Call {
+	return &MockTransferRepository_GetTransferHistory_Call{Call: _e.mock.On("GetTransferHistory", ctx, id)}
 }
 
-func (_c *MockTransferRepository_UpdateTransfer_Call) Run(run func(id string, status string)) *MockTransferRepository_UpdateTransfer_Call {
+func (_c *MockTransferRepository_GetTransferHistory_Call) Run(run func(ctx context.Context, id string)) *MockTransferRepository_GetTransferHistory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
 		}
 		var arg1 string
 		if args[1] != nil {
@@ -275,12 +553,2468 @@ func (_c *MockTransferRepository_UpdateTransfer_Call) Run(run func(id string, st
 	return _c
 }
 
-func (_c *MockTransferRepository_UpdateTransfer_Call) Return(err error) *MockTransferRepository_UpdateTransfer_Call {
-	_c.Call.Return(err)
+func (_c *MockTransferRepository_GetTransferHistory_Call) Return(transferStatusHistorys []models.TransferStatusHistory, err error) *MockTransferRepository_GetTransferHistory_Call {
+	_c.Call.Return(transferStatusHistorys, err)
+	return _c
+}
+
+func (_c *MockTransferRepository_GetTransferHistory_Call) RunAndReturn(run func(ctx context.Context, id string) ([]models.TransferStatusHistory, error)) *MockTransferRepository_GetTransferHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListScheduledTransfers provides a mock function for the type MockTransferRepository
+func (_mock *MockTransferRepository) ListScheduledTransfers(ctx context.Context, accounts []string, limit int) ([]models.Transfer, error) {
+	ret := _mock.Called(ctx, accounts, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListScheduledTransfers")
+	}
+
+	var r0 []models.Transfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, int) ([]models.Transfer, error)); ok {
+		return returnFunc(ctx, accounts, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, int) []models.Transfer); ok {
+		r0 = returnFunc(ctx, accounts, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Transfer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, int) error); ok {
+		r1 = returnFunc(ctx, accounts, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransferRepository_ListScheduledTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListScheduledTransfers'
+type MockTransferRepository_ListScheduledTransfers_Call struct {
+	*mock.Call
+}
+
+// ListScheduledTransfers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accounts []string
+//   - limit int
+func (_e *MockTransferRepository_Expecter) ListScheduledTransfers(ctx interface{}, accounts interface{}, limit interface{}) *MockTransferRepository_ListScheduledTransfers_Call {
+	return &MockTransferRepository_ListScheduledTransfers_Call{Call: _e.mock.On("ListScheduledTransfers", ctx, accounts, limit)}
+}
+
+func (_c *MockTransferRepository_ListScheduledTransfers_Call) Run(run func(ctx context.Context, accounts []string, limit int)) *MockTransferRepository_ListScheduledTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferRepository_ListScheduledTransfers_Call) Return(transfers []models.Transfer, err error) *MockTransferRepository_ListScheduledTransfers_Call {
+	_c.Call.Return(transfers, err)
+	return _c
+}
+
+func (_c *MockTransferRepository_ListScheduledTransfers_Call) RunAndReturn(run func(ctx context.Context, accounts []string, limit int) ([]models.Transfer, error)) *MockTransferRepository_ListScheduledTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTransfers provides a mock function for the type MockTransferRepository
+func (_mock *MockTransferRepository) ListTransfers(ctx context.Context, filter models.TransferFilter) ([]models.Transfer, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransfers")
+	}
+
+	var r0 []models.Transfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransferFilter) ([]models.Transfer, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransferFilter) []models.Transfer); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Transfer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.TransferFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransferRepository_ListTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTransfers'
+type MockTransferRepository_ListTransfers_Call struct {
+	*mock.Call
+}
+
+// ListTransfers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter models.TransferFilter
+func (_e *MockTransferRepository_Expecter) ListTransfers(ctx interface{}, filter interface{}) *MockTransferRepository_ListTransfers_Call {
+	return &MockTransferRepository_ListTransfers_Call{Call: _e.mock.On("ListTransfers", ctx, filter)}
+}
+
+func (_c *MockTransferRepository_ListTransfers_Call) Run(run func(ctx context.Context, filter models.TransferFilter)) *MockTransferRepository_ListTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.TransferFilter
+		if args[1] != nil {
+			arg1 = args[1].(models.TransferFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferRepository_ListTransfers_Call) Return(transfers []models.Transfer, err error) *MockTransferRepository_ListTransfers_Call {
+	_c.Call.Return(transfers, err)
+	return _c
+}
+
+func (_c *MockTransferRepository_ListTransfers_Call) RunAndReturn(run func(ctx context.Context, filter models.TransferFilter) ([]models.Transfer, error)) *MockTransferRepository_ListTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefundTransfer provides a mock function for the type MockTransferRepository
+func (_mock *MockTransferRepository) RefundTransfer(ctx context.Context, id string, amount money.Amount, change models.StatusChange) (models.Transfer, error) {
+	ret := _mock.Called(ctx, id, amount, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundTransfer")
+	}
+
+	var r0 models.Transfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, money.Amount, models.StatusChange) (models.Transfer, error)); ok {
+		return returnFunc(ctx, id, amount, change)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, money.Amount, models.StatusChange) models.Transfer); ok {
+		r0 = returnFunc(ctx, id, amount, change)
+	} else {
+		r0 = ret.Get(0).(models.Transfer)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, money.Amount, models.StatusChange) error); ok {
+		r1 = returnFunc(ctx, id, amount, change)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransferRepository_RefundTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefundTransfer'
+type MockTransferRepository_RefundTransfer_Call struct {
+	*mock.Call
+}
+
+// RefundTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - amount money.Amount
+//   - change models.StatusChange
+func (_e *MockTransferRepository_Expecter) RefundTransfer(ctx interface{}, id interface{}, amount interface{}, change interface{}) *MockTransferRepository_RefundTransfer_Call {
+	return &MockTransferRepository_RefundTransfer_Call{Call: _e.mock.On("RefundTransfer", ctx, id, amount, change)}
+}
+
+func (_c *MockTransferRepository_RefundTransfer_Call) Run(run func(ctx context.Context, id string, amount money.Amount, change models.StatusChange)) *MockTransferRepository_RefundTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 money.Amount
+		if args[2] != nil {
+			arg2 = args[2].(money.Amount)
+		}
+		var arg3 models.StatusChange
+		if args[3] != nil {
+			arg3 = args[3].(models.StatusChange)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferRepository_RefundTransfer_Call) Return(transfer models.Transfer, err error) *MockTransferRepository_RefundTransfer_Call {
+	_c.Call.Return(transfer, err)
+	return _c
+}
+
+func (_c *MockTransferRepository_RefundTransfer_Call) RunAndReturn(run func(ctx context.Context, id string, amount money.Amount, change models.StatusChange) (models.Transfer, error)) *MockTransferRepository_RefundTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseScheduledTransfer provides a mock function for the type MockTransferRepository
+func (_mock *MockTransferRepository) ReleaseScheduledTransfer(ctx context.Context, id string, now time.Time) (bool, error) {
+	ret := _mock.Called(ctx, id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseScheduledTransfer")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (bool, error)); ok {
+		return returnFunc(ctx, id, now)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) bool); ok {
+		r0 = returnFunc(ctx, id, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransferRepository_ReleaseScheduledTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseScheduledTransfer'
+type MockTransferRepository_ReleaseScheduledTransfer_Call struct {
+	*mock.Call
+}
+
+// ReleaseScheduledTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - now time.Time
+func (_e *MockTransferRepository_Expecter) ReleaseScheduledTransfer(ctx interface{}, id interface{}, now interface{}) *MockTransferRepository_ReleaseScheduledTransfer_Call {
+	return &MockTransferRepository_ReleaseScheduledTransfer_Call{Call: _e.mock.On("ReleaseScheduledTransfer", ctx, id, now)}
+}
+
+func (_c *MockTransferRepository_ReleaseScheduledTransfer_Call) Run(run func(ctx context.Context, id string, now time.Time)) *MockTransferRepository_ReleaseScheduledTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferRepository_ReleaseScheduledTransfer_Call) Return(b bool, err error) *MockTransferRepository_ReleaseScheduledTransfer_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockTransferRepository_ReleaseScheduledTransfer_Call) RunAndReturn(run func(ctx context.Context, id string, now time.Time) (bool, error)) *MockTransferRepository_ReleaseScheduledTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleTransfer provides a mock function for the type MockTransferRepository
+func (_mock *MockTransferRepository) ScheduleTransfer(ctx context.Context, from string, to string, amount money.Amount, currency string, executeAt time.Time) (string, error) {
+	ret := _mock.Called(ctx, from, to, amount, currency, executeAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleTransfer")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, money.Amount, string, time.Time) (string, error)); ok {
+		return returnFunc(ctx, from, to, amount, currency, executeAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, money.Amount, string, time.Time) string); ok {
+		r0 = returnFunc(ctx, from, to, amount, currency, executeAt)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, money.Amount, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, from, to, amount, currency, executeAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransferRepository_ScheduleTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleTransfer'
+type MockTransferRepository_ScheduleTransfer_Call struct {
+	*mock.Call
+}
+
+// ScheduleTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from string
+//   - to string
+//   - amount money.Amount
+//   - currency string
+//   - executeAt time.Time
+func (_e *MockTransferRepository_Expecter) ScheduleTransfer(ctx interface{}, from interface{}, to interface{}, amount interface{}, currency interface{}, executeAt interface{}) *MockTransferRepository_ScheduleTransfer_Call {
+	return &MockTransferRepository_ScheduleTransfer_Call{Call: _e.mock.On("ScheduleTransfer", ctx, from, to, amount, currency, executeAt)}
+}
+
+func (_c *MockTransferRepository_ScheduleTransfer_Call) Run(run func(ctx context.Context, from string, to string, amount money.Amount, currency string, executeAt time.Time)) *MockTransferRepository_ScheduleTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 money.Amount
+		if args[3] != nil {
+			arg3 = args[3].(money.Amount)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 time.Time
+		if args[5] != nil {
+			arg5 = args[5].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferRepository_ScheduleTransfer_Call) Return(s string, err error) *MockTransferRepository_ScheduleTransfer_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockTransferRepository_ScheduleTransfer_Call) RunAndReturn(run func(ctx context.Context, from string, to string, amount money.Amount, currency string, executeAt time.Time) (string, error)) *MockTransferRepository_ScheduleTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateScheduledTransfer provides a mock function for the type MockTransferRepository
+func (_mock *MockTransferRepository) UpdateScheduledTransfer(ctx context.Context, id string, edit models.ScheduledTransferEdit) (models.Transfer, error) {
+	ret := _mock.Called(ctx, id, edit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateScheduledTransfer")
+	}
+
+	var r0 models.Transfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.ScheduledTransferEdit) (models.Transfer, error)); ok {
+		return returnFunc(ctx, id, edit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.ScheduledTransferEdit) models.Transfer); ok {
+		r0 = returnFunc(ctx, id, edit)
+	} else {
+		r0 = ret.Get(0).(models.Transfer)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, models.ScheduledTransferEdit) error); ok {
+		r1 = returnFunc(ctx, id, edit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTransferRepository_UpdateScheduledTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateScheduledTransfer'
+type MockTransferRepository_UpdateScheduledTransfer_Call struct {
+	*mock.Call
+}
+
+// UpdateScheduledTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - edit models.ScheduledTransferEdit
+func (_e *MockTransferRepository_Expecter) UpdateScheduledTransfer(ctx interface{}, id interface{}, edit interface{}) *MockTransferRepository_UpdateScheduledTransfer_Call {
+	return &MockTransferRepository_UpdateScheduledTransfer_Call{Call: _e.mock.On("UpdateScheduledTransfer", ctx, id, edit)}
+}
+
+func (_c *MockTransferRepository_UpdateScheduledTransfer_Call) Run(run func(ctx context.Context, id string, edit models.ScheduledTransferEdit)) *MockTransferRepository_UpdateScheduledTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 models.ScheduledTransferEdit
+		if args[2] != nil {
+			arg2 = args[2].(models.ScheduledTransferEdit)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferRepository_UpdateScheduledTransfer_Call) Return(transfer models.Transfer, err error) *MockTransferRepository_UpdateScheduledTransfer_Call {
+	_c.Call.Return(transfer, err)
+	return _c
+}
+
+func (_c *MockTransferRepository_UpdateScheduledTransfer_Call) RunAndReturn(run func(ctx context.Context, id string, edit models.ScheduledTransferEdit) (models.Transfer, error)) *MockTransferRepository_UpdateScheduledTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTransfer provides a mock function for the type MockTransferRepository
+func (_mock *MockTransferRepository) UpdateTransfer(ctx context.Context, id string, change models.StatusChange) error {
+	ret := _mock.Called(ctx, id, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTransfer")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.StatusChange) error); ok {
+		r0 = returnFunc(ctx, id, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTransferRepository_UpdateTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTransfer'
+type MockTransferRepository_UpdateTransfer_Call struct {
+	*mock.Call
+}
+
+// UpdateTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - change models.StatusChange
+func (_e *MockTransferRepository_Expecter) UpdateTransfer(ctx interface{}, id interface{}, change interface{}) *MockTransferRepository_UpdateTransfer_Call {
+	return &MockTransferRepository_UpdateTransfer_Call{Call: _e.mock.On("UpdateTransfer", ctx, id, change)}
+}
+
+func (_c *MockTransferRepository_UpdateTransfer_Call) Run(run func(ctx context.Context, id string, change models.StatusChange)) *MockTransferRepository_UpdateTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 models.StatusChange
+		if args[2] != nil {
+			arg2 = args[2].(models.StatusChange)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTransferRepository_UpdateTransfer_Call) Return(err error) *MockTransferRepository_UpdateTransfer_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTransferRepository_UpdateTransfer_Call) RunAndReturn(run func(ctx context.Context, id string, change models.StatusChange) error) *MockTransferRepository_UpdateTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockJobRepository creates a new instance of MockJobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockJobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockJobRepository {
+	mock := &MockJobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockJobRepository is an autogenerated mock type for the JobRepository type
+type MockJobRepository struct {
+	mock.Mock
+}
+
+type MockJobRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockJobRepository) EXPECT() *MockJobRepository_Expecter {
+	return &MockJobRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimDue provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.ScheduledJob, error) {
+	ret := _mock.Called(ctx, now, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDue")
+	}
+
+	var r0 []models.ScheduledJob
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int, time.Duration) ([]models.ScheduledJob, error)); ok {
+		return returnFunc(ctx, now, limit, lease)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int, time.Duration) []models.ScheduledJob); ok {
+		r0 = returnFunc(ctx, now, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ScheduledJob)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int, time.Duration) error); ok {
+		r1 = returnFunc(ctx, now, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepository_ClaimDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDue'
+type MockJobRepository_ClaimDue_Call struct {
+	*mock.Call
+}
+
+// ClaimDue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+//   - lease time.Duration
+func (_e *MockJobRepository_Expecter) ClaimDue(ctx interface{}, now interface{}, limit interface{}, lease interface{}) *MockJobRepository_ClaimDue_Call {
+	return &MockJobRepository_ClaimDue_Call{Call: _e.mock.On("ClaimDue", ctx, now, limit, lease)}
+}
+
+func (_c *MockJobRepository_ClaimDue_Call) Run(run func(ctx context.Context, now time.Time, limit int, lease time.Duration)) *MockJobRepository_ClaimDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_ClaimDue_Call) Return(scheduledJobs []models.ScheduledJob, err error) *MockJobRepository_ClaimDue_Call {
+	_c.Call.Return(scheduledJobs, err)
+	return _c
+}
+
+func (_c *MockJobRepository_ClaimDue_Call) RunAndReturn(run func(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.ScheduledJob, error)) *MockJobRepository_ClaimDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Complete provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) Complete(ctx context.Context, jobID string, attempts int) error {
+	ret := _mock.Called(ctx, jobID, attempts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = returnFunc(ctx, jobID, attempts)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJobRepository_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type MockJobRepository_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID string
+//   - attempts int
+func (_e *MockJobRepository_Expecter) Complete(ctx interface{}, jobID interface{}, attempts interface{}) *MockJobRepository_Complete_Call {
+	return &MockJobRepository_Complete_Call{Call: _e.mock.On("Complete", ctx, jobID, attempts)}
+}
+
+func (_c *MockJobRepository_Complete_Call) Run(run func(ctx context.Context, jobID string, attempts int)) *MockJobRepository_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_Complete_Call) Return(err error) *MockJobRepository_Complete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJobRepository_Complete_Call) RunAndReturn(run func(ctx context.Context, jobID string, attempts int) error) *MockJobRepository_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enqueue provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) Enqueue(ctx context.Context, kind string, reference string, runAt time.Time) (string, error) {
+	ret := _mock.Called(ctx, kind, reference, runAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (string, error)); ok {
+		return returnFunc(ctx, kind, reference, runAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time) string); ok {
+		r0 = returnFunc(ctx, kind, reference, runAt)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, kind, reference, runAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepository_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type MockJobRepository_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - kind string
+//   - reference string
+//   - runAt time.Time
+func (_e *MockJobRepository_Expecter) Enqueue(ctx interface{}, kind interface{}, reference interface{}, runAt interface{}) *MockJobRepository_Enqueue_Call {
+	return &MockJobRepository_Enqueue_Call{Call: _e.mock.On("Enqueue", ctx, kind, reference, runAt)}
+}
+
+func (_c *MockJobRepository_Enqueue_Call) Run(run func(ctx context.Context, kind string, reference string, runAt time.Time)) *MockJobRepository_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_Enqueue_Call) Return(s string, err error) *MockJobRepository_Enqueue_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockJobRepository_Enqueue_Call) RunAndReturn(run func(ctx context.Context, kind string, reference string, runAt time.Time) (string, error)) *MockJobRepository_Enqueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Fail provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) Fail(ctx context.Context, jobID string, attempts int, lastError string) error {
+	ret := _mock.Called(ctx, jobID, attempts, lastError)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Fail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, string) error); ok {
+		r0 = returnFunc(ctx, jobID, attempts, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJobRepository_Fail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Fail'
+type MockJobRepository_Fail_Call struct {
+	*mock.Call
+}
+
+// Fail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID string
+//   - attempts int
+//   - lastError string
+func (_e *MockJobRepository_Expecter) Fail(ctx interface{}, jobID interface{}, attempts interface{}, lastError interface{}) *MockJobRepository_Fail_Call {
+	return &MockJobRepository_Fail_Call{Call: _e.mock.On("Fail", ctx, jobID, attempts, lastError)}
+}
+
+func (_c *MockJobRepository_Fail_Call) Run(run func(ctx context.Context, jobID string, attempts int, lastError string)) *MockJobRepository_Fail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_Fail_Call) Return(err error) *MockJobRepository_Fail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJobRepository_Fail_Call) RunAndReturn(run func(ctx context.Context, jobID string, attempts int, lastError string) error) *MockJobRepository_Fail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reschedule provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) Reschedule(ctx context.Context, jobID string, attempts int, nextRunAt time.Time, lastError string) error {
+	ret := _mock.Called(ctx, jobID, attempts, nextRunAt, lastError)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reschedule")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, time.Time, string) error); ok {
+		r0 = returnFunc(ctx, jobID, attempts, nextRunAt, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJobRepository_Reschedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reschedule'
+type MockJobRepository_Reschedule_Call struct {
+	*mock.Call
+}
+
+// Reschedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID string
+//   - attempts int
+//   - nextRunAt time.Time
+//   - lastError string
+func (_e *MockJobRepository_Expecter) Reschedule(ctx interface{}, jobID interface{}, attempts interface{}, nextRunAt interface{}, lastError interface{}) *MockJobRepository_Reschedule_Call {
+	return &MockJobRepository_Reschedule_Call{Call: _e.mock.On("Reschedule", ctx, jobID, attempts, nextRunAt, lastError)}
+}
+
+func (_c *MockJobRepository_Reschedule_Call) Run(run func(ctx context.Context, jobID string, attempts int, nextRunAt time.Time, lastError string)) *MockJobRepository_Reschedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_Reschedule_Call) Return(err error) *MockJobRepository_Reschedule_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJobRepository_Reschedule_Call) RunAndReturn(run func(ctx context.Context, jobID string, attempts int, nextRunAt time.Time, lastError string) error) *MockJobRepository_Reschedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAccountRepository creates a new instance of MockAccountRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAccountRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAccountRepository {
+	mock := &MockAccountRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAccountRepository is an autogenerated mock type for the AccountRepository type
+type MockAccountRepository struct {
+	mock.Mock
+}
+
+type MockAccountRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAccountRepository) EXPECT() *MockAccountRepository_Expecter {
+	return &MockAccountRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateAccount provides a mock function for the type MockAccountRepository
+func (_mock *MockAccountRepository) CreateAccount(ctx context.Context, account models.Account) (models.Account, error) {
+	ret := _mock.Called(ctx, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAccount")
+	}
+
+	var r0 models.Account
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Account) (models.Account, error)); ok {
+		return returnFunc(ctx, account)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Account) models.Account); ok {
+		r0 = returnFunc(ctx, account)
+	} else {
+		r0 = ret.Get(0).(models.Account)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Account) error); ok {
+		r1 = returnFunc(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountRepository_CreateAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAccount'
+type MockAccountRepository_CreateAccount_Call struct {
+	*mock.Call
+}
+
+// CreateAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account models.Account
+func (_e *MockAccountRepository_Expecter) CreateAccount(ctx interface{}, account interface{}) *MockAccountRepository_CreateAccount_Call {
+	return &MockAccountRepository_CreateAccount_Call{Call: _e.mock.On("CreateAccount", ctx, account)}
+}
+
+func (_c *MockAccountRepository_CreateAccount_Call) Run(run func(ctx context.Context, account models.Account)) *MockAccountRepository_CreateAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.Account
+		if args[1] != nil {
+			arg1 = args[1].(models.Account)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_CreateAccount_Call) Return(account1 models.Account, err error) *MockAccountRepository_CreateAccount_Call {
+	_c.Call.Return(account1, err)
+	return _c
+}
+
+func (_c *MockAccountRepository_CreateAccount_Call) RunAndReturn(run func(ctx context.Context, account models.Account) (models.Account, error)) *MockAccountRepository_CreateAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccount provides a mock function for the type MockAccountRepository
+func (_mock *MockAccountRepository) GetAccount(ctx context.Context, id string) (models.Account, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccount")
+	}
+
+	var r0 models.Account
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.Account, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.Account); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Account)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountRepository_GetAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccount'
+type MockAccountRepository_GetAccount_Call struct {
+	*mock.Call
+}
+
+// GetAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockAccountRepository_Expecter) GetAccount(ctx interface{}, id interface{}) *MockAccountRepository_GetAccount_Call {
+	return &MockAccountRepository_GetAccount_Call{Call: _e.mock.On("GetAccount", ctx, id)}
+}
+
+func (_c *MockAccountRepository_GetAccount_Call) Run(run func(ctx context.Context, id string)) *MockAccountRepository_GetAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_GetAccount_Call) Return(account models.Account, err error) *MockAccountRepository_GetAccount_Call {
+	_c.Call.Return(account, err)
+	return _c
+}
+
+func (_c *MockAccountRepository_GetAccount_Call) RunAndReturn(run func(ctx context.Context, id string) (models.Account, error)) *MockAccountRepository_GetAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAccountStatus provides a mock function for the type MockAccountRepository
+func (_mock *MockAccountRepository) UpdateAccountStatus(ctx context.Context, id string, status enums.AccountStatus) (models.Account, error) {
+	ret := _mock.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAccountStatus")
+	}
+
+	var r0 models.Account
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, enums.AccountStatus) (models.Account, error)); ok {
+		return returnFunc(ctx, id, status)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, enums.AccountStatus) models.Account); ok {
+		r0 = returnFunc(ctx, id, status)
+	} else {
+		r0 = ret.Get(0).(models.Account)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, enums.AccountStatus) error); ok {
+		r1 = returnFunc(ctx, id, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountRepository_UpdateAccountStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAccountStatus'
+type MockAccountRepository_UpdateAccountStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateAccountStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status enums.AccountStatus
+func (_e *MockAccountRepository_Expecter) UpdateAccountStatus(ctx interface{}, id interface{}, status interface{}) *MockAccountRepository_UpdateAccountStatus_Call {
+	return &MockAccountRepository_UpdateAccountStatus_Call{Call: _e.mock.On("UpdateAccountStatus", ctx, id, status)}
+}
+
+func (_c *MockAccountRepository_UpdateAccountStatus_Call) Run(run func(ctx context.Context, id string, status enums.AccountStatus)) *MockAccountRepository_UpdateAccountStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 enums.AccountStatus
+		if args[2] != nil {
+			arg2 = args[2].(enums.AccountStatus)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_UpdateAccountStatus_Call) Return(account models.Account, err error) *MockAccountRepository_UpdateAccountStatus_Call {
+	_c.Call.Return(account, err)
+	return _c
+}
+
+func (_c *MockAccountRepository_UpdateAccountStatus_Call) RunAndReturn(run func(ctx context.Context, id string, status enums.AccountStatus) (models.Account, error)) *MockAccountRepository_UpdateAccountStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStandingOrderRepository creates a new instance of MockStandingOrderRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStandingOrderRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStandingOrderRepository {
+	mock := &MockStandingOrderRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStandingOrderRepository is an autogenerated mock type for the StandingOrderRepository type
+type MockStandingOrderRepository struct {
+	mock.Mock
+}
+
+type MockStandingOrderRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStandingOrderRepository) EXPECT() *MockStandingOrderRepository_Expecter {
+	return &MockStandingOrderRepository_Expecter{mock: &_m.Mock}
+}
+
+// AbandonExecution provides a mock function for the type MockStandingOrderRepository
+func (_mock *MockStandingOrderRepository) AbandonExecution(ctx context.Context, execution models.StandingOrderExecution) error {
+	ret := _mock.Called(ctx, execution)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AbandonExecution")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.StandingOrderExecution) error); ok {
+		r0 = returnFunc(ctx, execution)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStandingOrderRepository_AbandonExecution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AbandonExecution'
+type MockStandingOrderRepository_AbandonExecution_Call struct {
+	*mock.Call
+}
+
+// AbandonExecution is a helper method to define mock.On call
+//   - ctx context.Context
+//   - execution models.StandingOrderExecution
+func (_e *MockStandingOrderRepository_Expecter) AbandonExecution(ctx interface{}, execution interface{}) *MockStandingOrderRepository_AbandonExecution_Call {
+	return &MockStandingOrderRepository_AbandonExecution_Call{Call: _e.mock.On("AbandonExecution", ctx, execution)}
+}
+
+func (_c *MockStandingOrderRepository_AbandonExecution_Call) Run(run func(ctx context.Context, execution models.StandingOrderExecution)) *MockStandingOrderRepository_AbandonExecution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.StandingOrderExecution
+		if args[1] != nil {
+			arg1 = args[1].(models.StandingOrderExecution)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_AbandonExecution_Call) Return(err error) *MockStandingOrderRepository_AbandonExecution_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_AbandonExecution_Call) RunAndReturn(run func(ctx context.Context, execution models.StandingOrderExecution) error) *MockStandingOrderRepository_AbandonExecution_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateStandingOrder provides a mock function for the type MockStandingOrderRepository
+func (_mock *MockStandingOrderRepository) CreateStandingOrder(ctx context.Context, order models.StandingOrder) (models.StandingOrder, error) {
+	ret := _mock.Called(ctx, order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStandingOrder")
+	}
+
+	var r0 models.StandingOrder
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.StandingOrder) (models.StandingOrder, error)); ok {
+		return returnFunc(ctx, order)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.StandingOrder) models.StandingOrder); ok {
+		r0 = returnFunc(ctx, order)
+	} else {
+		r0 = ret.Get(0).(models.StandingOrder)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.StandingOrder) error); ok {
+		r1 = returnFunc(ctx, order)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStandingOrderRepository_CreateStandingOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStandingOrder'
+type MockStandingOrderRepository_CreateStandingOrder_Call struct {
+	*mock.Call
+}
+
+// CreateStandingOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - order models.StandingOrder
+func (_e *MockStandingOrderRepository_Expecter) CreateStandingOrder(ctx interface{}, order interface{}) *MockStandingOrderRepository_CreateStandingOrder_Call {
+	return &MockStandingOrderRepository_CreateStandingOrder_Call{Call: _e.mock.On("CreateStandingOrder", ctx, order)}
+}
+
+func (_c *MockStandingOrderRepository_CreateStandingOrder_Call) Run(run func(ctx context.Context, order models.StandingOrder)) *MockStandingOrderRepository_CreateStandingOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.StandingOrder
+		if args[1] != nil {
+			arg1 = args[1].(models.StandingOrder)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_CreateStandingOrder_Call) Return(standingOrder models.StandingOrder, err error) *MockStandingOrderRepository_CreateStandingOrder_Call {
+	_c.Call.Return(standingOrder, err)
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_CreateStandingOrder_Call) RunAndReturn(run func(ctx context.Context, order models.StandingOrder) (models.StandingOrder, error)) *MockStandingOrderRepository_CreateStandingOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FinishExecution provides a mock function for the type MockStandingOrderRepository
+func (_mock *MockStandingOrderRepository) FinishExecution(ctx context.Context, execution models.StandingOrderExecution, nextRunAt *time.Time) (models.StandingOrder, error) {
+	ret := _mock.Called(ctx, execution, nextRunAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishExecution")
+	}
+
+	var r0 models.StandingOrder
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.StandingOrderExecution, *time.Time) (models.StandingOrder, error)); ok {
+		return returnFunc(ctx, execution, nextRunAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.StandingOrderExecution, *time.Time) models.StandingOrder); ok {
+		r0 = returnFunc(ctx, execution, nextRunAt)
+	} else {
+		r0 = ret.Get(0).(models.StandingOrder)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.StandingOrderExecution, *time.Time) error); ok {
+		r1 = returnFunc(ctx, execution, nextRunAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStandingOrderRepository_FinishExecution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishExecution'
+type MockStandingOrderRepository_FinishExecution_Call struct {
+	*mock.Call
+}
+
+// FinishExecution is a helper method to define mock.On call
+//   - ctx context.Context
+//   - execution models.StandingOrderExecution
+//   - nextRunAt *time.Time
+func (_e *MockStandingOrderRepository_Expecter) FinishExecution(ctx interface{}, execution interface{}, nextRunAt interface{}) *MockStandingOrderRepository_FinishExecution_Call {
+	return &MockStandingOrderRepository_FinishExecution_Call{Call: _e.mock.On("FinishExecution", ctx, execution, nextRunAt)}
+}
+
+func (_c *MockStandingOrderRepository_FinishExecution_Call) Run(run func(ctx context.Context, execution models.StandingOrderExecution, nextRunAt *time.Time)) *MockStandingOrderRepository_FinishExecution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.StandingOrderExecution
+		if args[1] != nil {
+			arg1 = args[1].(models.StandingOrderExecution)
+		}
+		var arg2 *time.Time
+		if args[2] != nil {
+			arg2 = args[2].(*time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_FinishExecution_Call) Return(standingOrder models.StandingOrder, err error) *MockStandingOrderRepository_FinishExecution_Call {
+	_c.Call.Return(standingOrder, err)
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_FinishExecution_Call) RunAndReturn(run func(ctx context.Context, execution models.StandingOrderExecution, nextRunAt *time.Time) (models.StandingOrder, error)) *MockStandingOrderRepository_FinishExecution_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStandingOrder provides a mock function for the type MockStandingOrderRepository
+func (_mock *MockStandingOrderRepository) GetStandingOrder(ctx context.Context, id string) (models.StandingOrder, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStandingOrder")
+	}
+
+	var r0 models.StandingOrder
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.StandingOrder, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.StandingOrder); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.StandingOrder)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStandingOrderRepository_GetStandingOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStandingOrder'
+type MockStandingOrderRepository_GetStandingOrder_Call struct {
+	*mock.Call
+}
+
+// GetStandingOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockStandingOrderRepository_Expecter) GetStandingOrder(ctx interface{}, id interface{}) *MockStandingOrderRepository_GetStandingOrder_Call {
+	return &MockStandingOrderRepository_GetStandingOrder_Call{Call: _e.mock.On("GetStandingOrder", ctx, id)}
+}
+
+func (_c *MockStandingOrderRepository_GetStandingOrder_Call) Run(run func(ctx context.Context, id string)) *MockStandingOrderRepository_GetStandingOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_GetStandingOrder_Call) Return(standingOrder models.StandingOrder, err error) *MockStandingOrderRepository_GetStandingOrder_Call {
+	_c.Call.Return(standingOrder, err)
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_GetStandingOrder_Call) RunAndReturn(run func(ctx context.Context, id string) (models.StandingOrder, error)) *MockStandingOrderRepository_GetStandingOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListExecutions provides a mock function for the type MockStandingOrderRepository
+func (_mock *MockStandingOrderRepository) ListExecutions(ctx context.Context, id string, limit int) ([]models.StandingOrderExecution, error) {
+	ret := _mock.Called(ctx, id, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExecutions")
+	}
+
+	var r0 []models.StandingOrderExecution
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]models.StandingOrderExecution, error)); ok {
+		return returnFunc(ctx, id, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []models.StandingOrderExecution); ok {
+		r0 = returnFunc(ctx, id, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StandingOrderExecution)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStandingOrderRepository_ListExecutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExecutions'
+type MockStandingOrderRepository_ListExecutions_Call struct {
+	*mock.Call
+}
+
+// ListExecutions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - limit int
+func (_e *MockStandingOrderRepository_Expecter) ListExecutions(ctx interface{}, id interface{}, limit interface{}) *MockStandingOrderRepository_ListExecutions_Call {
+	return &MockStandingOrderRepository_ListExecutions_Call{Call: _e.mock.On("ListExecutions", ctx, id, limit)}
+}
+
+func (_c *MockStandingOrderRepository_ListExecutions_Call) Run(run func(ctx context.Context, id string, limit int)) *MockStandingOrderRepository_ListExecutions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_ListExecutions_Call) Return(standingOrderExecutions []models.StandingOrderExecution, err error) *MockStandingOrderRepository_ListExecutions_Call {
+	_c.Call.Return(standingOrderExecutions, err)
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_ListExecutions_Call) RunAndReturn(run func(ctx context.Context, id string, limit int) ([]models.StandingOrderExecution, error)) *MockStandingOrderRepository_ListExecutions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStandingOrders provides a mock function for the type MockStandingOrderRepository
+func (_mock *MockStandingOrderRepository) ListStandingOrders(ctx context.Context, accounts []string, limit int) ([]models.StandingOrder, error) {
+	ret := _mock.Called(ctx, accounts, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStandingOrders")
+	}
+
+	var r0 []models.StandingOrder
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, int) ([]models.StandingOrder, error)); ok {
+		return returnFunc(ctx, accounts, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, int) []models.StandingOrder); ok {
+		r0 = returnFunc(ctx, accounts, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StandingOrder)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, int) error); ok {
+		r1 = returnFunc(ctx, accounts, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStandingOrderRepository_ListStandingOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStandingOrders'
+type MockStandingOrderRepository_ListStandingOrders_Call struct {
+	*mock.Call
+}
+
+// ListStandingOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accounts []string
+//   - limit int
+func (_e *MockStandingOrderRepository_Expecter) ListStandingOrders(ctx interface{}, accounts interface{}, limit interface{}) *MockStandingOrderRepository_ListStandingOrders_Call {
+	return &MockStandingOrderRepository_ListStandingOrders_Call{Call: _e.mock.On("ListStandingOrders", ctx, accounts, limit)}
+}
+
+func (_c *MockStandingOrderRepository_ListStandingOrders_Call) Run(run func(ctx context.Context, accounts []string, limit int)) *MockStandingOrderRepository_ListStandingOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_ListStandingOrders_Call) Return(standingOrders []models.StandingOrder, err error) *MockStandingOrderRepository_ListStandingOrders_Call {
+	_c.Call.Return(standingOrders, err)
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_ListStandingOrders_Call) RunAndReturn(run func(ctx context.Context, accounts []string, limit int) ([]models.StandingOrder, error)) *MockStandingOrderRepository_ListStandingOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetStandingOrderJob provides a mock function for the type MockStandingOrderRepository
+func (_mock *MockStandingOrderRepository) SetStandingOrderJob(ctx context.Context, id string, currentJobID string, jobID string) (bool, error) {
+	ret := _mock.Called(ctx, id, currentJobID, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStandingOrderJob")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (bool, error)); ok {
+		return returnFunc(ctx, id, currentJobID, jobID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
+		r0 = returnFunc(ctx, id, currentJobID, jobID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, id, currentJobID, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStandingOrderRepository_SetStandingOrderJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetStandingOrderJob'
+type MockStandingOrderRepository_SetStandingOrderJob_Call struct {
+	*mock.Call
+}
+
+// SetStandingOrderJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - currentJobID string
+//   - jobID string
+func (_e *MockStandingOrderRepository_Expecter) SetStandingOrderJob(ctx interface{}, id interface{}, currentJobID interface{}, jobID interface{}) *MockStandingOrderRepository_SetStandingOrderJob_Call {
+	return &MockStandingOrderRepository_SetStandingOrderJob_Call{Call: _e.mock.On("SetStandingOrderJob", ctx, id, currentJobID, jobID)}
+}
+
+func (_c *MockStandingOrderRepository_SetStandingOrderJob_Call) Run(run func(ctx context.Context, id string, currentJobID string, jobID string)) *MockStandingOrderRepository_SetStandingOrderJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_SetStandingOrderJob_Call) Return(b bool, err error) *MockStandingOrderRepository_SetStandingOrderJob_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_SetStandingOrderJob_Call) RunAndReturn(run func(ctx context.Context, id string, currentJobID string, jobID string) (bool, error)) *MockStandingOrderRepository_SetStandingOrderJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartExecution provides a mock function for the type MockStandingOrderRepository
+func (_mock *MockStandingOrderRepository) StartExecution(ctx context.Context, id string, scheduledFor time.Time) (models.StandingOrderExecution, bool, error) {
+	ret := _mock.Called(ctx, id, scheduledFor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartExecution")
+	}
+
+	var r0 models.StandingOrderExecution
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (models.StandingOrderExecution, bool, error)); ok {
+		return returnFunc(ctx, id, scheduledFor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) models.StandingOrderExecution); ok {
+		r0 = returnFunc(ctx, id, scheduledFor)
+	} else {
+		r0 = ret.Get(0).(models.StandingOrderExecution)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) bool); ok {
+		r1 = returnFunc(ctx, id, scheduledFor)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, time.Time) error); ok {
+		r2 = returnFunc(ctx, id, scheduledFor)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStandingOrderRepository_StartExecution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartExecution'
+type MockStandingOrderRepository_StartExecution_Call struct {
+	*mock.Call
+}
+
+// StartExecution is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - scheduledFor time.Time
+func (_e *MockStandingOrderRepository_Expecter) StartExecution(ctx interface{}, id interface{}, scheduledFor interface{}) *MockStandingOrderRepository_StartExecution_Call {
+	return &MockStandingOrderRepository_StartExecution_Call{Call: _e.mock.On("StartExecution", ctx, id, scheduledFor)}
+}
+
+func (_c *MockStandingOrderRepository_StartExecution_Call) Run(run func(ctx context.Context, id string, scheduledFor time.Time)) *MockStandingOrderRepository_StartExecution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_StartExecution_Call) Return(standingOrderExecution models.StandingOrderExecution, b bool, err error) *MockStandingOrderRepository_StartExecution_Call {
+	_c.Call.Return(standingOrderExecution, b, err)
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_StartExecution_Call) RunAndReturn(run func(ctx context.Context, id string, scheduledFor time.Time) (models.StandingOrderExecution, bool, error)) *MockStandingOrderRepository_StartExecution_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStandingOrderStatus provides a mock function for the type MockStandingOrderRepository
+func (_mock *MockStandingOrderRepository) UpdateStandingOrderStatus(ctx context.Context, id string, status enums.StandingOrderStatus, nextRunAt *time.Time, jobID string) (models.StandingOrder, error) {
+	ret := _mock.Called(ctx, id, status, nextRunAt, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStandingOrderStatus")
+	}
+
+	var r0 models.StandingOrder
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, enums.StandingOrderStatus, *time.Time, string) (models.StandingOrder, error)); ok {
+		return returnFunc(ctx, id, status, nextRunAt, jobID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, enums.StandingOrderStatus, *time.Time, string) models.StandingOrder); ok {
+		r0 = returnFunc(ctx, id, status, nextRunAt, jobID)
+	} else {
+		r0 = ret.Get(0).(models.StandingOrder)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, enums.StandingOrderStatus, *time.Time, string) error); ok {
+		r1 = returnFunc(ctx, id, status, nextRunAt, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStandingOrderRepository_UpdateStandingOrderStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStandingOrderStatus'
+type MockStandingOrderRepository_UpdateStandingOrderStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateStandingOrderStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status enums.StandingOrderStatus
+//   - nextRunAt *time.Time
+//   - jobID string
+func (_e *MockStandingOrderRepository_Expecter) UpdateStandingOrderStatus(ctx interface{}, id interface{}, status interface{}, nextRunAt interface{}, jobID interface{}) *MockStandingOrderRepository_UpdateStandingOrderStatus_Call {
+	return &MockStandingOrderRepository_UpdateStandingOrderStatus_Call{Call: _e.mock.On("UpdateStandingOrderStatus", ctx, id, status, nextRunAt, jobID)}
+}
+
+func (_c *MockStandingOrderRepository_UpdateStandingOrderStatus_Call) Run(run func(ctx context.Context, id string, status enums.StandingOrderStatus, nextRunAt *time.Time, jobID string)) *MockStandingOrderRepository_UpdateStandingOrderStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 enums.StandingOrderStatus
+		if args[2] != nil {
+			arg2 = args[2].(enums.StandingOrderStatus)
+		}
+		var arg3 *time.Time
+		if args[3] != nil {
+			arg3 = args[3].(*time.Time)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_UpdateStandingOrderStatus_Call) Return(standingOrder models.StandingOrder, err error) *MockStandingOrderRepository_UpdateStandingOrderStatus_Call {
+	_c.Call.Return(standingOrder, err)
+	return _c
+}
+
+func (_c *MockStandingOrderRepository_UpdateStandingOrderStatus_Call) RunAndReturn(run func(ctx context.Context, id string, status enums.StandingOrderStatus, nextRunAt *time.Time, jobID string) (models.StandingOrder, error)) *MockStandingOrderRepository_UpdateStandingOrderStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBatchRepository creates a new instance of MockBatchRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBatchRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBatchRepository {
+	mock := &MockBatchRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBatchRepository is an autogenerated mock type for the BatchRepository type
+type MockBatchRepository struct {
+	mock.Mock
+}
+
+type MockBatchRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBatchRepository) EXPECT() *MockBatchRepository_Expecter {
+	return &MockBatchRepository_Expecter{mock: &_m.Mock}
+}
+
+// CompleteBatch provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) CompleteBatch(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteBatch")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBatchRepository_CompleteBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteBatch'
+type MockBatchRepository_CompleteBatch_Call struct {
+	*mock.Call
+}
+
+// CompleteBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockBatchRepository_Expecter) CompleteBatch(ctx interface{}, id interface{}) *MockBatchRepository_CompleteBatch_Call {
+	return &MockBatchRepository_CompleteBatch_Call{Call: _e.mock.On("CompleteBatch", ctx, id)}
+}
+
+func (_c *MockBatchRepository_CompleteBatch_Call) Run(run func(ctx context.Context, id string)) *MockBatchRepository_CompleteBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_CompleteBatch_Call) Return(err error) *MockBatchRepository_CompleteBatch_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBatchRepository_CompleteBatch_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockBatchRepository_CompleteBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBatch provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) CreateBatch(ctx context.Context, batch models.TransferBatch, rows []models.TransferBatchRow) (models.TransferBatch, error) {
+	ret := _mock.Called(ctx, batch, rows)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 models.TransferBatch
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransferBatch, []models.TransferBatchRow) (models.TransferBatch, error)); ok {
+		return returnFunc(ctx, batch, rows)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransferBatch, []models.TransferBatchRow) models.TransferBatch); ok {
+		r0 = returnFunc(ctx, batch, rows)
+	} else {
+		r0 = ret.Get(0).(models.TransferBatch)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.TransferBatch, []models.TransferBatchRow) error); ok {
+		r1 = returnFunc(ctx, batch, rows)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchRepository_CreateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatch'
+type MockBatchRepository_CreateBatch_Call struct {
+	*mock.Call
+}
+
+// CreateBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batch models.TransferBatch
+//   - rows []models.TransferBatchRow
+func (_e *MockBatchRepository_Expecter) CreateBatch(ctx interface{}, batch interface{}, rows interface{}) *MockBatchRepository_CreateBatch_Call {
+	return &MockBatchRepository_CreateBatch_Call{Call: _e.mock.On("CreateBatch", ctx, batch, rows)}
+}
+
+func (_c *MockBatchRepository_CreateBatch_Call) Run(run func(ctx context.Context, batch models.TransferBatch, rows []models.TransferBatchRow)) *MockBatchRepository_CreateBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.TransferBatch
+		if args[1] != nil {
+			arg1 = args[1].(models.TransferBatch)
+		}
+		var arg2 []models.TransferBatchRow
+		if args[2] != nil {
+			arg2 = args[2].([]models.TransferBatchRow)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_CreateBatch_Call) Return(transferBatch models.TransferBatch, err error) *MockBatchRepository_CreateBatch_Call {
+	_c.Call.Return(transferBatch, err)
+	return _c
+}
+
+func (_c *MockBatchRepository_CreateBatch_Call) RunAndReturn(run func(ctx context.Context, batch models.TransferBatch, rows []models.TransferBatchRow) (models.TransferBatch, error)) *MockBatchRepository_CreateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailBatchRows provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) FailBatchRows(ctx context.Context, id string, status enums.BatchRowStatus, message string) (int64, error) {
+	ret := _mock.Called(ctx, id, status, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailBatchRows")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, enums.BatchRowStatus, string) (int64, error)); ok {
+		return returnFunc(ctx, id, status, message)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, enums.BatchRowStatus, string) int64); ok {
+		r0 = returnFunc(ctx, id, status, message)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, enums.BatchRowStatus, string) error); ok {
+		r1 = returnFunc(ctx, id, status, message)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchRepository_FailBatchRows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailBatchRows'
+type MockBatchRepository_FailBatchRows_Call struct {
+	*mock.Call
+}
+
+// FailBatchRows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status enums.BatchRowStatus
+//   - message string
+func (_e *MockBatchRepository_Expecter) FailBatchRows(ctx interface{}, id interface{}, status interface{}, message interface{}) *MockBatchRepository_FailBatchRows_Call {
+	return &MockBatchRepository_FailBatchRows_Call{Call: _e.mock.On("FailBatchRows", ctx, id, status, message)}
+}
+
+func (_c *MockBatchRepository_FailBatchRows_Call) Run(run func(ctx context.Context, id string, status enums.BatchRowStatus, message string)) *MockBatchRepository_FailBatchRows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 enums.BatchRowStatus
+		if args[2] != nil {
+			arg2 = args[2].(enums.BatchRowStatus)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_FailBatchRows_Call) Return(n int64, err error) *MockBatchRepository_FailBatchRows_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockBatchRepository_FailBatchRows_Call) RunAndReturn(run func(ctx context.Context, id string, status enums.BatchRowStatus, message string) (int64, error)) *MockBatchRepository_FailBatchRows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FinishBatchRow provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) FinishBatchRow(ctx context.Context, row models.TransferBatchRow) error {
+	ret := _mock.Called(ctx, row)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishBatchRow")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransferBatchRow) error); ok {
+		r0 = returnFunc(ctx, row)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBatchRepository_FinishBatchRow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishBatchRow'
+type MockBatchRepository_FinishBatchRow_Call struct {
+	*mock.Call
+}
+
+// FinishBatchRow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - row models.TransferBatchRow
+func (_e *MockBatchRepository_Expecter) FinishBatchRow(ctx interface{}, row interface{}) *MockBatchRepository_FinishBatchRow_Call {
+	return &MockBatchRepository_FinishBatchRow_Call{Call: _e.mock.On("FinishBatchRow", ctx, row)}
+}
+
+func (_c *MockBatchRepository_FinishBatchRow_Call) Run(run func(ctx context.Context, row models.TransferBatchRow)) *MockBatchRepository_FinishBatchRow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.TransferBatchRow
+		if args[1] != nil {
+			arg1 = args[1].(models.TransferBatchRow)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_FinishBatchRow_Call) Return(err error) *MockBatchRepository_FinishBatchRow_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBatchRepository_FinishBatchRow_Call) RunAndReturn(run func(ctx context.Context, row models.TransferBatchRow) error) *MockBatchRepository_FinishBatchRow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBatch provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) GetBatch(ctx context.Context, id string) (models.TransferBatch, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatch")
+	}
+
+	var r0 models.TransferBatch
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.TransferBatch, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.TransferBatch); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.TransferBatch)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchRepository_GetBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatch'
+type MockBatchRepository_GetBatch_Call struct {
+	*mock.Call
+}
+
+// GetBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockBatchRepository_Expecter) GetBatch(ctx interface{}, id interface{}) *MockBatchRepository_GetBatch_Call {
+	return &MockBatchRepository_GetBatch_Call{Call: _e.mock.On("GetBatch", ctx, id)}
+}
+
+func (_c *MockBatchRepository_GetBatch_Call) Run(run func(ctx context.Context, id string)) *MockBatchRepository_GetBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_GetBatch_Call) Return(transferBatch models.TransferBatch, err error) *MockBatchRepository_GetBatch_Call {
+	_c.Call.Return(transferBatch, err)
+	return _c
+}
+
+func (_c *MockBatchRepository_GetBatch_Call) RunAndReturn(run func(ctx context.Context, id string) (models.TransferBatch, error)) *MockBatchRepository_GetBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBatchRows provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) ListBatchRows(ctx context.Context, id string, status enums.BatchRowStatus, afterLine int, limit int) ([]models.TransferBatchRow, error) {
+	ret := _mock.Called(ctx, id, status, afterLine, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBatchRows")
+	}
+
+	var r0 []models.TransferBatchRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, enums.BatchRowStatus, int, int) ([]models.TransferBatchRow, error)); ok {
+		return returnFunc(ctx, id, status, afterLine, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, enums.BatchRowStatus, int, int) []models.TransferBatchRow); ok {
+		r0 = returnFunc(ctx, id, status, afterLine, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TransferBatchRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, enums.BatchRowStatus, int, int) error); ok {
+		r1 = returnFunc(ctx, id, status, afterLine, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchRepository_ListBatchRows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBatchRows'
+type MockBatchRepository_ListBatchRows_Call struct {
+	*mock.Call
+}
+
+// ListBatchRows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status enums.BatchRowStatus
+//   - afterLine int
+//   - limit int
+func (_e *MockBatchRepository_Expecter) ListBatchRows(ctx interface{}, id interface{}, status interface{}, afterLine interface{}, limit interface{}) *MockBatchRepository_ListBatchRows_Call {
+	return &MockBatchRepository_ListBatchRows_Call{Call: _e.mock.On("ListBatchRows", ctx, id, status, afterLine, limit)}
+}
+
+func (_c *MockBatchRepository_ListBatchRows_Call) Run(run func(ctx context.Context, id string, status enums.BatchRowStatus, afterLine int, limit int)) *MockBatchRepository_ListBatchRows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 enums.BatchRowStatus
+		if args[2] != nil {
+			arg2 = args[2].(enums.BatchRowStatus)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_ListBatchRows_Call) Return(transferBatchRows []models.TransferBatchRow, err error) *MockBatchRepository_ListBatchRows_Call {
+	_c.Call.Return(transferBatchRows, err)
+	return _c
+}
+
+func (_c *MockBatchRepository_ListBatchRows_Call) RunAndReturn(run func(ctx context.Context, id string, status enums.BatchRowStatus, afterLine int, limit int) ([]models.TransferBatchRow, error)) *MockBatchRepository_ListBatchRows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PendingBatchRows provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) PendingBatchRows(ctx context.Context, id string, limit int) ([]models.TransferBatchRow, error) {
+	ret := _mock.Called(ctx, id, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PendingBatchRows")
+	}
+
+	var r0 []models.TransferBatchRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]models.TransferBatchRow, error)); ok {
+		return returnFunc(ctx, id, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []models.TransferBatchRow); ok {
+		r0 = returnFunc(ctx, id, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TransferBatchRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchRepository_PendingBatchRows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PendingBatchRows'
+type MockBatchRepository_PendingBatchRows_Call struct {
+	*mock.Call
+}
+
+// PendingBatchRows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - limit int
+func (_e *MockBatchRepository_Expecter) PendingBatchRows(ctx interface{}, id interface{}, limit interface{}) *MockBatchRepository_PendingBatchRows_Call {
+	return &MockBatchRepository_PendingBatchRows_Call{Call: _e.mock.On("PendingBatchRows", ctx, id, limit)}
+}
+
+func (_c *MockBatchRepository_PendingBatchRows_Call) Run(run func(ctx context.Context, id string, limit int)) *MockBatchRepository_PendingBatchRows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_PendingBatchRows_Call) Return(transferBatchRows []models.TransferBatchRow, err error) *MockBatchRepository_PendingBatchRows_Call {
+	_c.Call.Return(transferBatchRows, err)
+	return _c
+}
+
+func (_c *MockBatchRepository_PendingBatchRows_Call) RunAndReturn(run func(ctx context.Context, id string, limit int) ([]models.TransferBatchRow, error)) *MockBatchRepository_PendingBatchRows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseBatchRow provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) ReleaseBatchRow(ctx context.Context, row models.TransferBatchRow) error {
+	ret := _mock.Called(ctx, row)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseBatchRow")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransferBatchRow) error); ok {
+		r0 = returnFunc(ctx, row)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBatchRepository_ReleaseBatchRow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseBatchRow'
+type MockBatchRepository_ReleaseBatchRow_Call struct {
+	*mock.Call
+}
+
+// ReleaseBatchRow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - row models.TransferBatchRow
+func (_e *MockBatchRepository_Expecter) ReleaseBatchRow(ctx interface{}, row interface{}) *MockBatchRepository_ReleaseBatchRow_Call {
+	return &MockBatchRepository_ReleaseBatchRow_Call{Call: _e.mock.On("ReleaseBatchRow", ctx, row)}
+}
+
+func (_c *MockBatchRepository_ReleaseBatchRow_Call) Run(run func(ctx context.Context, row models.TransferBatchRow)) *MockBatchRepository_ReleaseBatchRow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.TransferBatchRow
+		if args[1] != nil {
+			arg1 = args[1].(models.TransferBatchRow)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_ReleaseBatchRow_Call) Return(err error) *MockBatchRepository_ReleaseBatchRow_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBatchRepository_ReleaseBatchRow_Call) RunAndReturn(run func(ctx context.Context, row models.TransferBatchRow) error) *MockBatchRepository_ReleaseBatchRow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartBatchRow provides a mock function for the type MockBatchRepository
+func (_mock *MockBatchRepository) StartBatchRow(ctx context.Context, row models.TransferBatchRow) (bool, error) {
+	ret := _mock.Called(ctx, row)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartBatchRow")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransferBatchRow) (bool, error)); ok {
+		return returnFunc(ctx, row)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TransferBatchRow) bool); ok {
+		r0 = returnFunc(ctx, row)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.TransferBatchRow) error); ok {
+		r1 = returnFunc(ctx, row)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchRepository_StartBatchRow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartBatchRow'
+type MockBatchRepository_StartBatchRow_Call struct {
+	*mock.Call
+}
+
+// StartBatchRow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - row models.TransferBatchRow
+func (_e *MockBatchRepository_Expecter) StartBatchRow(ctx interface{}, row interface{}) *MockBatchRepository_StartBatchRow_Call {
+	return &MockBatchRepository_StartBatchRow_Call{Call: _e.mock.On("StartBatchRow", ctx, row)}
+}
+
+func (_c *MockBatchRepository_StartBatchRow_Call) Run(run func(ctx context.Context, row models.TransferBatchRow)) *MockBatchRepository_StartBatchRow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.TransferBatchRow
+		if args[1] != nil {
+			arg1 = args[1].(models.TransferBatchRow)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchRepository_StartBatchRow_Call) Return(b bool, err error) *MockBatchRepository_StartBatchRow_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockTransferRepository_UpdateTransfer_Call) RunAndReturn(run func(id string, status string) error) *MockTransferRepository_UpdateTransfer_Call {
+func (_c *MockBatchRepository_StartBatchRow_Call) RunAndReturn(run func(ctx context.Context, row models.TransferBatchRow) (bool, error)) *MockBatchRepository_StartBatchRow_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/logging"
+	"secure-payment-service/internal/models"
+)
+
+const ExecuteScheduledTransferJob = "scheduled_transfer"
+
+// ScheduledTransferExecutor is the scheduled job handler that releases
+// future-dated transfers once their execution time has come.
+type ScheduledTransferExecutor struct {
+	transferService TransferService
+}
+
+func NewScheduledTransferExecutor(svc TransferService) *ScheduledTransferExecutor {
+	return &ScheduledTransferExecutor{transferService: svc}
+}
+
+func (e *ScheduledTransferExecutor) Handle(ctx context.Context, job models.ScheduledJob) (bool, error) {
+	if err := e.transferService.ExecuteScheduledTransfer(ctx, job.Reference); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Exhausted fails the transfer so it does not stay SCHEDULED forever after
+// the executor kept erroring. The change is pinned to the version that was
+// still SCHEDULED, so a transfer released or cancelled meanwhile is left
+// alone.
+func (e *ScheduledTransferExecutor) Exhausted(ctx context.Context, job models.ScheduledJob) error {
+	transfer, err := e.transferService.GetTransfer(ctx, job.Reference)
+	if err != nil {
+		return err
+	}
+	if transfer.Status != enums.SCHEDULED.String() {
+		return nil
+	}
+
+	logging.Logger.WithFields(logrus.Fields{
+		"transfer_id": job.Reference,
+		"attempts":    job.Attempts,
+	}).Warn("scheduled transfer could not be executed, marking it as failed")
+
+	err = e.transferService.UpdateTransfer(ctx, job.Reference, models.StatusChange{
+		Status:  enums.FAILED.String(),
+		Source:  enums.SourceScheduler,
+		Reason:  "scheduled execution kept failing",
+		IfMatch: []uint{transfer.Version},
+	})
+
+	if errors.Is(err, apperrors.ErrInvalidTransition) || errors.Is(err, apperrors.ErrPrecondition) {
+		// The transfer was released or cancelled meanwhile.
+		return nil
+	}
+	return err
+}
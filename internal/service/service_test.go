@@ -1,14 +1,21 @@
 package service_test
 
 import (
+	"context"
 	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/enums"
 	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/money"
+	"secure-payment-service/internal/repository"
 	"secure-payment-service/internal/service"
 
 	transfers "secure-payment-service/internal/transfers"
@@ -17,15 +24,23 @@ import (
 const (
 	fromAccount               = "acc-001"
 	toAccount                 = "acc-002"
-	amount                    = 100.50
 	currency                  = "USD"
 	expectedMonitorTransferID = "test-transfer-id-123"
 	statusCompleted           = "COMPLETED"
 	statusFailed              = "FAILED"
+	statusPending             = "PENDING"
 	transferID                = "some-transfer-id"
-	expectedBalance           = 123.45
 )
 
+var (
+	amount          = money.MustParse("100.50")
+	expectedBalance = money.MustParse("123.45")
+)
+
+func webhookChange(status string) models.StatusChange {
+	return models.StatusChange{Status: status, Source: enums.SourceWebhook}
+}
+
 func givenAnTransferRequest() transfers.TransferRequest {
 	return transfers.TransferRequest{
 		FromAccount: fromAccount,
@@ -37,37 +52,75 @@ func givenAnTransferRequest() transfers.TransferRequest {
 
 func TestTransferServiceImpl_CreateTransfer_Success(t *testing.T) {
 	mockRepo := service.NewMockTransferRepository(t)
-	transferService := service.NewTransferService(mockRepo)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
 	req := givenAnTransferRequest()
 
-	mockRepo.On("CreateTransfer", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("float64")).
+	mockRepo.On("CreateTransfer", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("money.Amount"), currency).
 		Return(expectedMonitorTransferID, nil).Once()
 
-	mockRepo.On("GetTransfer", expectedMonitorTransferID).Return(models.Transfer{Status: statusCompleted}, nil).Maybe()
+	mockJobs.On("Enqueue", mock.Anything, service.MonitorTransferJob, expectedMonitorTransferID, mock.AnythingOfType("time.Time")).
+		Return("job-id", nil).Once()
 
-	id, err := transferService.CreateTransfer(req)
+	id, err := transferService.CreateTransfer(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedMonitorTransferID, id)
 
-	time.Sleep(50 * time.Millisecond)
-	mockRepo.AssertCalled(t, "CreateTransfer", fromAccount, toAccount, mock.Anything)
+	mockRepo.AssertCalled(t, "CreateTransfer", mock.Anything, fromAccount, toAccount, mock.Anything, currency)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestTransferServiceImpl_CreateTransfer_NormalizesCurrency(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	req := givenAnTransferRequest()
+	req.Currency = "eur"
+
+	mockRepo.On("CreateTransfer", mock.Anything, fromAccount, toAccount, amount, "EUR").Return(expectedMonitorTransferID, nil).Once()
+	mockJobs.On("Enqueue", mock.Anything, service.MonitorTransferJob, expectedMonitorTransferID, mock.AnythingOfType("time.Time")).
+		Return("job-id", nil).Once()
+
+	id, err := transferService.CreateTransfer(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMonitorTransferID, id)
+}
+
+func TestTransferServiceImpl_CreateTransfer_InvalidCurrency(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	req := givenAnTransferRequest()
+	req.Currency = "ABC"
+
+	id, err := transferService.CreateTransfer(context.Background(), req)
+
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+	var validationErr *apperrors.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "currency", validationErr.Fields[0].Field)
+	assert.Equal(t, apperrors.CodeInvalidCurrency, validationErr.Fields[0].Code)
+	assert.Empty(t, id)
+	mockRepo.AssertNotCalled(t, "CreateTransfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestTransferServiceImpl_CreateTransfer_RepositoryError(t *testing.T) {
 	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
 	expectedError := errors.New("error de base de datos simulado")
 
-	mockRepo.On("CreateTransfer", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("float64")).
+	mockRepo.On("CreateTransfer", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("money.Amount"), currency).
 		Return("", expectedError).Once()
 
-	transferService := service.NewTransferService(mockRepo)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
 
 	req := givenAnTransferRequest()
 
-	id, err := transferService.CreateTransfer(req)
+	id, err := transferService.CreateTransfer(context.Background(), req)
 
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
@@ -75,9 +128,239 @@ func TestTransferServiceImpl_CreateTransfer_RepositoryError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestTransferServiceImpl_CreateTransfer_Scheduled(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	req := givenAnTransferRequest()
+	executeAt := time.Now().Add(72 * time.Hour)
+	req.ExecuteAt = &executeAt
+
+	mockRepo.On("ScheduleTransfer", mock.Anything, fromAccount, toAccount, amount, currency, executeAt).Return(transferID, nil).Once()
+	mockJobs.On("Enqueue", mock.Anything, service.ExecuteScheduledTransferJob, transferID, executeAt).Return("job-id", nil).Once()
+
+	id, err := transferService.CreateTransfer(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, transferID, id)
+	mockRepo.AssertNotCalled(t, "CreateTransfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferServiceImpl_CreateTransfer_ScheduledWithoutJobIsCancelled(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	req := givenAnTransferRequest()
+	executeAt := time.Now().Add(time.Hour)
+	req.ExecuteAt = &executeAt
+	enqueueErr := errors.New("database unavailable")
+
+	mockRepo.On("ScheduleTransfer", mock.Anything, fromAccount, toAccount, amount, currency, executeAt).Return(transferID, nil).Once()
+	mockJobs.On("Enqueue", mock.Anything, service.ExecuteScheduledTransferJob, transferID, executeAt).Return("", enqueueErr).Once()
+	mockRepo.On("UpdateTransfer", mock.Anything, transferID, mock.MatchedBy(func(c models.StatusChange) bool {
+		return c.Status == enums.CANCELLED.String() && c.Source == enums.SourceScheduler
+	})).Return(nil).Once()
+
+	_, err := transferService.CreateTransfer(context.Background(), req)
+
+	assert.Equal(t, enqueueErr, err)
+}
+
+func TestTransferServiceImpl_ExecuteScheduledTransfer_Released(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	mockRepo.On("ReleaseScheduledTransfer", mock.Anything, transferID, mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+	mockJobs.On("Enqueue", mock.Anything, service.MonitorTransferJob, transferID, mock.AnythingOfType("time.Time")).Return("job-id", nil).Once()
+
+	assert.NoError(t, transferService.ExecuteScheduledTransfer(context.Background(), transferID))
+}
+
+func TestTransferServiceImpl_ExecuteScheduledTransfer_NotDue(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	mockRepo.On("ReleaseScheduledTransfer", mock.Anything, transferID, mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+
+	assert.NoError(t, transferService.ExecuteScheduledTransfer(context.Background(), transferID))
+	mockJobs.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferServiceImpl_ExecuteScheduledTransfer_InsufficientFundsFailsTransfer(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	mockRepo.On("ReleaseScheduledTransfer", mock.Anything, transferID, mock.AnythingOfType("time.Time")).
+		Return(false, apperrors.New(apperrors.ErrInsufficientFunds, apperrors.CodeInsufficientFunds, "insufficient funds in account acc-001")).Once()
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: enums.SCHEDULED.String(), Version: 1}, nil).Once()
+	mockRepo.On("UpdateTransfer", mock.Anything, transferID, models.StatusChange{
+		Status:  statusFailed,
+		Source:  enums.SourceScheduler,
+		Reason:  "insufficient funds in account acc-001",
+		IfMatch: []uint{1},
+	}).Return(nil).Once()
+
+	assert.NoError(t, transferService.ExecuteScheduledTransfer(context.Background(), transferID))
+}
+
+func TestTransferServiceImpl_ExecuteScheduledTransfer_RepositoryErrorIsRetried(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	expectedError := errors.New("database unavailable")
+
+	mockRepo.On("ReleaseScheduledTransfer", mock.Anything, transferID, mock.AnythingOfType("time.Time")).Return(false, expectedError).Once()
+
+	assert.Equal(t, expectedError, transferService.ExecuteScheduledTransfer(context.Background(), transferID))
+	mockRepo.AssertNotCalled(t, "UpdateTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferServiceImpl_UpdateScheduledTransfer(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	executeAt := time.Now().Add(time.Hour)
+	req := transfers.ScheduledTransferUpdate{ExecuteAt: &executeAt}
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, FromAccount: fromAccount, Currency: currency}, nil).Once()
+	mockRepo.On("UpdateScheduledTransfer", mock.Anything, transferID, models.ScheduledTransferEdit{ExecuteAt: &executeAt, IfMatch: []uint{1}}).
+		Return(models.Transfer{TransferID: transferID, Version: 2}, nil).Once()
+	mockJobs.On("Enqueue", mock.Anything, service.ExecuteScheduledTransferJob, transferID, executeAt).Return("job-id", nil).Once()
+
+	transfer, err := transferService.UpdateScheduledTransfer(context.Background(), transferID, req, []uint{1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), transfer.Version)
+}
+
+func TestTransferServiceImpl_UpdateScheduledTransfer_CancelsWhenJobCannotBeEnqueued(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	executeAt := time.Now().Add(time.Hour)
+	expectedError := errors.New("queue unavailable")
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, FromAccount: fromAccount, Currency: currency}, nil).Once()
+	mockRepo.On("UpdateScheduledTransfer", mock.Anything, transferID, models.ScheduledTransferEdit{ExecuteAt: &executeAt}).
+		Return(models.Transfer{TransferID: transferID, Version: 2}, nil).Once()
+	mockJobs.On("Enqueue", mock.Anything, service.ExecuteScheduledTransferJob, transferID, executeAt).Return("", expectedError).Once()
+	mockRepo.On("UpdateTransfer", mock.Anything, transferID, mock.MatchedBy(func(c models.StatusChange) bool {
+		return c.Status == enums.CANCELLED.String() && c.Source == enums.SourceScheduler
+	})).Return(nil).Once()
+
+	_, err := transferService.UpdateScheduledTransfer(context.Background(), transferID, transfers.ScheduledTransferUpdate{ExecuteAt: &executeAt}, nil)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func TestTransferServiceImpl_UpdateScheduledTransfer_InvalidForTransfer(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	payee := fromAccount
+	precise := money.MustParse("1.001")
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, FromAccount: fromAccount, Currency: currency}, nil).Once()
+
+	_, err := transferService.UpdateScheduledTransfer(context.Background(), transferID, transfers.ScheduledTransferUpdate{ToAccount: &payee, Amount: &precise}, nil)
+
+	var validationErr *apperrors.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "destination_account_id", Code: apperrors.CodeSameAccount, Message: "must differ from source_account_id"},
+		{Field: "amount", Code: apperrors.CodeTooPrecise, Message: "USD allows at most 2 decimal places"},
+	}, validationErr.Fields)
+	mockRepo.AssertNotCalled(t, "UpdateScheduledTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func givenATransferGroupRequest() transfers.TransferGroupRequest {
+	return transfers.TransferGroupRequest{
+		FromAccount: fromAccount,
+		Currency:    "usd",
+		Legs: []transfers.TransferLegRequest{
+			{ToAccount: toAccount, Amount: money.MustParse("90")},
+			{ToAccount: "acc-fee", Amount: money.MustParse("10.50")},
+		},
+	}
+}
+
+func TestTransferServiceImpl_CreateTransferGroup_MonitorsEveryLeg(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	legs := []models.Transfer{
+		{TransferID: "leg-1", ParentID: "parent-1", FromAccount: fromAccount, ToAccount: toAccount, Amount: money.MustParse("90"), Currency: currency, Status: statusPending},
+		{TransferID: "leg-2", ParentID: "parent-1", FromAccount: fromAccount, ToAccount: "acc-fee", Amount: money.MustParse("10.50"), Currency: currency, Status: statusPending},
+	}
+	mockRepo.On("CreateTransferGroup", mock.Anything, fromAccount, currency, []models.TransferLeg{
+		{ToAccount: toAccount, Amount: money.MustParse("90")},
+		{ToAccount: "acc-fee", Amount: money.MustParse("10.50")},
+	}).Return(legs, nil).Once()
+	mockJobs.On("Enqueue", mock.Anything, service.MonitorTransferJob, "leg-1", mock.AnythingOfType("time.Time")).Return("job-1", nil).Once()
+	mockJobs.On("Enqueue", mock.Anything, service.MonitorTransferJob, "leg-2", mock.AnythingOfType("time.Time")).Return("job-2", nil).Once()
+
+	group, err := transferService.CreateTransferGroup(context.Background(), givenATransferGroupRequest())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "parent-1", group.ParentID)
+	assert.Equal(t, fromAccount, group.FromAccount)
+	assert.Equal(t, amount, group.Amount)
+	assert.Equal(t, statusPending, group.Status)
+	assert.Len(t, group.Legs, 2)
+}
+
+func TestTransferServiceImpl_CreateTransferGroup_InvalidRequest(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	req := givenATransferGroupRequest()
+	req.Legs = req.Legs[:1]
+
+	_, err := transferService.CreateTransferGroup(context.Background(), req)
+
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+	mockRepo.AssertNotCalled(t, "CreateTransferGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferServiceImpl_CreateTransferGroup_InsufficientFunds(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	insufficient := apperrors.New(apperrors.ErrInsufficientFunds, apperrors.CodeInsufficientFunds, "insufficient funds")
+
+	mockRepo.On("CreateTransferGroup", mock.Anything, fromAccount, currency, mock.Anything).Return(nil, insufficient).Once()
+
+	_, err := transferService.CreateTransferGroup(context.Background(), givenATransferGroupRequest())
+
+	assert.ErrorIs(t, err, apperrors.ErrInsufficientFunds)
+	mockJobs.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferServiceImpl_GetTransferGroup_DerivesStatus(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	mockRepo.On("GetTransferGroup", mock.Anything, "parent-1").Return([]models.Transfer{
+		{TransferID: "leg-1", ParentID: "parent-1", Amount: money.MustParse("90"), Currency: currency, Status: enums.REVERSED.String()},
+		{TransferID: "leg-2", ParentID: "parent-1", Amount: money.MustParse("10.50"), Currency: currency, Status: statusCompleted},
+	}, nil).Once()
+
+	group, err := transferService.GetTransferGroup(context.Background(), "parent-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, enums.PARTIALLY_REFUNDED.String(), group.Status)
+	assert.Equal(t, amount, group.Amount)
+}
+
 func TestTransferServiceImpl_GetTransfer_Success(t *testing.T) {
 	mockRepo := service.NewMockTransferRepository(t)
-	transferService := service.NewTransferService(mockRepo)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
 
 	expectedTransfer := models.Transfer{
 		TransferID:  transferID,
@@ -88,24 +371,25 @@ func TestTransferServiceImpl_GetTransfer_Success(t *testing.T) {
 		Status:      statusCompleted,
 	}
 
-	mockRepo.On("GetTransfer", transferID).Return(expectedTransfer, nil).Once()
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(expectedTransfer, nil).Once()
 
-	actualTransfer, err := transferService.GetTransfer(transferID)
+	actualTransfer, err := transferService.GetTransfer(context.Background(), transferID)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedTransfer, actualTransfer)
 	mockRepo.AssertExpectations(t)
-	mockRepo.AssertCalled(t, "GetTransfer", transferID)
+	mockRepo.AssertCalled(t, "GetTransfer", mock.Anything, transferID)
 }
 
 func TestTransferServiceImpl_GetTransfer_RepositoryError(t *testing.T) {
 	mockRepo := service.NewMockTransferRepository(t)
-	transferService := service.NewTransferService(mockRepo)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
 	expectedError := errors.New("transfer not found in db")
 
-	mockRepo.On("GetTransfer", "non-existent-id").Return(models.Transfer{}, expectedError).Once()
+	mockRepo.On("GetTransfer", mock.Anything, "non-existent-id").Return(models.Transfer{}, expectedError).Once()
 
-	actualTransfer, err := transferService.GetTransfer("non-existent-id")
+	actualTransfer, err := transferService.GetTransfer(context.Background(), "non-existent-id")
 
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
@@ -115,53 +399,960 @@ func TestTransferServiceImpl_GetTransfer_RepositoryError(t *testing.T) {
 
 func TestTransferServiceImpl_GetAccountBalance_Success(t *testing.T) {
 	mockRepo := service.NewMockTransferRepository(t)
-	transferService := service.NewTransferService(mockRepo)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
 
-	mockRepo.On("GetAccountBalance", toAccount).Return(expectedBalance, nil).Once()
+	expectedBalances := []models.AccountBalance{{Currency: currency, Ledger: expectedBalance, Available: expectedBalance}}
+	mockRepo.On("GetAccountBalance", mock.Anything, toAccount).Return(expectedBalances, nil).Once()
 
-	balance, err := transferService.GetAccountBalance(toAccount)
+	balances, err := transferService.GetAccountBalance(context.Background(), toAccount)
 
 	assert.NoError(t, err)
-	assert.Equal(t, expectedBalance, balance)
+	assert.Equal(t, expectedBalances, balances)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestTransferServiceImpl_GetAccountBalance_RepositoryError(t *testing.T) {
 	mockRepo := service.NewMockTransferRepository(t)
-	transferService := service.NewTransferService(mockRepo)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
 	expectedError := errors.New("error fetching balance from repository")
 
-	mockRepo.On("GetAccountBalance", "account-id-error").Return(0.0, expectedError).Once()
+	mockRepo.On("GetAccountBalance", mock.Anything, "account-id-error").Return(nil, expectedError).Once()
 
-	balance, err := transferService.GetAccountBalance("account-id-error")
+	balances, err := transferService.GetAccountBalance(context.Background(), "account-id-error")
 
 	assert.Error(t, err)
-	assert.Equal(t, 0.0, balance)
+	assert.Nil(t, balances)
 	assert.Equal(t, expectedError, err)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestTransferServiceImpl_UpdateTransfer_Success(t *testing.T) {
 	mockRepo := new(service.MockTransferRepository)
-	transferService := service.NewTransferService(mockRepo)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
 
-	mockRepo.On("UpdateTransfer", transferID, statusCompleted).Return(nil).Once()
-	err := transferService.UpdateTransfer(transferID, statusCompleted)
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: statusPending, Version: 2}, nil).Once()
+	pinned := webhookChange(statusCompleted)
+	pinned.IfMatch = []uint{2}
+	mockRepo.On("UpdateTransfer", mock.Anything, transferID, pinned).Return(nil).Once()
+	err := transferService.UpdateTransfer(context.Background(), transferID, webhookChange(statusCompleted))
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestTransferServiceImpl_UpdateTransfer_ChangedConcurrently(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: statusPending, Version: 2}, nil).Once()
+	mockRepo.On("UpdateTransfer", mock.Anything, transferID, mock.MatchedBy(func(change models.StatusChange) bool {
+		return slices.Equal(change.IfMatch, []uint{2})
+	})).Return(repository.NewVersionMismatchError(3)).Once()
+
+	err := transferService.UpdateTransfer(context.Background(), transferID, webhookChange(statusFailed))
+
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+	assert.Equal(t, apperrors.CodeConflict, apperrors.Code(err, ""))
+}
+
+func TestTransferServiceImpl_UpdateTransfer_IllegalTransition(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: statusCompleted}, nil).Once()
+
+	err := transferService.UpdateTransfer(context.Background(), transferID, webhookChange(statusPending))
+
+	var transitionErr *enums.InvalidTransitionError
+	assert.True(t, errors.As(err, &transitionErr))
+	assert.Equal(t, enums.COMPLETED, transitionErr.From)
+	assert.Equal(t, enums.PENDING, transitionErr.To)
+	mockRepo.AssertNotCalled(t, "UpdateTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferServiceImpl_UpdateTransfer_VersionMismatch(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: statusPending, Version: 3}, nil).Once()
+
+	change := webhookChange(statusPending)
+	change.IfMatch = []uint{2}
+	err := transferService.UpdateTransfer(context.Background(), transferID, change)
+
+	assert.ErrorIs(t, err, apperrors.ErrPrecondition)
+	mockRepo.AssertNotCalled(t, "UpdateTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferServiceImpl_UpdateTransfer_InvalidStatus(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	err := transferService.UpdateTransfer(context.Background(), transferID, webhookChange("DONE"))
+
+	var statusErr *enums.InvalidStatusError
+	assert.True(t, errors.As(err, &statusErr))
+	mockRepo.AssertNotCalled(t, "GetTransfer", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "UpdateTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferServiceImpl_UpdateTransfer_SameStatusIsNoop(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: statusCompleted}, nil).Once()
+
+	err := transferService.UpdateTransfer(context.Background(), transferID, webhookChange(statusCompleted))
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "UpdateTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestTransferServiceImpl_UpdateTransfer_RepositoryError(t *testing.T) {
 	mockRepo := new(service.MockTransferRepository)
-	transferService := service.NewTransferService(mockRepo)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
 	expectedError := errors.New("error updating transfer in repository")
 
-	mockRepo.On("UpdateTransfer", "transfer-id-error", statusFailed).Return(expectedError).Once()
+	mockRepo.On("GetTransfer", mock.Anything, "transfer-id-error").Return(models.Transfer{Status: statusPending}, nil).Once()
+	mockRepo.On("UpdateTransfer", mock.Anything, "transfer-id-error", mock.AnythingOfType("models.StatusChange")).Return(expectedError).Once()
 
-	err := transferService.UpdateTransfer("transfer-id-error", statusFailed)
+	err := transferService.UpdateTransfer(context.Background(), "transfer-id-error", webhookChange(statusFailed))
 
 	assert.Error(t, err)
 	assert.Equal(t, expectedError, err)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestTransferServiceImpl_CancelTransfer_Pending(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: statusPending, Version: 2}, nil).Once()
+	mockRepo.On("UpdateTransfer", mock.Anything, transferID, models.StatusChange{
+		Status:  enums.CANCELLED.String(),
+		Source:  enums.SourceAPI,
+		Actor:   "user-1",
+		Reason:  "typo",
+		IfMatch: []uint{2},
+	}).Return(nil).Once()
+
+	err := transferService.CancelTransfer(context.Background(), transferID, models.StatusChange{Source: enums.SourceAPI, Actor: "user-1", Reason: "typo"})
+
+	assert.NoError(t, err)
+}
+
+func TestTransferServiceImpl_CancelTransfer_AlreadyCancelledIsNoop(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: enums.CANCELLED.String()}, nil).Once()
+
+	assert.NoError(t, transferService.CancelTransfer(context.Background(), transferID, models.StatusChange{Source: enums.SourceAPI}))
+	mockRepo.AssertNotCalled(t, "UpdateTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferServiceImpl_CancelTransfer_NotPending(t *testing.T) {
+	for _, status := range []string{enums.PROCESSING.String(), statusCompleted, statusFailed} {
+		t.Run(status, func(t *testing.T) {
+			mockRepo := service.NewMockTransferRepository(t)
+			mockJobs := service.NewMockJobRepository(t)
+			transferService := service.NewTransferService(mockRepo, mockJobs)
+
+			mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: status}, nil).Once()
+
+			err := transferService.CancelTransfer(context.Background(), transferID, models.StatusChange{Source: enums.SourceAPI})
+
+			assert.ErrorIs(t, err, apperrors.ErrConflict)
+			assert.Equal(t, apperrors.CodeNotCancellable, apperrors.Code(err, ""))
+			mockRepo.AssertNotCalled(t, "UpdateTransfer", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTransferServiceImpl_CancelTransfer_ChangedConcurrently(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: statusPending, Version: 1}, nil).Once()
+	mockRepo.On("UpdateTransfer", mock.Anything, transferID, mock.Anything).Return(repository.NewVersionMismatchError(2)).Once()
+
+	err := transferService.CancelTransfer(context.Background(), transferID, models.StatusChange{Source: enums.SourceAPI})
+
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+	assert.NotErrorIs(t, err, apperrors.ErrPrecondition)
+}
+
+func givenACompletedTransfer() models.Transfer {
+	return models.Transfer{TransferID: transferID, Amount: amount, Currency: currency, Status: statusCompleted, RefundedAmount: money.MustParse("0.50")}
+}
+
+func TestTransferServiceImpl_RefundTransfer_DefaultsToRemainingAmount(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	change := models.StatusChange{Source: enums.SourceAPI, Actor: "support-1"}
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(givenACompletedTransfer(), nil).Once()
+	mockRepo.On("RefundTransfer", mock.Anything, transferID, money.MustParse("100"), change).
+		Return(models.Transfer{TransferID: "refund-id", RefundOf: transferID}, nil).Once()
+
+	refund, err := transferService.RefundTransfer(context.Background(), transferID, transfers.RefundRequest{}, change)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "refund-id", refund.TransferID)
+}
+
+func TestTransferServiceImpl_RefundTransfer_ExceedsRemaining(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	requested := money.MustParse("100.01")
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(givenACompletedTransfer(), nil).Once()
+
+	_, err := transferService.RefundTransfer(context.Background(), transferID, transfers.RefundRequest{Amount: &requested}, models.StatusChange{})
+
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+	assert.Equal(t, apperrors.CodeRefundExceeds, apperrors.Code(err, ""))
+	assert.EqualError(t, err, "at most 100.00 USD can still be refunded")
+	mockRepo.AssertNotCalled(t, "RefundTransfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferServiceImpl_RefundTransfer_TooPrecise(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	requested := money.MustParse("1.001")
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(givenACompletedTransfer(), nil).Once()
+
+	_, err := transferService.RefundTransfer(context.Background(), transferID, transfers.RefundRequest{Amount: &requested}, models.StatusChange{})
+
+	var validationErr *apperrors.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, apperrors.CodeTooPrecise, validationErr.Fields[0].Code)
+}
+
+func TestTransferServiceImpl_RefundTransfer_NotCompleted(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: statusPending}, nil).Once()
+
+	_, err := transferService.RefundTransfer(context.Background(), transferID, transfers.RefundRequest{}, models.StatusChange{})
+
+	assert.Equal(t, apperrors.CodeNotRefundable, apperrors.Code(err, ""))
+}
+
+func TestTransferServiceImpl_GetTransferHistory_Success(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	expectedHistory := []models.TransferStatusHistory{
+		{TransferID: transferID, ToStatus: statusPending, Source: enums.SourceAPI.String()},
+		{TransferID: transferID, FromStatus: statusPending, ToStatus: statusCompleted, Source: enums.SourceWebhook.String()},
+	}
+	mockRepo.On("GetTransferHistory", mock.Anything, transferID).Return(expectedHistory, nil).Once()
+
+	history, err := transferService.GetTransferHistory(context.Background(), transferID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedHistory, history)
+}
+
+func TestTransferServiceImpl_GetTransferHistory_RepositoryError(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	expectedError := errors.New("transfer not found")
+
+	mockRepo.On("GetTransferHistory", mock.Anything, transferID).Return(nil, expectedError).Once()
+
+	history, err := transferService.GetTransferHistory(context.Background(), transferID)
+
+	assert.Equal(t, expectedError, err)
+	assert.Nil(t, history)
+}
+
+func TestTransferServiceImpl_ListTransfers_ReturnsNextCursor(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	rows := []models.Transfer{
+		{ID: 3, TransferID: "t-3", CreatedAt: createdAt},
+		{ID: 2, TransferID: "t-2", CreatedAt: createdAt},
+		{ID: 1, TransferID: "t-1", CreatedAt: createdAt},
+	}
+	mockRepo.On("ListTransfers", mock.Anything, models.TransferFilter{Accounts: []string{fromAccount}, Limit: 3}).Return(rows, nil).Once()
+
+	page, err := transferService.ListTransfers(context.Background(), models.TransferFilter{Accounts: []string{fromAccount}, Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, rows[:2], page.Transfers)
+	assert.Equal(t, &models.TransferCursor{CreatedAt: createdAt, ID: 2}, page.Next)
+}
+
+func TestTransferServiceImpl_ListTransfers_LastPage(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	rows := []models.Transfer{{ID: 1, TransferID: "t-1"}}
+	mockRepo.On("ListTransfers", mock.Anything, models.TransferFilter{Limit: 3}).Return(rows, nil).Once()
+
+	page, err := transferService.ListTransfers(context.Background(), models.TransferFilter{Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, rows, page.Transfers)
+	assert.Nil(t, page.Next)
+}
+
+func TestTransferServiceImpl_ListTransfers_RepositoryError(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	expectedError := errors.New("database is down")
+
+	mockRepo.On("ListTransfers", mock.Anything, mock.Anything).Return(nil, expectedError).Once()
+
+	page, err := transferService.ListTransfers(context.Background(), models.TransferFilter{Limit: 2})
+
+	assert.Equal(t, expectedError, err)
+	assert.Empty(t, page.Transfers)
+}
+
+func TestTransferServiceImpl_GetAccountStatement_RunningBalance(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	opening := []models.Balance{{Currency: "USD", Balance: money.MustParse("100")}}
+	lines := []models.StatementLine{
+		{EntryID: "e1", Currency: "EUR", Amount: money.MustParse("20")},
+		{EntryID: "e2", Currency: "USD", Amount: money.MustParse("-30.25")},
+		{EntryID: "e3", Currency: "USD", Amount: money.MustParse("5")},
+	}
+	mockRepo.On("GetStatementEntries", mock.Anything, fromAccount, from, to).Return(opening, lines, nil).Once()
+
+	statement, err := transferService.GetAccountStatement(context.Background(), fromAccount, from, to)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fromAccount, statement.AccountID)
+	assert.Len(t, statement.Currencies, 2)
+
+	eur := statement.Currencies[0]
+	assert.Equal(t, "EUR", eur.Currency)
+	assert.True(t, eur.OpeningBalance.IsZero())
+	assert.Equal(t, money.MustParse("20"), eur.ClosingBalance)
+
+	usd := statement.Currencies[1]
+	assert.Equal(t, money.MustParse("100"), usd.OpeningBalance)
+	assert.Equal(t, money.MustParse("69.75"), usd.Lines[0].Balance)
+	assert.Equal(t, money.MustParse("74.75"), usd.Lines[1].Balance)
+	assert.Equal(t, money.MustParse("74.75"), usd.ClosingBalance)
+}
+
+func TestTransferServiceImpl_GetAccountStatement_OpeningBalanceOnly(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+
+	opening := []models.Balance{{Currency: "USD", Balance: money.MustParse("10")}}
+	mockRepo.On("GetStatementEntries", mock.Anything, fromAccount, mock.Anything, mock.Anything).Return(opening, []models.StatementLine{}, nil).Once()
+
+	statement, err := transferService.GetAccountStatement(context.Background(), fromAccount, time.Now().Add(-time.Hour), time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.CurrencyStatement{{
+		Currency:       "USD",
+		OpeningBalance: money.MustParse("10"),
+		ClosingBalance: money.MustParse("10"),
+		Lines:          []models.StatementLine{},
+	}}, statement.Currencies)
+}
+
+func TestTransferServiceImpl_GetAccountStatement_RepositoryError(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	transferService := service.NewTransferService(mockRepo, mockJobs)
+	expectedError := apperrors.New(apperrors.ErrNotFound, apperrors.CodeAccountNotFound, "account not found")
+
+	mockRepo.On("GetStatementEntries", mock.Anything, fromAccount, mock.Anything, mock.Anything).Return(nil, nil, expectedError).Once()
+
+	_, err := transferService.GetAccountStatement(context.Background(), fromAccount, time.Now().Add(-time.Hour), time.Now())
+
+	assert.Equal(t, expectedError, err)
+}
+
+func TestScheduledTransferExecutor_Exhausted_FailsTransfer(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	executor := service.NewScheduledTransferExecutor(service.NewTransferService(mockRepo, mockJobs))
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: enums.SCHEDULED.String(), Version: 3}, nil).Twice()
+	mockRepo.On("UpdateTransfer", mock.Anything, transferID, mock.MatchedBy(func(c models.StatusChange) bool {
+		return c.Status == statusFailed && c.Source == enums.SourceScheduler && slices.Equal(c.IfMatch, []uint{3})
+	})).Return(nil).Once()
+
+	assert.NoError(t, executor.Exhausted(context.Background(), models.ScheduledJob{Kind: service.ExecuteScheduledTransferJob, Reference: transferID, Attempts: 5}))
+}
+
+func TestScheduledTransferExecutor_Exhausted_IgnoresReleasedTransfers(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	executor := service.NewScheduledTransferExecutor(service.NewTransferService(mockRepo, mockJobs))
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: statusPending, Version: 4}, nil).Once()
+
+	assert.NoError(t, executor.Exhausted(context.Background(), models.ScheduledJob{Kind: service.ExecuteScheduledTransferJob, Reference: transferID, Attempts: 5}))
+	mockRepo.AssertNotCalled(t, "UpdateTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestScheduledTransferExecutor_Exhausted_IgnoresTransfersReleasedWhileFailing(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	executor := service.NewScheduledTransferExecutor(service.NewTransferService(mockRepo, mockJobs))
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: enums.SCHEDULED.String(), Version: 3}, nil).Twice()
+	mockRepo.On("UpdateTransfer", mock.Anything, transferID, mock.AnythingOfType("models.StatusChange")).Return(repository.NewVersionMismatchError(4)).Once()
+
+	assert.NoError(t, executor.Exhausted(context.Background(), models.ScheduledJob{Kind: service.ExecuteScheduledTransferJob, Reference: transferID, Attempts: 5}))
+}
+
+func TestScheduledTransferExecutor_Exhausted_IgnoresCancelledTransfers(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	executor := service.NewScheduledTransferExecutor(service.NewTransferService(mockRepo, mockJobs))
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: enums.CANCELLED.String()}, nil).Once()
+
+	assert.NoError(t, executor.Exhausted(context.Background(), models.ScheduledJob{Kind: service.ExecuteScheduledTransferJob, Reference: transferID, Attempts: 5}))
+	mockRepo.AssertNotCalled(t, "UpdateTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func givenAMonitorJob(attempts int) models.ScheduledJob {
+	return models.ScheduledJob{
+		JobID:     "job-id",
+		Kind:      service.MonitorTransferJob,
+		Reference: transferID,
+		Attempts:  attempts,
+	}
+}
+
+func TestTransferMonitor_Handle_StillPending(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	monitor := service.NewTransferMonitor(service.NewTransferService(mockRepo, mockJobs))
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: statusPending}, nil).Once()
+
+	done, err := monitor.Handle(context.Background(), givenAMonitorJob(1))
+
+	assert.NoError(t, err)
+	assert.False(t, done)
+}
+
+func TestTransferMonitor_Handle_Settled(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	monitor := service.NewTransferMonitor(service.NewTransferService(mockRepo, mockJobs))
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: statusCompleted}, nil).Once()
+
+	done, err := monitor.Handle(context.Background(), givenAMonitorJob(2))
+
+	assert.NoError(t, err)
+	assert.True(t, done)
+}
+
+func TestTransferMonitor_Exhausted_ExpiresTransfer(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	monitor := service.NewTransferMonitor(service.NewTransferService(mockRepo, mockJobs))
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: statusPending}, nil).Once()
+	mockRepo.On("UpdateTransfer", mock.Anything, transferID, mock.MatchedBy(func(change models.StatusChange) bool {
+		return change.Status == enums.EXPIRED.String() && change.Source == enums.SourceMonitor
+	})).Return(nil).Once()
+
+	err := monitor.Exhausted(context.Background(), givenAMonitorJob(5))
+
+	assert.NoError(t, err)
+}
+
+func TestTransferMonitor_Exhausted_IgnoresTransfersSettledMeanwhile(t *testing.T) {
+	mockRepo := service.NewMockTransferRepository(t)
+	mockJobs := service.NewMockJobRepository(t)
+	monitor := service.NewTransferMonitor(service.NewTransferService(mockRepo, mockJobs))
+
+	mockRepo.On("GetTransfer", mock.Anything, transferID).Return(models.Transfer{TransferID: transferID, Status: statusFailed}, nil).Once()
+
+	err := monitor.Exhausted(context.Background(), givenAMonitorJob(5))
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "UpdateTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAccountServiceImpl_OpenAccount_GeneratesIDAndNormalizesCurrency(t *testing.T) {
+	mockRepo := service.NewMockAccountRepository(t)
+	accountService := service.NewAccountService(mockRepo)
+
+	mockRepo.On("CreateAccount", mock.Anything, mock.MatchedBy(func(a models.Account) bool {
+		return strings.HasPrefix(a.AccountID, "acc-") && a.Owner == "owner-1" && a.Currency == "EUR"
+	})).Return(func(_ context.Context, a models.Account) (models.Account, error) {
+		a.Status = enums.AccountActive.String()
+		return a, nil
+	}).Once()
+
+	account, err := accountService.OpenAccount(context.Background(), transfers.OpenAccountRequest{Owner: "owner-1", Currency: "eur"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, enums.AccountActive.String(), account.Status)
+	assert.Equal(t, "EUR", account.Currency)
+}
+
+func TestAccountServiceImpl_OpenAccount_InvalidRequest(t *testing.T) {
+	mockRepo := service.NewMockAccountRepository(t)
+	accountService := service.NewAccountService(mockRepo)
+
+	_, err := accountService.OpenAccount(context.Background(), transfers.OpenAccountRequest{AccountID: "bad id", Currency: "ABC"})
+
+	var validationErr *apperrors.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Len(t, validationErr.Fields, 3)
+	mockRepo.AssertNotCalled(t, "CreateAccount", mock.Anything, mock.Anything)
+}
+
+func TestAccountServiceImpl_FreezeAccount(t *testing.T) {
+	mockRepo := service.NewMockAccountRepository(t)
+	accountService := service.NewAccountService(mockRepo)
+
+	mockRepo.On("UpdateAccountStatus", mock.Anything, fromAccount, enums.AccountFrozen).
+		Return(models.Account{AccountID: fromAccount, Status: enums.AccountFrozen.String()}, nil).Once()
+
+	account, err := accountService.FreezeAccount(context.Background(), fromAccount)
+
+	assert.NoError(t, err)
+	assert.Equal(t, enums.AccountFrozen.String(), account.Status)
+}
+
+func TestAccountServiceImpl_CloseAccount_NotEmpty(t *testing.T) {
+	mockRepo := service.NewMockAccountRepository(t)
+	accountService := service.NewAccountService(mockRepo)
+	expectedError := apperrors.New(apperrors.ErrConflict, apperrors.CodeAccountNotEmpty, "account still holds funds or has transfers in flight")
+
+	mockRepo.On("UpdateAccountStatus", mock.Anything, fromAccount, enums.AccountClosed).Return(models.Account{}, expectedError).Once()
+
+	_, err := accountService.CloseAccount(context.Background(), fromAccount)
+
+	assert.Equal(t, expectedError, err)
+}
+
+const standingOrderID = "standing-order-id"
+
+type standingOrderMocks struct {
+	orders    *service.MockStandingOrderRepository
+	transfers *service.MockTransferRepository
+	jobs      *service.MockJobRepository
+}
+
+func givenAStandingOrderService(t *testing.T) (service.StandingOrderService, standingOrderMocks) {
+	mocks := standingOrderMocks{
+		orders:    service.NewMockStandingOrderRepository(t),
+		transfers: service.NewMockTransferRepository(t),
+		jobs:      service.NewMockJobRepository(t),
+	}
+	transferService := service.NewTransferService(mocks.transfers, mocks.jobs)
+	return service.NewStandingOrderService(mocks.orders, mocks.jobs, transferService), mocks
+}
+
+func givenADueStandingOrder(runAt time.Time) models.StandingOrder {
+	return models.StandingOrder{
+		OrderID:     standingOrderID,
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+		Amount:      amount,
+		Currency:    currency,
+		Frequency:   "DAILY",
+		StartAt:     runAt,
+		Status:      enums.StandingOrderActive.String(),
+		NextRunAt:   &runAt,
+		JobID:       "job-1",
+	}
+}
+
+func TestStandingOrderServiceImpl_CreateStandingOrder(t *testing.T) {
+	svc, mocks := givenAStandingOrderService(t)
+	startAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	req := transfers.StandingOrderRequest{
+		FromAccount:   fromAccount,
+		ToAccount:     toAccount,
+		Amount:        amount,
+		Currency:      "usd",
+		Frequency:     "weekly",
+		StartAt:       &startAt,
+		MaxExecutions: 4,
+	}
+
+	mocks.jobs.On("Enqueue", mock.Anything, service.RunStandingOrderJob, mock.AnythingOfType("string"), startAt).Return("job-1", nil).Once()
+	mocks.orders.On("CreateStandingOrder", mock.Anything, mock.MatchedBy(func(o models.StandingOrder) bool {
+		return o.OrderID != "" && o.Currency == currency && o.Frequency == "WEEKLY" && o.JobID == "job-1" &&
+			o.NextRunAt.Equal(startAt) && o.CreatedBy == "user-1" && o.MaxExecutions == 4
+	})).Return(func(_ context.Context, o models.StandingOrder) (models.StandingOrder, error) {
+		o.Status = enums.StandingOrderActive.String()
+		return o, nil
+	}).Once()
+
+	order, err := svc.CreateStandingOrder(context.Background(), req, "user-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, enums.StandingOrderActive.String(), order.Status)
+}
+
+func TestStandingOrderServiceImpl_CreateStandingOrder_InvalidSchedule(t *testing.T) {
+	svc, mocks := givenAStandingOrderService(t)
+	req := transfers.StandingOrderRequest{FromAccount: fromAccount, ToAccount: toAccount, Amount: amount, Currency: currency, Cron: "0 9 * *"}
+
+	_, err := svc.CreateStandingOrder(context.Background(), req, "user-1")
+
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+	mocks.jobs.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStandingOrderServiceImpl_RunStandingOrder_CreatesTransfer(t *testing.T) {
+	svc, mocks := givenAStandingOrderService(t)
+	runAt := time.Now().Add(-time.Minute).UTC()
+	next := runAt.AddDate(0, 0, 1)
+	order := givenADueStandingOrder(runAt)
+	execution := models.StandingOrderExecution{OrderID: standingOrderID, ScheduledFor: runAt, Status: enums.ExecutionRunning.String()}
+
+	mocks.orders.On("GetStandingOrder", mock.Anything, standingOrderID).Return(order, nil).Once()
+	mocks.orders.On("StartExecution", mock.Anything, standingOrderID, runAt).Return(execution, true, nil).Once()
+	mocks.transfers.On("CreateTransfer", mock.Anything, fromAccount, toAccount, amount, currency).Return(transferID, nil).Once()
+	mocks.jobs.On("Enqueue", mock.Anything, service.MonitorTransferJob, transferID, mock.AnythingOfType("time.Time")).Return("monitor-job", nil).Once()
+	mocks.orders.On("FinishExecution", mock.Anything, mock.MatchedBy(func(e models.StandingOrderExecution) bool {
+		return e.Status == enums.ExecutionCreated.String() && e.TransferID == transferID
+	}), &next).Return(func(_ context.Context, _ models.StandingOrderExecution, next *time.Time) (models.StandingOrder, error) {
+		order.Executions = 1
+		order.NextRunAt = next
+		return order, nil
+	}).Once()
+	mocks.jobs.On("Enqueue", mock.Anything, service.RunStandingOrderJob, standingOrderID, next).Return("job-2", nil).Once()
+	mocks.orders.On("SetStandingOrderJob", mock.Anything, standingOrderID, "job-1", "job-2").Return(true, nil).Once()
+
+	assert.NoError(t, svc.RunStandingOrder(context.Background(), standingOrderID, "job-1"))
+}
+
+func TestStandingOrderServiceImpl_RunStandingOrder_InsufficientFundsIsRecorded(t *testing.T) {
+	svc, mocks := givenAStandingOrderService(t)
+	runAt := time.Now().Add(-time.Minute).UTC()
+	order := givenADueStandingOrder(runAt)
+	order.MaxExecutions = 1
+	execution := models.StandingOrderExecution{OrderID: standingOrderID, ScheduledFor: runAt, Status: enums.ExecutionRunning.String()}
+
+	mocks.orders.On("GetStandingOrder", mock.Anything, standingOrderID).Return(order, nil).Once()
+	mocks.orders.On("StartExecution", mock.Anything, standingOrderID, runAt).Return(execution, true, nil).Once()
+	mocks.transfers.On("CreateTransfer", mock.Anything, fromAccount, toAccount, amount, currency).
+		Return("", apperrors.New(apperrors.ErrInsufficientFunds, apperrors.CodeInsufficientFunds, "insufficient funds in account acc-001")).Once()
+	mocks.orders.On("FinishExecution", mock.Anything, models.StandingOrderExecution{
+		OrderID:      standingOrderID,
+		ScheduledFor: runAt,
+		Status:       enums.ExecutionFailed.String(),
+		Error:        "insufficient funds in account acc-001",
+	}, (*time.Time)(nil)).Return(models.StandingOrder{OrderID: standingOrderID, Status: enums.StandingOrderFinished.String()}, nil).Once()
+
+	assert.NoError(t, svc.RunStandingOrder(context.Background(), standingOrderID, "job-1"))
+	mocks.jobs.AssertNotCalled(t, "Enqueue", mock.Anything, service.RunStandingOrderJob, mock.Anything, mock.Anything)
+}
+
+func TestStandingOrderServiceImpl_RunStandingOrder_InterruptedExecutionIsNotRepeated(t *testing.T) {
+	svc, mocks := givenAStandingOrderService(t)
+	runAt := time.Now().Add(-time.Minute).UTC()
+	order := givenADueStandingOrder(runAt)
+	order.MaxExecutions = 1
+	execution := models.StandingOrderExecution{OrderID: standingOrderID, ScheduledFor: runAt, Status: enums.ExecutionRunning.String()}
+
+	mocks.orders.On("GetStandingOrder", mock.Anything, standingOrderID).Return(order, nil).Once()
+	mocks.orders.On("StartExecution", mock.Anything, standingOrderID, runAt).Return(execution, false, nil).Once()
+	mocks.orders.On("FinishExecution", mock.Anything, mock.MatchedBy(func(e models.StandingOrderExecution) bool {
+		return e.Status == enums.ExecutionFailed.String() && strings.Contains(e.Error, "interrupted")
+	}), (*time.Time)(nil)).Return(models.StandingOrder{OrderID: standingOrderID, Status: enums.StandingOrderFinished.String()}, nil).Once()
+
+	assert.NoError(t, svc.RunStandingOrder(context.Background(), standingOrderID, "job-1"))
+	mocks.transfers.AssertNotCalled(t, "CreateTransfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStandingOrderServiceImpl_RunStandingOrder_TransientErrorIsRetried(t *testing.T) {
+	svc, mocks := givenAStandingOrderService(t)
+	runAt := time.Now().Add(-time.Minute).UTC()
+	execution := models.StandingOrderExecution{OrderID: standingOrderID, ScheduledFor: runAt, Status: enums.ExecutionRunning.String()}
+	expectedError := errors.New("database unavailable")
+
+	mocks.orders.On("GetStandingOrder", mock.Anything, standingOrderID).Return(givenADueStandingOrder(runAt), nil).Once()
+	mocks.orders.On("StartExecution", mock.Anything, standingOrderID, runAt).Return(execution, true, nil).Once()
+	mocks.transfers.On("CreateTransfer", mock.Anything, fromAccount, toAccount, amount, currency).Return("", expectedError).Once()
+	mocks.orders.On("AbandonExecution", mock.Anything, execution).Return(nil).Once()
+
+	assert.Equal(t, expectedError, svc.RunStandingOrder(context.Background(), standingOrderID, "job-1"))
+	mocks.orders.AssertNotCalled(t, "FinishExecution", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStandingOrderServiceImpl_RunStandingOrder_StaleJobDoesNothing(t *testing.T) {
+	svc, mocks := givenAStandingOrderService(t)
+	order := givenADueStandingOrder(time.Now().Add(-time.Minute))
+	order.JobID = "job-after-resume"
+
+	mocks.orders.On("GetStandingOrder", mock.Anything, standingOrderID).Return(order, nil).Once()
+
+	assert.NoError(t, svc.RunStandingOrder(context.Background(), standingOrderID, "job-1"))
+	mocks.orders.AssertNotCalled(t, "StartExecution", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStandingOrderServiceImpl_RunStandingOrder_OnlyEnqueuesWhenNotDue(t *testing.T) {
+	svc, mocks := givenAStandingOrderService(t)
+	next := time.Now().Add(time.Hour).UTC()
+
+	mocks.orders.On("GetStandingOrder", mock.Anything, standingOrderID).Return(givenADueStandingOrder(next), nil).Once()
+	mocks.jobs.On("Enqueue", mock.Anything, service.RunStandingOrderJob, standingOrderID, next).Return("job-2", nil).Once()
+	mocks.orders.On("SetStandingOrderJob", mock.Anything, standingOrderID, "job-1", "job-2").Return(true, nil).Once()
+
+	assert.NoError(t, svc.RunStandingOrder(context.Background(), standingOrderID, "job-1"))
+	mocks.orders.AssertNotCalled(t, "StartExecution", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStandingOrderServiceImpl_ResumeStandingOrder_SkipsPausedOccurrences(t *testing.T) {
+	svc, mocks := givenAStandingOrderService(t)
+	start := time.Now().AddDate(0, 0, -10).UTC().Truncate(time.Second)
+	order := givenADueStandingOrder(start)
+	order.Status = enums.StandingOrderPaused.String()
+	order.NextRunAt = nil
+	expectedNext := start.AddDate(0, 0, 11)
+
+	mocks.orders.On("GetStandingOrder", mock.Anything, standingOrderID).Return(order, nil).Once()
+	mocks.jobs.On("Enqueue", mock.Anything, service.RunStandingOrderJob, standingOrderID, expectedNext).Return("job-2", nil).Once()
+	mocks.orders.On("UpdateStandingOrderStatus", mock.Anything, standingOrderID, enums.StandingOrderActive, &expectedNext, "job-2").
+		Return(models.StandingOrder{OrderID: standingOrderID, Status: enums.StandingOrderActive.String()}, nil).Once()
+
+	resumed, err := svc.ResumeStandingOrder(context.Background(), standingOrderID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, enums.StandingOrderActive.String(), resumed.Status)
+}
+
+func TestStandingOrderServiceImpl_ResumeStandingOrder_NotPaused(t *testing.T) {
+	svc, mocks := givenAStandingOrderService(t)
+	order := givenADueStandingOrder(time.Now())
+	order.Status = enums.StandingOrderCancelled.String()
+
+	mocks.orders.On("GetStandingOrder", mock.Anything, standingOrderID).Return(order, nil).Once()
+
+	_, err := svc.ResumeStandingOrder(context.Background(), standingOrderID)
+
+	assert.ErrorIs(t, err, apperrors.ErrInvalidTransition)
+}
+
+func TestStandingOrderExecutor_Exhausted_PausesOrder(t *testing.T) {
+	svc, mocks := givenAStandingOrderService(t)
+	executor := service.NewStandingOrderExecutor(svc)
+
+	mocks.orders.On("GetStandingOrder", mock.Anything, standingOrderID).Return(givenADueStandingOrder(time.Now()), nil).Once()
+	mocks.orders.On("UpdateStandingOrderStatus", mock.Anything, standingOrderID, enums.StandingOrderPaused, (*time.Time)(nil), "").
+		Return(models.StandingOrder{Status: enums.StandingOrderPaused.String()}, nil).Once()
+
+	assert.NoError(t, executor.Exhausted(context.Background(), models.ScheduledJob{JobID: "job-1", Kind: service.RunStandingOrderJob, Reference: standingOrderID, Attempts: 5}))
+}
+
+func TestStandingOrderExecutor_Handle_MissingOrderIsDone(t *testing.T) {
+	svc, mocks := givenAStandingOrderService(t)
+	executor := service.NewStandingOrderExecutor(svc)
+
+	mocks.orders.On("GetStandingOrder", mock.Anything, standingOrderID).
+		Return(models.StandingOrder{}, apperrors.New(apperrors.ErrNotFound, apperrors.CodeOrderNotFound, "standing order not found")).Once()
+
+	done, err := executor.Handle(context.Background(), models.ScheduledJob{JobID: "job-1", Reference: standingOrderID})
+
+	assert.NoError(t, err)
+	assert.True(t, done)
+}
+
+const batchID = "batch-id"
+
+type batchMocks struct {
+	batches   *service.MockBatchRepository
+	transfers *service.MockTransferRepository
+	jobs      *service.MockJobRepository
+}
+
+func givenABatchService(t *testing.T, workers int) (service.BatchService, batchMocks) {
+	mocks := batchMocks{
+		batches:   service.NewMockBatchRepository(t),
+		transfers: service.NewMockTransferRepository(t),
+		jobs:      service.NewMockJobRepository(t),
+	}
+	transferService := service.NewTransferService(mocks.transfers, mocks.jobs)
+	return service.NewBatchService(mocks.batches, mocks.jobs, transferService, workers), mocks
+}
+
+func givenAPendingBatchRow(id uint, line int) models.TransferBatchRow {
+	row := models.TransferBatchRow{
+		BatchID:     batchID,
+		Line:        line,
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+		Amount:      amount,
+		Currency:    currency,
+		Status:      enums.BatchRowPending.String(),
+	}
+	row.ID = id
+	return row
+}
+
+func TestBatchServiceImpl_CreateBatch(t *testing.T) {
+	svc, mocks := givenABatchService(t, 2)
+	var invalid apperrors.ValidationError
+	invalid.Add("amount", apperrors.CodeMustBePositive, "must be greater than zero")
+	rows := []transfers.BatchRow{
+		{Line: 2, Request: transfers.TransferRequest{FromAccount: fromAccount, ToAccount: toAccount, Amount: amount, Currency: currency}},
+		{Line: 3, Request: transfers.TransferRequest{FromAccount: fromAccount, ToAccount: toAccount, Currency: currency}, Err: invalid.Err()},
+	}
+
+	mocks.jobs.On("Enqueue", mock.Anything, service.ProcessBatchJob, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return("job-1", nil).Once()
+	mocks.batches.On("CreateBatch", mock.Anything, mock.MatchedBy(func(b models.TransferBatch) bool {
+		return b.BatchID != "" && b.Format == transfers.BatchCSV && b.CreatedBy == "user-1"
+	}), mock.MatchedBy(func(stored []models.TransferBatchRow) bool {
+		return len(stored) == 2 &&
+			stored[0].Line == 2 && stored[0].Status == "" && stored[0].Amount == amount &&
+			stored[1].Line == 3 && stored[1].Status == enums.BatchRowFailed.String() &&
+			stored[1].ErrorCode == apperrors.CodeValidationFailed && stored[1].Fields[0].Field == "amount"
+	})).Return(func(_ context.Context, b models.TransferBatch, _ []models.TransferBatchRow) (models.TransferBatch, error) {
+		b.Status = enums.BatchProcessing.String()
+		return b, nil
+	}).Once()
+
+	batch, err := svc.CreateBatch(context.Background(), transfers.BatchCSV, rows, "user-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, enums.BatchProcessing.String(), batch.Status)
+}
+
+func TestBatchServiceImpl_CreateBatch_NothingToProcess(t *testing.T) {
+	svc, mocks := givenABatchService(t, 2)
+	var invalid apperrors.ValidationError
+	invalid.Add("line", apperrors.CodeInvalidFormat, "has 2 fields, the header has 4")
+	rows := []transfers.BatchRow{{Line: 2, Err: invalid.Err()}}
+
+	mocks.batches.On("CreateBatch", mock.Anything, mock.Anything, mock.Anything).
+		Return(models.TransferBatch{BatchID: batchID, Status: enums.BatchCompleted.String()}, nil).Once()
+
+	_, err := svc.CreateBatch(context.Background(), transfers.BatchCSV, rows, "user-1")
+
+	assert.NoError(t, err)
+	mocks.jobs.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBatchServiceImpl_ProcessBatch_RecordsEachRow(t *testing.T) {
+	svc, mocks := givenABatchService(t, 2)
+	funded := givenAPendingBatchRow(1, 2)
+	unfunded := givenAPendingBatchRow(2, 3)
+	unfunded.FromAccount = "acc-003"
+
+	mocks.batches.On("GetBatch", mock.Anything, batchID).Return(models.TransferBatch{BatchID: batchID, Status: enums.BatchProcessing.String()}, nil).Once()
+	mocks.batches.On("FailBatchRows", mock.Anything, batchID, enums.BatchRowRunning, mock.MatchedBy(func(message string) bool {
+		return strings.Contains(message, "interrupted")
+	})).Return(int64(1), nil).Once()
+	mocks.batches.On("PendingBatchRows", mock.Anything, batchID, mock.AnythingOfType("int")).Return([]models.TransferBatchRow{funded, unfunded}, nil).Once()
+	mocks.batches.On("StartBatchRow", mock.Anything, mock.Anything).Return(true, nil).Twice()
+	mocks.transfers.On("CreateTransfer", mock.Anything, fromAccount, toAccount, amount, currency).Return(transferID, nil).Once()
+	mocks.jobs.On("Enqueue", mock.Anything, service.MonitorTransferJob, transferID, mock.AnythingOfType("time.Time")).Return("monitor-job", nil).Once()
+	mocks.transfers.On("CreateTransfer", mock.Anything, "acc-003", toAccount, amount, currency).
+		Return("", apperrors.New(apperrors.ErrInsufficientFunds, apperrors.CodeInsufficientFunds, "insufficient funds in account acc-003")).Once()
+	mocks.batches.On("FinishBatchRow", mock.Anything, mock.MatchedBy(func(r models.TransferBatchRow) bool {
+		return r.Line == 2 && r.Status == enums.BatchRowCreated.String() && r.TransferID == transferID
+	})).Return(nil).Once()
+	mocks.batches.On("FinishBatchRow", mock.Anything, mock.MatchedBy(func(r models.TransferBatchRow) bool {
+		return r.Line == 3 && r.Status == enums.BatchRowFailed.String() && r.ErrorCode == apperrors.CodeInsufficientFunds
+	})).Return(nil).Once()
+	mocks.batches.On("PendingBatchRows", mock.Anything, batchID, mock.AnythingOfType("int")).Return([]models.TransferBatchRow{}, nil).Once()
+	mocks.batches.On("CompleteBatch", mock.Anything, batchID).Return(nil).Once()
+
+	assert.NoError(t, svc.ProcessBatch(context.Background(), batchID))
+}
+
+func TestBatchServiceImpl_ProcessBatch_TransientErrorIsRetried(t *testing.T) {
+	svc, mocks := givenABatchService(t, 1)
+	row := givenAPendingBatchRow(1, 2)
+	expectedError := errors.New("database unavailable")
+
+	mocks.batches.On("GetBatch", mock.Anything, batchID).Return(models.TransferBatch{BatchID: batchID, Status: enums.BatchProcessing.String()}, nil).Once()
+	mocks.batches.On("FailBatchRows", mock.Anything, batchID, enums.BatchRowRunning, mock.Anything).Return(int64(0), nil).Once()
+	mocks.batches.On("PendingBatchRows", mock.Anything, batchID, mock.AnythingOfType("int")).Return([]models.TransferBatchRow{row}, nil).Once()
+	mocks.batches.On("StartBatchRow", mock.Anything, row).Return(true, nil).Once()
+	mocks.transfers.On("CreateTransfer", mock.Anything, fromAccount, toAccount, amount, currency).Return("", expectedError).Once()
+	mocks.batches.On("ReleaseBatchRow", mock.Anything, row).Return(nil).Once()
+
+	assert.Equal(t, expectedError, svc.ProcessBatch(context.Background(), batchID))
+	mocks.batches.AssertNotCalled(t, "FinishBatchRow", mock.Anything, mock.Anything)
+	mocks.batches.AssertNotCalled(t, "CompleteBatch", mock.Anything, mock.Anything)
+}
+
+func TestBatchServiceImpl_ProcessBatch_HandsOverBeforeLeaseRunsOut(t *testing.T) {
+	svc, mocks := givenABatchService(t, 1)
+	row := givenAPendingBatchRow(1, 2)
+	ctx, cancel := context.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer cancel()
+
+	mocks.batches.On("GetBatch", mock.Anything, batchID).Return(models.TransferBatch{BatchID: batchID, Status: enums.BatchProcessing.String()}, nil).Once()
+	mocks.batches.On("FailBatchRows", mock.Anything, batchID, enums.BatchRowRunning, mock.Anything).Return(int64(0), nil).Once()
+	mocks.batches.On("PendingBatchRows", mock.Anything, batchID, mock.AnythingOfType("int")).Return([]models.TransferBatchRow{row}, nil).Once()
+	mocks.batches.On("StartBatchRow", mock.Anything, row).Return(true, nil).Once()
+	mocks.transfers.On("CreateTransfer", mock.Anything, fromAccount, toAccount, amount, currency).
+		After(250*time.Millisecond).Return(transferID, nil).Once()
+	mocks.jobs.On("Enqueue", mock.Anything, service.MonitorTransferJob, transferID, mock.AnythingOfType("time.Time")).Return("monitor-job", nil).Once()
+	mocks.batches.On("FinishBatchRow", mock.Anything, mock.Anything).Return(nil).Once()
+	mocks.jobs.On("Enqueue", mock.Anything, service.ProcessBatchJob, batchID, mock.AnythingOfType("time.Time")).Return("job-2", nil).Once()
+
+	assert.NoError(t, svc.ProcessBatch(ctx, batchID))
+	mocks.batches.AssertNotCalled(t, "CompleteBatch", mock.Anything, mock.Anything)
+}
+
+func TestBatchExecutor_Exhausted_FailsRemainingRows(t *testing.T) {
+	svc, mocks := givenABatchService(t, 1)
+	executor := service.NewBatchExecutor(svc)
+
+	mocks.batches.On("FailBatchRows", mock.Anything, batchID, enums.BatchRowRunning, mock.Anything).Return(int64(0), nil).Once()
+	mocks.batches.On("FailBatchRows", mock.Anything, batchID, enums.BatchRowPending, mock.Anything).Return(int64(3), nil).Once()
+	mocks.batches.On("CompleteBatch", mock.Anything, batchID).Return(nil).Once()
+
+	assert.NoError(t, executor.Exhausted(context.Background(), models.ScheduledJob{JobID: "job-1", Kind: service.ProcessBatchJob, Reference: batchID, Attempts: 5}))
+}
+
+func TestBatchExecutor_Handle_MissingBatchIsDone(t *testing.T) {
+	svc, mocks := givenABatchService(t, 1)
+	executor := service.NewBatchExecutor(svc)
+
+	mocks.batches.On("GetBatch", mock.Anything, batchID).
+		Return(models.TransferBatch{}, apperrors.New(apperrors.ErrNotFound, apperrors.CodeBatchNotFound, "batch not found")).Once()
+
+	done, err := executor.Handle(context.Background(), models.ScheduledJob{JobID: "job-1", Reference: batchID})
+
+	assert.NoError(t, err)
+	assert.True(t, done)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/logging"
+	"secure-payment-service/internal/models"
+)
+
+const RunStandingOrderJob = "standing_order"
+
+// StandingOrderExecutor is the scheduled job handler that runs each
+// occurrence of a standing order.
+type StandingOrderExecutor struct {
+	standingOrderService StandingOrderService
+}
+
+func NewStandingOrderExecutor(svc StandingOrderService) *StandingOrderExecutor {
+	return &StandingOrderExecutor{standingOrderService: svc}
+}
+
+func (e *StandingOrderExecutor) Handle(ctx context.Context, job models.ScheduledJob) (bool, error) {
+	err := e.standingOrderService.RunStandingOrder(ctx, job.Reference, job.JobID)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		// The order failed to save after its first job was enqueued.
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Exhausted pauses the order so it does not silently stop running; its owner
+// sees it PAUSED and can resume it. Jobs the order no longer relies on are
+// left alone.
+func (e *StandingOrderExecutor) Exhausted(ctx context.Context, job models.ScheduledJob) error {
+	order, err := e.standingOrderService.GetStandingOrder(ctx, job.Reference)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if order.JobID != job.JobID {
+		return nil
+	}
+
+	logging.Logger.WithFields(logrus.Fields{
+		"standing_order_id": job.Reference,
+		"attempts":          job.Attempts,
+	}).Warn("standing order could not be run, pausing it")
+
+	_, err = e.standingOrderService.PauseStandingOrder(ctx, job.Reference)
+	if errors.Is(err, apperrors.ErrInvalidTransition) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/logging"
+	"secure-payment-service/internal/metrics"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/recurrence"
+	"secure-payment-service/internal/repository"
+	"secure-payment-service/internal/transfers"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	CreateStandingOrder       = "create_standing_order"
+	GetStandingOrder          = "get_standing_order"
+	ListStandingOrders        = "list_standing_orders"
+	UpdateStandingOrderStatus = "update_standing_order_status"
+	RunStandingOrder          = "run_standing_order"
+	ListOrderExecutions       = "list_standing_order_executions"
+)
+
+type StandingOrderService interface {
+	CreateStandingOrder(ctx context.Context, req transfers.StandingOrderRequest, createdBy string) (models.StandingOrder, error)
+	GetStandingOrder(ctx context.Context, id string) (models.StandingOrder, error)
+	ListStandingOrders(ctx context.Context, accounts []string, limit int) ([]models.StandingOrder, error)
+	ListExecutions(ctx context.Context, id string, limit int) ([]models.StandingOrderExecution, error)
+	PauseStandingOrder(ctx context.Context, id string) (models.StandingOrder, error)
+	ResumeStandingOrder(ctx context.Context, id string) (models.StandingOrder, error)
+	CancelStandingOrder(ctx context.Context, id string) (models.StandingOrder, error)
+	RunStandingOrder(ctx context.Context, id, jobID string) error
+}
+
+// StandingOrderServiceImpl keeps one scheduled job per ACTIVE order, for its
+// next occurrence, and creates each transfer through the TransferService like
+// any other client would.
+type StandingOrderServiceImpl struct {
+	repo            repository.StandingOrderRepository
+	jobs            repository.JobRepository
+	transferService TransferService
+}
+
+func NewStandingOrderService(repo repository.StandingOrderRepository, jobs repository.JobRepository, transferService TransferService) StandingOrderService {
+	return &StandingOrderServiceImpl{repo: repo, jobs: jobs, transferService: transferService}
+}
+
+func (s *StandingOrderServiceImpl) CreateStandingOrder(ctx context.Context, req transfers.StandingOrderRequest, createdBy string) (models.StandingOrder, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(CreateStandingOrder, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	order, err := s.create(ctx, req, createdBy)
+	if err != nil {
+		metrics.ServiceOperationsTotal.WithLabelValues(CreateStandingOrder, StatusFailure).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(CreateStandingOrder, StatusFailure).Observe(0)
+		return models.StandingOrder{}, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(CreateStandingOrder, StatusSuccess).Inc()
+	return order, nil
+}
+
+func (s *StandingOrderServiceImpl) create(ctx context.Context, req transfers.StandingOrderRequest, createdBy string) (models.StandingOrder, error) {
+	if err := req.Validate(); err != nil {
+		return models.StandingOrder{}, err
+	}
+
+	order := models.StandingOrder{
+		OrderID:       uuid.New().String(),
+		FromAccount:   req.FromAccount,
+		ToAccount:     req.ToAccount,
+		Amount:        req.Amount,
+		Currency:      strings.ToUpper(req.Currency),
+		Frequency:     strings.ToUpper(req.Frequency),
+		Cron:          req.Cron,
+		StartAt:       time.Now().UTC().Truncate(time.Second),
+		EndAt:         req.EndAt,
+		MaxExecutions: req.MaxExecutions,
+		CreatedBy:     createdBy,
+	}
+	if req.StartAt != nil {
+		order.StartAt = *req.StartAt
+	}
+
+	order.NextRunAt = nextOccurrence(order, 0, order.StartAt.Add(-time.Nanosecond))
+	if order.NextRunAt == nil {
+		var errs apperrors.ValidationError
+		errs.Add("end_at", apperrors.CodeInvalidFormat, "leaves no occurrence of the schedule")
+		return models.StandingOrder{}, errs.Err()
+	}
+
+	// The job goes first so the order is never stored without one. If the
+	// order then fails to save, the job finds nothing to run.
+	jobID, err := s.jobs.Enqueue(ctx, RunStandingOrderJob, order.OrderID, *order.NextRunAt)
+	if err != nil {
+		return models.StandingOrder{}, err
+	}
+	order.JobID = jobID
+
+	return s.repo.CreateStandingOrder(ctx, order)
+}
+
+func (s *StandingOrderServiceImpl) GetStandingOrder(ctx context.Context, id string) (models.StandingOrder, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(GetStandingOrder, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	order, err := s.repo.GetStandingOrder(ctx, id)
+	if err != nil {
+		statusLabel := StatusFailure
+		if errors.Is(err, apperrors.ErrNotFound) {
+			statusLabel = StatusNotFound
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(GetStandingOrder, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(GetStandingOrder, statusLabel).Observe(0)
+		return models.StandingOrder{}, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(GetStandingOrder, StatusSuccess).Inc()
+	return order, nil
+}
+
+func (s *StandingOrderServiceImpl) ListStandingOrders(ctx context.Context, accounts []string, limit int) ([]models.StandingOrder, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(ListStandingOrders, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	orders, err := s.repo.ListStandingOrders(ctx, accounts, limit)
+	if err != nil {
+		metrics.ServiceOperationsTotal.WithLabelValues(ListStandingOrders, StatusFailure).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(ListStandingOrders, StatusFailure).Observe(0)
+		return nil, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(ListStandingOrders, StatusSuccess).Inc()
+	return orders, nil
+}
+
+func (s *StandingOrderServiceImpl) ListExecutions(ctx context.Context, id string, limit int) ([]models.StandingOrderExecution, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(ListOrderExecutions, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	executions, err := s.repo.ListExecutions(ctx, id, limit)
+	if err != nil {
+		metrics.ServiceOperationsTotal.WithLabelValues(ListOrderExecutions, StatusFailure).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(ListOrderExecutions, StatusFailure).Observe(0)
+		return nil, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(ListOrderExecutions, StatusSuccess).Inc()
+	return executions, nil
+}
+
+// PauseStandingOrder stops an order from running until it is resumed. The
+// pending job is left in place and does nothing when it fires.
+func (s *StandingOrderServiceImpl) PauseStandingOrder(ctx context.Context, id string) (models.StandingOrder, error) {
+	return s.updateStatus(ctx, id, func() (models.StandingOrder, error) {
+		return s.repo.UpdateStandingOrderStatus(ctx, id, enums.StandingOrderPaused, nil, "")
+	})
+}
+
+// ResumeStandingOrder reactivates a paused order from its next occurrence
+// after now; occurrences that fell within the pause are skipped. An order
+// with no occurrence left is FINISHED instead.
+func (s *StandingOrderServiceImpl) ResumeStandingOrder(ctx context.Context, id string) (models.StandingOrder, error) {
+	return s.updateStatus(ctx, id, func() (models.StandingOrder, error) {
+		order, err := s.repo.GetStandingOrder(ctx, id)
+		if err != nil {
+			return models.StandingOrder{}, err
+		}
+		if order.Status != enums.StandingOrderPaused.String() {
+			return models.StandingOrder{}, enums.ValidateStandingOrderTransition(enums.StandingOrderStatus(order.Status), enums.StandingOrderActive)
+		}
+
+		next := nextOccurrence(order, order.Executions, time.Now())
+		if next == nil {
+			return s.repo.UpdateStandingOrderStatus(ctx, id, enums.StandingOrderFinished, nil, "")
+		}
+
+		jobID, err := s.jobs.Enqueue(ctx, RunStandingOrderJob, id, *next)
+		if err != nil {
+			return models.StandingOrder{}, err
+		}
+		return s.repo.UpdateStandingOrderStatus(ctx, id, enums.StandingOrderActive, next, jobID)
+	})
+}
+
+func (s *StandingOrderServiceImpl) CancelStandingOrder(ctx context.Context, id string) (models.StandingOrder, error) {
+	return s.updateStatus(ctx, id, func() (models.StandingOrder, error) {
+		return s.repo.UpdateStandingOrderStatus(ctx, id, enums.StandingOrderCancelled, nil, "")
+	})
+}
+
+func (s *StandingOrderServiceImpl) updateStatus(ctx context.Context, id string, update func() (models.StandingOrder, error)) (models.StandingOrder, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(UpdateStandingOrderStatus, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	order, err := update()
+	if err != nil {
+		statusLabel := StatusFailure
+		switch {
+		case errors.Is(err, apperrors.ErrInvalidTransition):
+			statusLabel = StatusConflict
+		case errors.Is(err, apperrors.ErrNotFound):
+			statusLabel = StatusNotFound
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(UpdateStandingOrderStatus, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(UpdateStandingOrderStatus, statusLabel).Observe(0)
+		return models.StandingOrder{}, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(UpdateStandingOrderStatus, StatusSuccess).Inc()
+	return order, nil
+}
+
+// RunStandingOrder is called by the job jobID. If that job still belongs to
+// the order and the next occurrence is due, it creates the transfer and
+// records the execution; then it enqueues the job for the following
+// occurrence. Returning an error retries the whole step, which picks up
+// where the previous attempt stopped.
+func (s *StandingOrderServiceImpl) RunStandingOrder(ctx context.Context, id, jobID string) error {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(RunStandingOrder, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	err := s.run(ctx, id, jobID)
+	if err != nil {
+		statusLabel := StatusFailure
+		if errors.Is(err, apperrors.ErrNotFound) {
+			statusLabel = StatusNotFound
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(RunStandingOrder, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(RunStandingOrder, statusLabel).Observe(0)
+		return err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(RunStandingOrder, StatusSuccess).Inc()
+	return nil
+}
+
+func (s *StandingOrderServiceImpl) run(ctx context.Context, id, jobID string) error {
+	order, err := s.repo.GetStandingOrder(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if order.JobID != jobID || order.Status != enums.StandingOrderActive.String() || order.NextRunAt == nil {
+		return nil
+	}
+
+	if !order.NextRunAt.After(time.Now()) {
+		if order, err = s.execute(ctx, order); err != nil {
+			return err
+		}
+		if order.Status != enums.StandingOrderActive.String() || order.NextRunAt == nil {
+			return nil
+		}
+	}
+
+	next, err := s.jobs.Enqueue(ctx, RunStandingOrderJob, id, *order.NextRunAt)
+	if err != nil {
+		return err
+	}
+	if _, err := s.repo.SetStandingOrderJob(ctx, id, jobID, next); err != nil {
+		return err
+	}
+	return nil
+}
+
+// execute runs the due occurrence of order. Failures that retrying cannot
+// fix, like missing funds or a frozen account, are recorded on the execution
+// and the order moves on; anything else abandons the occurrence so it is
+// retried.
+func (s *StandingOrderServiceImpl) execute(ctx context.Context, order models.StandingOrder) (models.StandingOrder, error) {
+	execution, started, err := s.repo.StartExecution(ctx, order.OrderID, *order.NextRunAt)
+	if err != nil {
+		return models.StandingOrder{}, err
+	}
+
+	log := logging.Logger.WithFields(logrus.Fields{
+		"standing_order_id": order.OrderID,
+		"scheduled_for":     execution.ScheduledFor,
+	})
+
+	switch {
+	case !started && execution.Status == enums.ExecutionRunning.String():
+		// An earlier attempt stopped between claiming the occurrence and
+		// recording its transfer. The transfer may exist, so it is not
+		// created again.
+		log.Warn("standing order execution was interrupted, not retrying it")
+		execution.Status = enums.ExecutionFailed.String()
+		execution.Error = "execution was interrupted; check the account's transfers before creating it again"
+	case started:
+		transferID, err := s.transferService.CreateTransfer(ctx, transfers.TransferRequest{
+			FromAccount: order.FromAccount,
+			ToAccount:   order.ToAccount,
+			Amount:      order.Amount,
+			Currency:    order.Currency,
+		})
+		switch {
+		case err == nil:
+			execution.Status = enums.ExecutionCreated.String()
+			execution.TransferID = transferID
+		case errors.Is(err, apperrors.ErrValidation), errors.Is(err, apperrors.ErrConflict), errors.Is(err, apperrors.ErrInsufficientFunds):
+			log.WithError(err).Warn("standing order execution failed")
+			execution.Status = enums.ExecutionFailed.String()
+			execution.Error = err.Error()
+		default:
+			if abandonErr := s.repo.AbandonExecution(context.WithoutCancel(ctx), execution); abandonErr != nil {
+				log.WithError(abandonErr).Error("failed to release standing order execution")
+			}
+			return models.StandingOrder{}, err
+		}
+	}
+
+	next := nextOccurrence(order, order.Executions+1, execution.ScheduledFor)
+	return s.repo.FinishExecution(context.WithoutCancel(ctx), execution, next)
+}
+
+// nextOccurrence returns the first occurrence of order after t, or nil when
+// the order is over once it has run executions times.
+func nextOccurrence(order models.StandingOrder, executions int, t time.Time) *time.Time {
+	if order.MaxExecutions > 0 && executions >= order.MaxExecutions {
+		return nil
+	}
+
+	var schedule recurrence.Schedule
+	var err error
+	if order.Cron != "" {
+		schedule, err = recurrence.ParseCron(order.Cron, time.UTC)
+	} else {
+		schedule, err = recurrence.Every(recurrence.Frequency(order.Frequency), order.StartAt)
+	}
+	if err != nil {
+		return nil
+	}
+
+	next := schedule.Next(t)
+	if next.IsZero() || (order.EndAt != nil && next.After(*order.EndAt)) {
+		return nil
+	}
+	return &next
+}
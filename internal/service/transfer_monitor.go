@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/logging"
+	"secure-payment-service/internal/metrics"
+	"secure-payment-service/internal/models"
+)
+
+const (
+	MonitorTransferJob     = "transfer_monitor"
+	monitorFirstCheckDelay = 5 * time.Second
+)
+
+// TransferMonitor is the scheduled job handler that follows a transfer until
+// the provider settles it, and expires the transfer when it never does.
+type TransferMonitor struct {
+	transferService TransferService
+}
+
+func NewTransferMonitor(svc TransferService) *TransferMonitor {
+	return &TransferMonitor{transferService: svc}
+}
+
+func (m *TransferMonitor) Handle(ctx context.Context, job models.ScheduledJob) (bool, error) {
+	attempt := fmt.Sprintf("%d", job.Attempts)
+
+	transfer, err := m.transferService.GetTransfer(ctx, job.Reference)
+	if err != nil {
+		metrics.TransferMonitorAttemptsTotal.WithLabelValues(job.Reference, attempt, "error").Inc()
+		return false, err
+	}
+
+	status := enums.TransactionStatus(transfer.Status)
+	if status == enums.PENDING || status == enums.PROCESSING {
+		metrics.TransferMonitorAttemptsTotal.WithLabelValues(job.Reference, attempt, "still_pending").Inc()
+		return false, nil
+	}
+
+	metrics.TransferMonitorAttemptsTotal.WithLabelValues(job.Reference, attempt, StatusSuccess).Inc()
+	return true, nil
+}
+
+func (m *TransferMonitor) Exhausted(ctx context.Context, job models.ScheduledJob) error {
+	metrics.TransferMonitorAttemptsTotal.WithLabelValues(job.Reference, fmt.Sprintf("%d", job.Attempts), "max_attempts_reached").Inc()
+
+	logging.Logger.WithFields(logrus.Fields{
+		"transfer_id": job.Reference,
+		"attempts":    job.Attempts,
+	}).Warn("transfer was not settled in time, marking it as expired")
+
+	err := m.transferService.UpdateTransfer(ctx, job.Reference, models.StatusChange{
+		Status: enums.EXPIRED.String(),
+		Source: enums.SourceMonitor,
+		Reason: fmt.Sprintf("not settled after %d checks", job.Attempts),
+	})
+
+	if errors.Is(err, apperrors.ErrInvalidTransition) {
+		// The transfer settled between the last check and now.
+		return nil
+	}
+	return err
+}
@@ -1,13 +1,19 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"secure-payment-service/internal/apperrors"
 	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/logging"
 	"secure-payment-service/internal/metrics"
 	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/money"
 	"secure-payment-service/internal/repository"
 	"secure-payment-service/internal/transfers"
 
@@ -15,37 +21,75 @@ import (
 )
 
 const (
-	StatusSuccess     = "success"
-	StatusFailure     = "failure"
-	StatusNotFound    = "not_found"
-	CreateTransfer    = "create_transfer"
-	GetTransfer       = "get_transfer"
-	GetAccountBalance = "get_account_balance"
-	UpdateTransfer    = "update_transfer"
-	maxAttempts       = 5
-	baseDelay         = 5
+	StatusSuccess      = "success"
+	StatusFailure      = "failure"
+	StatusNotFound     = "not_found"
+	StatusConflict     = "conflict"
+	CreateTransfer     = "create_transfer"
+	GetTransfer        = "get_transfer"
+	GetAccountBalance  = "get_account_balance"
+	UpdateTransfer     = "update_transfer"
+	GetTransferHistory = "get_transfer_history"
+	ListTransfers      = "list_transfers"
+	GetStatement       = "get_statement"
+	CancelTransfer     = "cancel_transfer"
+	RefundTransfer     = "refund_transfer"
+	ExecuteScheduled   = "execute_scheduled_transfer"
+	UpdateScheduled    = "update_scheduled_transfer"
+	ListScheduled      = "list_scheduled_transfers"
+	CreateGroup        = "create_transfer_group"
+	GetGroup           = "get_transfer_group"
 )
 
 type TransferService interface {
-	CreateTransfer(req transfers.TransferRequest) (string, error)
-	GetTransfer(id string) (models.Transfer, error)
-	GetAccountBalance(id string) (float64, error)
-	UpdateTransfer(id, status string) error
+	CreateTransfer(ctx context.Context, req transfers.TransferRequest) (string, error)
+	GetTransfer(ctx context.Context, id string) (models.Transfer, error)
+	GetAccountBalance(ctx context.Context, id string) ([]models.AccountBalance, error)
+	UpdateTransfer(ctx context.Context, id string, change models.StatusChange) error
+	GetTransferHistory(ctx context.Context, id string) ([]models.TransferStatusHistory, error)
+	ListTransfers(ctx context.Context, filter models.TransferFilter) (models.TransferPage, error)
+	GetAccountStatement(ctx context.Context, id string, from, to time.Time) (models.Statement, error)
+	CancelTransfer(ctx context.Context, id string, change models.StatusChange) error
+	RefundTransfer(ctx context.Context, id string, req transfers.RefundRequest, change models.StatusChange) (models.Transfer, error)
+	ExecuteScheduledTransfer(ctx context.Context, id string) error
+	UpdateScheduledTransfer(ctx context.Context, id string, req transfers.ScheduledTransferUpdate, ifMatch []uint) (models.Transfer, error)
+	ListScheduledTransfers(ctx context.Context, accounts []string, limit int) ([]models.Transfer, error)
+	CreateTransferGroup(ctx context.Context, req transfers.TransferGroupRequest) (models.TransferGroup, error)
+	GetTransferGroup(ctx context.Context, id string) (models.TransferGroup, error)
 }
 
 type TransferServiceImpl struct {
 	repo repository.TransferRepository
+	jobs repository.JobRepository
 }
 
-func NewTransferService(repo repository.TransferRepository) TransferService {
-	return &TransferServiceImpl{repo: repo}
+func NewTransferService(repo repository.TransferRepository, jobs repository.JobRepository) TransferService {
+	return &TransferServiceImpl{repo: repo, jobs: jobs}
 }
 
-func (s *TransferServiceImpl) CreateTransfer(req transfers.TransferRequest) (string, error) {
+func (s *TransferServiceImpl) CreateTransfer(ctx context.Context, req transfers.TransferRequest) (string, error) {
 	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(CreateTransfer, StatusSuccess))
 	defer timer.ObserveDuration()
 
-	id, err := s.repo.CreateTransfer(req.FromAccount, req.ToAccount, req.Amount)
+	if err := req.Validate(); err != nil {
+		metrics.ServiceOperationsTotal.WithLabelValues(CreateTransfer, StatusFailure).Inc()
+		return "", err
+	}
+	currency := strings.ToUpper(req.Currency)
+
+	if req.ExecuteAt != nil {
+		id, err := s.schedule(ctx, req, currency)
+		if err != nil {
+			metrics.ServiceOperationsTotal.WithLabelValues(CreateTransfer, StatusFailure).Inc()
+			timer.ObserveDuration()
+			metrics.ServiceOperationDurationSeconds.WithLabelValues(CreateTransfer, StatusFailure).Observe(0)
+			return "", err
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(CreateTransfer, StatusSuccess).Inc()
+		return id, nil
+	}
+
+	id, err := s.repo.CreateTransfer(ctx, req.FromAccount, req.ToAccount, req.Amount, currency)
 	if err != nil {
 		metrics.ServiceOperationsTotal.WithLabelValues(CreateTransfer, StatusFailure).Inc()
 		timer.ObserveDuration()
@@ -54,19 +98,76 @@ func (s *TransferServiceImpl) CreateTransfer(req transfers.TransferRequest) (str
 	}
 
 	metrics.ServiceOperationsTotal.WithLabelValues(CreateTransfer, StatusSuccess).Inc()
-	go s.MonitorTransfer(id)
+	// The transfer exists now, so schedule its monitor even if the caller has
+	// already gone away.
+	if _, err := s.jobs.Enqueue(context.WithoutCancel(ctx), MonitorTransferJob, id, time.Now().Add(monitorFirstCheckDelay)); err != nil {
+		logging.Logger.WithError(err).WithField("transfer_id", id).Error("failed to schedule transfer monitor")
+	}
 
 	return id, nil
 }
 
-func (s *TransferServiceImpl) GetTransfer(id string) (models.Transfer, error) {
+// CreateTransferGroup creates every leg of a multi-leg transfer, or none of
+// them, and monitors each leg like a single transfer.
+func (s *TransferServiceImpl) CreateTransferGroup(ctx context.Context, req transfers.TransferGroupRequest) (models.TransferGroup, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(CreateGroup, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	if err := req.Validate(); err != nil {
+		metrics.ServiceOperationsTotal.WithLabelValues(CreateGroup, StatusFailure).Inc()
+		return models.TransferGroup{}, err
+	}
+
+	legs := make([]models.TransferLeg, len(req.Legs))
+	for i, leg := range req.Legs {
+		legs[i] = models.TransferLeg{ToAccount: leg.ToAccount, Amount: leg.Amount}
+	}
+	created, err := s.repo.CreateTransferGroup(ctx, req.FromAccount, strings.ToUpper(req.Currency), legs)
+	if err != nil {
+		metrics.ServiceOperationsTotal.WithLabelValues(CreateGroup, StatusFailure).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(CreateGroup, StatusFailure).Observe(0)
+		return models.TransferGroup{}, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(CreateGroup, StatusSuccess).Inc()
+	for _, leg := range created {
+		if _, err := s.jobs.Enqueue(context.WithoutCancel(ctx), MonitorTransferJob, leg.TransferID, time.Now().Add(monitorFirstCheckDelay)); err != nil {
+			logging.Logger.WithError(err).WithField("transfer_id", leg.TransferID).Error("failed to schedule transfer monitor")
+		}
+	}
+
+	return models.NewTransferGroup(created), nil
+}
+
+func (s *TransferServiceImpl) GetTransferGroup(ctx context.Context, id string) (models.TransferGroup, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(GetGroup, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	legs, err := s.repo.GetTransferGroup(ctx, id)
+	if err != nil {
+		statusLabel := StatusFailure
+		if errors.Is(err, apperrors.ErrNotFound) {
+			statusLabel = StatusNotFound
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(GetGroup, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(GetGroup, statusLabel).Observe(0)
+		return models.TransferGroup{}, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(GetGroup, StatusSuccess).Inc()
+	return models.NewTransferGroup(legs), nil
+}
+
+func (s *TransferServiceImpl) GetTransfer(ctx context.Context, id string) (models.Transfer, error) {
 	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(GetTransfer, StatusSuccess))
 	defer timer.ObserveDuration()
 
-	transfer, err := s.repo.GetTransfer(id)
+	transfer, err := s.repo.GetTransfer(ctx, id)
 	if err != nil {
 		statusLabel := StatusFailure
-		if errors.Is(err, errors.New("transfer not found")) {
+		if errors.Is(err, apperrors.ErrNotFound) {
 			statusLabel = StatusNotFound
 		}
 		metrics.ServiceOperationsTotal.WithLabelValues(GetTransfer, statusLabel).Inc()
@@ -79,33 +180,62 @@ func (s *TransferServiceImpl) GetTransfer(id string) (models.Transfer, error) {
 	return transfer, nil
 }
 
-func (s *TransferServiceImpl) GetAccountBalance(id string) (float64, error) {
+func (s *TransferServiceImpl) GetAccountBalance(ctx context.Context, id string) ([]models.AccountBalance, error) {
 	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(GetAccountBalance, StatusSuccess))
 	defer timer.ObserveDuration()
 
-	balance, err := s.repo.GetAccountBalance(id)
+	balances, err := s.repo.GetAccountBalance(ctx, id)
 	if err != nil {
 		statusLabel := StatusFailure
-		if errors.Is(err, errors.New("account not found")) {
+		if errors.Is(err, apperrors.ErrNotFound) {
 			statusLabel = StatusNotFound
 		}
 		metrics.ServiceOperationsTotal.WithLabelValues(GetAccountBalance, statusLabel).Inc()
 		timer.ObserveDuration()
 		metrics.ServiceOperationDurationSeconds.WithLabelValues(GetAccountBalance, statusLabel).Observe(0)
-		return 0, err
+		return nil, err
 	}
 
 	metrics.ServiceOperationsTotal.WithLabelValues(GetAccountBalance, StatusSuccess).Inc()
-	return balance, nil
+	return balances, nil
 }
 
-func (s *TransferServiceImpl) UpdateTransfer(id, status string) error {
+func (s *TransferServiceImpl) UpdateTransfer(ctx context.Context, id string, change models.StatusChange) error {
 	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(UpdateTransfer, StatusSuccess))
 	defer timer.ObserveDuration()
 
-	if err := s.repo.UpdateTransfer(id, status); err != nil {
+	transfer, err := s.validateTransition(ctx, id, change)
+	if err != nil {
+		if errors.Is(err, errSameStatus) {
+			metrics.ServiceOperationsTotal.WithLabelValues(UpdateTransfer, StatusSuccess).Inc()
+			return nil
+		}
 		statusLabel := StatusFailure
-		if errors.Is(err, errors.New("transfer not found")) {
+		switch {
+		case errors.Is(err, apperrors.ErrInvalidTransition), errors.Is(err, apperrors.ErrPrecondition):
+			statusLabel = StatusConflict
+		case errors.Is(err, apperrors.ErrNotFound):
+			statusLabel = StatusNotFound
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(UpdateTransfer, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(UpdateTransfer, statusLabel).Observe(0)
+		return err
+	}
+
+	// Pin the version that was checked, as cancel does, so a webhook or monitor
+	// working from a stale read cannot overwrite a newer status.
+	pinned := change
+	pinned.IfMatch = []uint{transfer.Version}
+	if err := s.repo.UpdateTransfer(ctx, id, pinned); err != nil {
+		if errors.Is(err, apperrors.ErrPrecondition) && len(change.IfMatch) == 0 {
+			err = apperrors.New(apperrors.ErrConflict, apperrors.CodeConflict, "transfer was modified concurrently")
+		}
+		statusLabel := StatusFailure
+		switch {
+		case errors.Is(err, apperrors.ErrConflict), errors.Is(err, apperrors.ErrInvalidTransition), errors.Is(err, apperrors.ErrPrecondition):
+			statusLabel = StatusConflict
+		case errors.Is(err, apperrors.ErrNotFound):
 			statusLabel = StatusNotFound
 		}
 		metrics.ServiceOperationsTotal.WithLabelValues(UpdateTransfer, statusLabel).Inc()
@@ -118,20 +248,404 @@ func (s *TransferServiceImpl) UpdateTransfer(id, status string) error {
 	return nil
 }
 
-func (s *TransferServiceImpl) MonitorTransfer(id string) {
-	for i := 0; i < maxAttempts; i++ {
-		transfer, err := s.GetTransfer(id)
-		if err == nil && transfer.Status == enums.PENDING.String() {
-			metrics.TransferMonitorAttemptsTotal.WithLabelValues(id, fmt.Sprintf("%d", i+1), "still_pending").Inc()
-			time.Sleep(time.Duration(baseDelay+i*2) * time.Second)
-			continue
-		} else if err != nil {
-			metrics.TransferMonitorAttemptsTotal.WithLabelValues(id, fmt.Sprintf("%d", i+1), "error").Inc()
-			return
-		} else {
-			metrics.TransferMonitorAttemptsTotal.WithLabelValues(id, fmt.Sprintf("%d", i+1), StatusSuccess).Inc()
-			return
+// CancelTransfer moves a PENDING or SCHEDULED transfer to CANCELLED, which
+// releases its hold. Cancelling an already cancelled transfer is a no-op; any other status
+// means the transfer is with the provider or settled and is reported as a
+// conflict.
+func (s *TransferServiceImpl) CancelTransfer(ctx context.Context, id string, change models.StatusChange) error {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(CancelTransfer, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	change.Status = enums.CANCELLED.String()
+	if err := s.cancel(ctx, id, change); err != nil {
+		statusLabel := StatusFailure
+		switch {
+		case errors.Is(err, apperrors.ErrConflict), errors.Is(err, apperrors.ErrPrecondition):
+			statusLabel = StatusConflict
+		case errors.Is(err, apperrors.ErrNotFound):
+			statusLabel = StatusNotFound
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(CancelTransfer, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(CancelTransfer, statusLabel).Observe(0)
+		return err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(CancelTransfer, StatusSuccess).Inc()
+	return nil
+}
+
+func (s *TransferServiceImpl) cancel(ctx context.Context, id string, change models.StatusChange) error {
+	transfer, err := s.repo.GetTransfer(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if len(change.IfMatch) > 0 && !slices.Contains(change.IfMatch, transfer.Version) {
+		return repository.NewVersionMismatchError(transfer.Version)
+	}
+
+	switch enums.TransactionStatus(transfer.Status) {
+	case enums.CANCELLED:
+		return nil
+	case enums.PENDING, enums.SCHEDULED:
+	default:
+		return apperrors.New(apperrors.ErrConflict, apperrors.CodeNotCancellable, "transfer is "+transfer.Status+" and can no longer be cancelled")
+	}
+
+	// Pin the version that was checked, so a webhook that moves the transfer
+	// in the meantime wins instead of being overwritten.
+	pinned := change
+	pinned.IfMatch = []uint{transfer.Version}
+	err = s.repo.UpdateTransfer(ctx, id, pinned)
+	if errors.Is(err, apperrors.ErrPrecondition) && len(change.IfMatch) == 0 {
+		return apperrors.New(apperrors.ErrConflict, apperrors.CodeConflict, "transfer was modified concurrently")
+	}
+	return err
+}
+
+// RefundTransfer books a refund against a completed transfer and returns the
+// refund transfer. The repository re-checks status and remaining amount under
+// lock; the checks here only give early, precise errors.
+func (s *TransferServiceImpl) RefundTransfer(ctx context.Context, id string, req transfers.RefundRequest, change models.StatusChange) (models.Transfer, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(RefundTransfer, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	refund, err := s.refund(ctx, id, req, change)
+	if err != nil {
+		statusLabel := StatusFailure
+		switch {
+		case errors.Is(err, apperrors.ErrConflict), errors.Is(err, apperrors.ErrPrecondition):
+			statusLabel = StatusConflict
+		case errors.Is(err, apperrors.ErrNotFound):
+			statusLabel = StatusNotFound
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(RefundTransfer, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(RefundTransfer, statusLabel).Observe(0)
+		return models.Transfer{}, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(RefundTransfer, StatusSuccess).Inc()
+	return refund, nil
+}
+
+func (s *TransferServiceImpl) refund(ctx context.Context, id string, req transfers.RefundRequest, change models.StatusChange) (models.Transfer, error) {
+	if err := req.Validate(); err != nil {
+		return models.Transfer{}, err
+	}
+
+	original, err := s.repo.GetTransfer(ctx, id)
+	if err != nil {
+		return models.Transfer{}, err
+	}
+
+	if len(change.IfMatch) > 0 && !slices.Contains(change.IfMatch, original.Version) {
+		return models.Transfer{}, repository.NewVersionMismatchError(original.Version)
+	}
+	if !enums.TransactionStatus(original.Status).IsRefundable() {
+		return models.Transfer{}, repository.NewNotRefundableError(original.Status)
+	}
+
+	remaining := original.Amount.Sub(original.RefundedAmount)
+	amount := remaining
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+	if !amount.FitsPrecision(original.Currency) {
+		var errs apperrors.ValidationError
+		places, _ := money.Precision(original.Currency)
+		errs.Add("amount", apperrors.CodeTooPrecise, fmt.Sprintf("%s allows at most %d decimal places", original.Currency, places))
+		return models.Transfer{}, errs.Err()
+	}
+	if remaining.Sub(amount).IsNegative() {
+		return models.Transfer{}, repository.NewRefundExceedsError(remaining, original.Currency)
+	}
+
+	return s.repo.RefundTransfer(ctx, id, amount, change)
+}
+
+// schedule stores a future-dated transfer and the job that will release it.
+func (s *TransferServiceImpl) schedule(ctx context.Context, req transfers.TransferRequest, currency string) (string, error) {
+	id, err := s.repo.ScheduleTransfer(ctx, req.FromAccount, req.ToAccount, req.Amount, currency, *req.ExecuteAt)
+	if err != nil {
+		return "", err
+	}
+
+	// Without its job the transfer would never run, so do not leave it
+	// behind as SCHEDULED.
+	persistCtx := context.WithoutCancel(ctx)
+	if _, err := s.jobs.Enqueue(persistCtx, ExecuteScheduledTransferJob, id, *req.ExecuteAt); err != nil {
+		change := models.StatusChange{Status: enums.CANCELLED.String(), Source: enums.SourceScheduler, Reason: "could not schedule execution"}
+		if cancelErr := s.repo.UpdateTransfer(persistCtx, id, change); cancelErr != nil {
+			logging.Logger.WithError(cancelErr).WithField("transfer_id", id).Error("failed to cancel unschedulable transfer")
+		}
+		return "", err
+	}
+
+	return id, nil
+}
+
+// ExecuteScheduledTransfer releases a due scheduled transfer to PENDING. When
+// it can never run as scheduled, because funds are missing or an account was
+// frozen, closed or restricted meanwhile, it is marked FAILED with the reason
+// instead of being retried.
+func (s *TransferServiceImpl) ExecuteScheduledTransfer(ctx context.Context, id string) error {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(ExecuteScheduled, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	released, err := s.repo.ReleaseScheduledTransfer(ctx, id, time.Now())
+	switch apperrors.Code(err, "") {
+	case apperrors.CodeInsufficientFunds, apperrors.CodeAccountFrozen, apperrors.CodeAccountClosed, apperrors.CodeInvalidCurrency:
+		// UpdateTransfer checks the transition, so a cancellation that got
+		// in first is left alone.
+		change := models.StatusChange{Status: enums.FAILED.String(), Source: enums.SourceScheduler, Reason: err.Error()}
+		err = s.UpdateTransfer(ctx, id, change)
+		if err == nil || errors.Is(err, apperrors.ErrInvalidTransition) {
+			metrics.ServiceOperationsTotal.WithLabelValues(ExecuteScheduled, StatusConflict).Inc()
+			return nil
+		}
+	}
+	if err != nil {
+		statusLabel := StatusFailure
+		if errors.Is(err, apperrors.ErrNotFound) {
+			statusLabel = StatusNotFound
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(ExecuteScheduled, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(ExecuteScheduled, statusLabel).Observe(0)
+		return err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(ExecuteScheduled, StatusSuccess).Inc()
+	if released {
+		if _, err := s.jobs.Enqueue(context.WithoutCancel(ctx), MonitorTransferJob, id, time.Now().Add(monitorFirstCheckDelay)); err != nil {
+			logging.Logger.WithError(err).WithField("transfer_id", id).Error("failed to schedule transfer monitor")
+		}
+	}
+	return nil
+}
+
+// UpdateScheduledTransfer edits a transfer that has not run yet. Moving
+// execute_at enqueues a new job; the one for the old time finds the transfer
+// not due, or no longer scheduled, and does nothing.
+func (s *TransferServiceImpl) UpdateScheduledTransfer(ctx context.Context, id string, req transfers.ScheduledTransferUpdate, ifMatch []uint) (models.Transfer, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(UpdateScheduled, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	transfer, err := s.updateScheduled(ctx, id, req, ifMatch)
+	if err != nil {
+		statusLabel := StatusFailure
+		switch {
+		case errors.Is(err, apperrors.ErrConflict), errors.Is(err, apperrors.ErrPrecondition):
+			statusLabel = StatusConflict
+		case errors.Is(err, apperrors.ErrNotFound):
+			statusLabel = StatusNotFound
 		}
+		metrics.ServiceOperationsTotal.WithLabelValues(UpdateScheduled, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(UpdateScheduled, statusLabel).Observe(0)
+		return models.Transfer{}, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(UpdateScheduled, StatusSuccess).Inc()
+	return transfer, nil
+}
+
+func (s *TransferServiceImpl) updateScheduled(ctx context.Context, id string, req transfers.ScheduledTransferUpdate, ifMatch []uint) (models.Transfer, error) {
+	if err := req.Validate(); err != nil {
+		return models.Transfer{}, err
 	}
-	metrics.TransferMonitorAttemptsTotal.WithLabelValues(id, fmt.Sprintf("%d", maxAttempts), "max_attempts_reached").Inc()
+
+	transfer, err := s.repo.GetTransfer(ctx, id)
+	if err != nil {
+		return models.Transfer{}, err
+	}
+
+	var errs apperrors.ValidationError
+	if req.ToAccount != nil && *req.ToAccount == transfer.FromAccount {
+		errs.Add("destination_account_id", apperrors.CodeSameAccount, "must differ from source_account_id")
+	}
+	if req.Amount != nil && !req.Amount.FitsPrecision(transfer.Currency) {
+		places, _ := money.Precision(transfer.Currency)
+		errs.Add("amount", apperrors.CodeTooPrecise, fmt.Sprintf("%s allows at most %d decimal places", transfer.Currency, places))
+	}
+	if err := errs.Err(); err != nil {
+		return models.Transfer{}, err
+	}
+
+	updated, err := s.repo.UpdateScheduledTransfer(ctx, id, models.ScheduledTransferEdit{
+		ToAccount: req.ToAccount,
+		Amount:    req.Amount,
+		ExecuteAt: req.ExecuteAt,
+		IfMatch:   ifMatch,
+	})
+	if err != nil || req.ExecuteAt == nil {
+		return updated, err
+	}
+
+	// The job for the old time finds the transfer not yet due and does
+	// nothing, so without the new one it would never run. As in schedule,
+	// cancel it rather than leave it behind as SCHEDULED.
+	persistCtx := context.WithoutCancel(ctx)
+	if _, err := s.jobs.Enqueue(persistCtx, ExecuteScheduledTransferJob, id, *req.ExecuteAt); err != nil {
+		change := models.StatusChange{Status: enums.CANCELLED.String(), Source: enums.SourceScheduler, Reason: "could not reschedule execution"}
+		if cancelErr := s.repo.UpdateTransfer(persistCtx, id, change); cancelErr != nil {
+			logging.Logger.WithError(cancelErr).WithField("transfer_id", id).Error("failed to cancel unschedulable transfer")
+		}
+		return models.Transfer{}, err
+	}
+	return updated, nil
+}
+
+func (s *TransferServiceImpl) ListScheduledTransfers(ctx context.Context, accounts []string, limit int) ([]models.Transfer, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(ListScheduled, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	scheduled, err := s.repo.ListScheduledTransfers(ctx, accounts, limit)
+	if err != nil {
+		metrics.ServiceOperationsTotal.WithLabelValues(ListScheduled, StatusFailure).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(ListScheduled, StatusFailure).Observe(0)
+		return nil, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(ListScheduled, StatusSuccess).Inc()
+	return scheduled, nil
+}
+
+func (s *TransferServiceImpl) GetTransferHistory(ctx context.Context, id string) ([]models.TransferStatusHistory, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(GetTransferHistory, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	history, err := s.repo.GetTransferHistory(ctx, id)
+	if err != nil {
+		statusLabel := StatusFailure
+		if errors.Is(err, apperrors.ErrNotFound) {
+			statusLabel = StatusNotFound
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(GetTransferHistory, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(GetTransferHistory, statusLabel).Observe(0)
+		return nil, err
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(GetTransferHistory, StatusSuccess).Inc()
+	return history, nil
+}
+
+// ListTransfers returns one page of transfers. One extra row is fetched to
+// tell whether another page follows.
+func (s *TransferServiceImpl) ListTransfers(ctx context.Context, filter models.TransferFilter) (models.TransferPage, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(ListTransfers, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	limit := filter.Limit
+	if limit > 0 {
+		filter.Limit = limit + 1
+	}
+
+	list, err := s.repo.ListTransfers(ctx, filter)
+	if err != nil {
+		metrics.ServiceOperationsTotal.WithLabelValues(ListTransfers, StatusFailure).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(ListTransfers, StatusFailure).Observe(0)
+		return models.TransferPage{}, err
+	}
+
+	page := models.TransferPage{Transfers: list}
+	if limit > 0 && len(list) > limit {
+		page.Transfers = list[:limit]
+		last := page.Transfers[limit-1]
+		page.Next = &models.TransferCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	metrics.ServiceOperationsTotal.WithLabelValues(ListTransfers, StatusSuccess).Inc()
+	return page, nil
+}
+
+// GetAccountStatement builds the statement of an account for [from, to),
+// carrying the balance of each currency from the opening balance through
+// every line to the closing balance.
+func (s *TransferServiceImpl) GetAccountStatement(ctx context.Context, id string, from, to time.Time) (models.Statement, error) {
+	timer := prometheus.NewTimer(metrics.ServiceOperationDurationSeconds.WithLabelValues(GetStatement, StatusSuccess))
+	defer timer.ObserveDuration()
+
+	opening, lines, err := s.repo.GetStatementEntries(ctx, id, from, to)
+	if err != nil {
+		statusLabel := StatusFailure
+		if errors.Is(err, apperrors.ErrNotFound) {
+			statusLabel = StatusNotFound
+		}
+		metrics.ServiceOperationsTotal.WithLabelValues(GetStatement, statusLabel).Inc()
+		timer.ObserveDuration()
+		metrics.ServiceOperationDurationSeconds.WithLabelValues(GetStatement, statusLabel).Observe(0)
+		return models.Statement{}, err
+	}
+
+	sections := map[string]*models.CurrencyStatement{}
+	section := func(currency string) *models.CurrencyStatement {
+		if sections[currency] == nil {
+			sections[currency] = &models.CurrencyStatement{Currency: currency, Lines: []models.StatementLine{}}
+		}
+		return sections[currency]
+	}
+
+	for _, b := range opening {
+		sec := section(b.Currency)
+		sec.OpeningBalance = b.Balance
+		sec.ClosingBalance = b.Balance
+	}
+	for _, line := range lines {
+		sec := section(line.Currency)
+		sec.ClosingBalance = sec.ClosingBalance.Add(line.Amount)
+		line.Balance = sec.ClosingBalance
+		sec.Lines = append(sec.Lines, line)
+	}
+
+	statement := models.Statement{
+		AccountID:   id,
+		From:        from,
+		To:          to,
+		GeneratedAt: time.Now().UTC(),
+		Currencies:  make([]models.CurrencyStatement, 0, len(sections)),
+	}
+	for _, sec := range sections {
+		statement.Currencies = append(statement.Currencies, *sec)
+	}
+	slices.SortFunc(statement.Currencies, func(a, b models.CurrencyStatement) int {
+		return strings.Compare(a.Currency, b.Currency)
+	})
+
+	metrics.ServiceOperationsTotal.WithLabelValues(GetStatement, StatusSuccess).Inc()
+	return statement, nil
+}
+
+var errSameStatus = errors.New("transfer already has the requested status")
+
+// validateTransition checks the requested change against the transfer's
+// current version and status, and returns the transfer it checked.
+// Re-delivering the current status is reported as errSameStatus so callers
+// can treat it as a no-op.
+func (s *TransferServiceImpl) validateTransition(ctx context.Context, id string, change models.StatusChange) (models.Transfer, error) {
+	target, err := enums.NewTransactionStatusFromString(change.Status)
+	if err != nil {
+		return models.Transfer{}, err
+	}
+
+	transfer, err := s.repo.GetTransfer(ctx, id)
+	if err != nil {
+		return models.Transfer{}, err
+	}
+
+	if len(change.IfMatch) > 0 && !slices.Contains(change.IfMatch, transfer.Version) {
+		return transfer, repository.NewVersionMismatchError(transfer.Version)
+	}
+
+	current := enums.TransactionStatus(transfer.Status)
+	if current == target {
+		return transfer, errSameStatus
+	}
+
+	return transfer, enums.ValidateTransition(current, target)
 }
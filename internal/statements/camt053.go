@@ -0,0 +1,146 @@
+package statements
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/money"
+)
+
+const camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"
+
+// The types below cover the subset of camt.053.001.08 needed to report booked
+// entries with opening (OPBD) and closing (CLBD) balances.
+
+type camtDocument struct {
+	XMLName xml.Name     `xml:"Document"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Report  camtBkToCstm `xml:"BkToCstmrStmt"`
+}
+
+type camtBkToCstm struct {
+	GrpHdr     camtGrpHdr      `xml:"GrpHdr"`
+	Statements []camtStatement `xml:"Stmt"`
+}
+
+type camtGrpHdr struct {
+	MsgID   string `xml:"MsgId"`
+	CreDtTm string `xml:"CreDtTm"`
+}
+
+type camtStatement struct {
+	ID      string        `xml:"Id"`
+	CreDtTm string        `xml:"CreDtTm"`
+	FrToDt  camtPeriod    `xml:"FrToDt"`
+	Acct    camtAccount   `xml:"Acct"`
+	Bal     []camtBalance `xml:"Bal"`
+	Ntry    []camtEntry   `xml:"Ntry"`
+}
+
+type camtPeriod struct {
+	FrDtTm string `xml:"FrDtTm"`
+	ToDtTm string `xml:"ToDtTm"`
+}
+
+type camtAccount struct {
+	ID  string `xml:"Id>Othr>Id"`
+	Ccy string `xml:"Ccy"`
+}
+
+type camtAmount struct {
+	Ccy   string `xml:"Ccy,attr"`
+	Value string `xml:",chardata"`
+}
+
+type camtBalance struct {
+	Code      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amt       camtAmount `xml:"Amt"`
+	CdtDbtInd string     `xml:"CdtDbtInd"`
+	DtTm      string     `xml:"Dt>DtTm"`
+}
+
+type camtEntry struct {
+	NtryRef    string     `xml:"NtryRef"`
+	Amt        camtAmount `xml:"Amt"`
+	CdtDbtInd  string     `xml:"CdtDbtInd"`
+	Status     string     `xml:"Sts>Cd"`
+	BookgDtTm  string     `xml:"BookgDt>DtTm"`
+	ValDtTm    string     `xml:"ValDt>DtTm"`
+	AcctSvcrRf string     `xml:"AcctSvcrRef"`
+	BkTxCd     string     `xml:"BkTxCd>Prtry>Cd"`
+	EndToEndID string     `xml:"NtryDtls>TxDtls>Refs>EndToEndId"`
+}
+
+// WriteCamt053 writes the statement as an ISO 20022 camt.053 document with
+// one Stmt per currency.
+func WriteCamt053(w io.Writer, statement models.Statement) error {
+	created := statement.GeneratedAt.UTC().Format(time.RFC3339)
+	msgID := "STMT-" + statement.AccountID + "-" + statement.From.UTC().Format("20060102")
+
+	doc := camtDocument{
+		Xmlns: camt053Namespace,
+		Report: camtBkToCstm{
+			GrpHdr: camtGrpHdr{MsgID: msgID, CreDtTm: created},
+		},
+	}
+
+	for _, sec := range statement.Currencies {
+		places, _ := money.Precision(sec.Currency)
+		amount := func(a money.Amount) camtAmount {
+			if a.IsNegative() {
+				a = a.Neg()
+			}
+			return camtAmount{Ccy: sec.Currency, Value: a.StringFixed(places)}
+		}
+
+		stmt := camtStatement{
+			ID:      msgID + "-" + sec.Currency,
+			CreDtTm: created,
+			FrToDt: camtPeriod{
+				FrDtTm: statement.From.UTC().Format(time.RFC3339),
+				ToDtTm: statement.To.UTC().Format(time.RFC3339),
+			},
+			Acct: camtAccount{ID: statement.AccountID, Ccy: sec.Currency},
+			Bal: []camtBalance{
+				{Code: "OPBD", Amt: amount(sec.OpeningBalance), CdtDbtInd: creditDebit(sec.OpeningBalance), DtTm: statement.From.UTC().Format(time.RFC3339)},
+				{Code: "CLBD", Amt: amount(sec.ClosingBalance), CdtDbtInd: creditDebit(sec.ClosingBalance), DtTm: statement.To.UTC().Format(time.RFC3339)},
+			},
+		}
+		for _, line := range sec.Lines {
+			booked := line.BookedAt.UTC().Format(time.RFC3339)
+			stmt.Ntry = append(stmt.Ntry, camtEntry{
+				NtryRef:    line.EntryID,
+				Amt:        amount(line.Amount),
+				CdtDbtInd:  creditDebit(line.Amount),
+				Status:     "BOOK",
+				BookgDtTm:  booked,
+				ValDtTm:    booked,
+				AcctSvcrRf: line.EntryID,
+				BkTxCd:     "TRANSFER",
+				EndToEndID: line.TransferID,
+			})
+		}
+		doc.Report.Statements = append(doc.Report.Statements, stmt)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// creditDebit follows camt.053, where amounts are unsigned and the sign is
+// carried by CRDT or DBIT. A zero balance is reported as a credit.
+func creditDebit(a money.Amount) string {
+	if a.IsNegative() {
+		return "DBIT"
+	}
+	return "CRDT"
+}
@@ -0,0 +1,50 @@
+package statements
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/money"
+)
+
+var csvHeader = []string{"account_id", "currency", "type", "booked_at", "entry_id", "transfer_id", "counterparty", "direction", "amount", "balance"}
+
+// WriteCSV writes the statement as one row per line item, framed by an
+// opening and a closing row for each currency.
+func WriteCSV(w io.Writer, statement models.Statement) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, sec := range statement.Currencies {
+		places, _ := money.Precision(sec.Currency)
+		format := func(a money.Amount) string { return a.StringFixed(places) }
+
+		rows := [][]string{{statement.AccountID, sec.Currency, "opening", statement.From.UTC().Format(time.RFC3339), "", "", "", "", "", format(sec.OpeningBalance)}}
+		for _, line := range sec.Lines {
+			rows = append(rows, []string{
+				statement.AccountID,
+				sec.Currency,
+				"entry",
+				line.BookedAt.UTC().Format(time.RFC3339Nano),
+				line.EntryID,
+				line.TransferID,
+				line.Counterparty,
+				line.Direction,
+				format(line.Amount),
+				format(line.Balance),
+			})
+		}
+		rows = append(rows, []string{statement.AccountID, sec.Currency, "closing", statement.To.UTC().Format(time.RFC3339), "", "", "", "", "", format(sec.ClosingBalance)})
+
+		if err := out.WriteAll(rows); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
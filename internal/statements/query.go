@@ -0,0 +1,52 @@
+package statements
+
+import (
+	"time"
+
+	"secure-payment-service/internal/apperrors"
+)
+
+const dateLayout = "2006-01-02"
+
+// Query holds the period parameters of the statement endpoint. Both bounds
+// accept a date or an RFC 3339 timestamp; a date in To includes that whole
+// day, so from=2024-03-01&to=2024-03-31 covers March.
+type Query struct {
+	From string `form:"from"`
+	To   string `form:"to"`
+}
+
+// Period returns the half-open interval [from, to) selected by the query.
+func (q Query) Period() (time.Time, time.Time, error) {
+	var errs apperrors.ValidationError
+
+	from, fromOK := parseBound(&errs, "from", q.From, false)
+	to, toOK := parseBound(&errs, "to", q.To, true)
+	if fromOK && toOK && !from.Before(to) {
+		errs.Add("to", apperrors.CodeInvalidFormat, "must be after from")
+	}
+
+	if err := errs.Err(); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return from, to, nil
+}
+
+func parseBound(errs *apperrors.ValidationError, field, value string, endOfDay bool) (time.Time, bool) {
+	if value == "" {
+		errs.Add(field, apperrors.CodeRequired, "is required")
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(dateLayout, value); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, true
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		errs.Add(field, apperrors.CodeInvalidFormat, "must be a date (YYYY-MM-DD) or an RFC 3339 timestamp")
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,127 @@
+package statements_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/money"
+	"secure-payment-service/internal/statements"
+)
+
+var march = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func givenAStatement() models.Statement {
+	return models.Statement{
+		AccountID:   "acc-001",
+		From:        march,
+		To:          march.AddDate(0, 1, 0),
+		GeneratedAt: march.AddDate(0, 1, 1),
+		Currencies: []models.CurrencyStatement{{
+			Currency:       "USD",
+			OpeningBalance: money.MustParse("100"),
+			ClosingBalance: money.MustParse("69.5"),
+			Lines: []models.StatementLine{{
+				EntryID:      "e1",
+				TransferID:   "t1",
+				BookedAt:     march.Add(36 * time.Hour),
+				Direction:    "DEBIT",
+				Counterparty: "acc-002",
+				Currency:     "USD",
+				Amount:       money.MustParse("-30.5"),
+				Balance:      money.MustParse("69.5"),
+			}},
+		}},
+	}
+}
+
+func TestQuery_Period(t *testing.T) {
+	t.Run("dates_cover_whole_days", func(t *testing.T) {
+		from, to, err := statements.Query{From: "2024-03-01", To: "2024-03-31"}.Period()
+		assert.NoError(t, err)
+		assert.Equal(t, march, from)
+		assert.Equal(t, march.AddDate(0, 1, 0), to)
+	})
+
+	t.Run("timestamps_are_used_as_is", func(t *testing.T) {
+		from, to, err := statements.Query{From: "2024-03-01T00:00:00Z", To: "2024-03-02T12:00:00Z"}.Period()
+		assert.NoError(t, err)
+		assert.Equal(t, march, from)
+		assert.Equal(t, march.Add(36*time.Hour), to)
+	})
+
+	t.Run("invalid_period", func(t *testing.T) {
+		_, _, err := statements.Query{To: "last month"}.Period()
+		var validationErr *apperrors.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, []apperrors.FieldError{
+			{Field: "from", Code: apperrors.CodeRequired, Message: "is required"},
+			{Field: "to", Code: apperrors.CodeInvalidFormat, Message: "must be a date (YYYY-MM-DD) or an RFC 3339 timestamp"},
+		}, validationErr.Fields)
+	})
+
+	t.Run("to_before_from", func(t *testing.T) {
+		_, _, err := statements.Query{From: "2024-03-10", To: "2024-03-01"}.Period()
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+	})
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, statements.WriteCSV(&buf, givenAStatement()))
+
+	assert.Equal(t, strings.Join([]string{
+		"account_id,currency,type,booked_at,entry_id,transfer_id,counterparty,direction,amount,balance",
+		"acc-001,USD,opening,2024-03-01T00:00:00Z,,,,,,100.00",
+		"acc-001,USD,entry,2024-03-02T12:00:00Z,e1,t1,acc-002,DEBIT,-30.50,69.50",
+		"acc-001,USD,closing,2024-04-01T00:00:00Z,,,,,,69.50",
+		"",
+	}, "\n"), buf.String())
+}
+
+func TestWriteCamt053(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, statements.WriteCamt053(&buf, givenAStatement()))
+
+	var doc struct {
+		XMLName xml.Name
+		MsgID   string `xml:"BkToCstmrStmt>GrpHdr>MsgId"`
+		Stmt    []struct {
+			Account string `xml:"Acct>Id>Othr>Id"`
+			Bal     []struct {
+				Code string `xml:"Tp>CdOrPrtry>Cd"`
+				Amt  string `xml:"Amt"`
+				Ind  string `xml:"CdtDbtInd"`
+			} `xml:"Bal"`
+			Ntry []struct {
+				Amt      string `xml:"Amt"`
+				Ind      string `xml:"CdtDbtInd"`
+				EndToEnd string `xml:"NtryDtls>TxDtls>Refs>EndToEndId"`
+			} `xml:"Ntry"`
+		} `xml:"BkToCstmrStmt>Stmt"`
+	}
+	assert.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Contains(t, buf.String(), `<Amt Ccy="USD">30.50</Amt>`)
+
+	assert.Equal(t, "urn:iso:std:iso:20022:tech:xsd:camt.053.001.08", doc.XMLName.Space)
+	assert.Equal(t, "STMT-acc-001-20240301", doc.MsgID)
+	if !assert.Len(t, doc.Stmt, 1) {
+		return
+	}
+	assert.Equal(t, "acc-001", doc.Stmt[0].Account)
+	assert.Equal(t, "OPBD", doc.Stmt[0].Bal[0].Code)
+	assert.Equal(t, "100.00", doc.Stmt[0].Bal[0].Amt)
+	assert.Equal(t, "CLBD", doc.Stmt[0].Bal[1].Code)
+	assert.Equal(t, "69.50", doc.Stmt[0].Bal[1].Amt)
+	assert.Len(t, doc.Stmt[0].Ntry, 1)
+	assert.Equal(t, "30.50", doc.Stmt[0].Ntry[0].Amt)
+	assert.Equal(t, "DBIT", doc.Stmt[0].Ntry[0].Ind)
+	assert.Equal(t, "t1", doc.Stmt[0].Ntry[0].EndToEnd)
+}
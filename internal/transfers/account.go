@@ -0,0 +1,43 @@
+package transfers
+
+import (
+	"fmt"
+	"strings"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/money"
+)
+
+const maxMetadataEntries = 20
+
+type OpenAccountRequest struct {
+	AccountID string            `json:"account_id"`
+	Owner     string            `json:"owner"`
+	Currency  string            `json:"currency"`
+	Metadata  map[string]string `json:"metadata"`
+}
+
+// Validate checks the request like TransferRequest.Validate does. AccountID
+// may be left empty to have one generated; Currency may be left empty to
+// accept transfers in any currency.
+func (r OpenAccountRequest) Validate() error {
+	var errs apperrors.ValidationError
+
+	if r.AccountID != "" {
+		validateAccountID(&errs, "account_id", r.AccountID)
+	}
+
+	if strings.TrimSpace(r.Owner) == "" {
+		errs.Add("owner", apperrors.CodeRequired, "is required")
+	}
+
+	if r.Currency != "" && !money.IsValidCurrency(strings.ToUpper(r.Currency)) {
+		errs.Add("currency", apperrors.CodeInvalidCurrency, fmt.Sprintf("'%s' is not an ISO 4217 currency code", r.Currency))
+	}
+
+	if len(r.Metadata) > maxMetadataEntries {
+		errs.Add("metadata", apperrors.CodeInvalidFormat, fmt.Sprintf("must have at most %d entries", maxMetadataEntries))
+	}
+
+	return errs.Err()
+}
@@ -0,0 +1,187 @@
+package transfers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"secure-payment-service/internal/apperrors"
+)
+
+// MaxBatchRows is how many transfers a single bulk upload may hold.
+const MaxBatchRows = 10000
+
+// Formats of a bulk upload, named by their media type.
+const (
+	BatchCSV    = "text/csv"
+	BatchNDJSON = "application/x-ndjson"
+)
+
+// batchColumns are the CSV columns, named like the JSON fields of
+// TransferRequest. execute_at is the only optional one.
+var batchColumns = []string{"source_account_id", "destination_account_id", "amount", "currency", "execute_at"}
+
+// BatchRow is one transfer of a bulk upload. Line is where it starts in the
+// file. Err is set, as an *apperrors.ValidationError, when the row could not
+// be parsed or fails TransferRequest.Validate.
+type BatchRow struct {
+	Line    int
+	Request TransferRequest
+	Err     error
+}
+
+// ParseBatch reads a bulk upload in format. A bad row is reported on that
+// row only. An upload that is unusable as a whole is reported as an
+// *apperrors.ValidationError on the file field; errors reading r are
+// returned as they are.
+func ParseBatch(r io.Reader, format string) ([]BatchRow, error) {
+	var rows []BatchRow
+	var err error
+	switch format {
+	case BatchCSV:
+		rows, err = parseBatchCSV(r)
+	case BatchNDJSON:
+		rows, err = parseBatchNDJSON(r)
+	default:
+		return nil, fmt.Errorf("unsupported batch format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, fileError(apperrors.CodeRequired, "contains no transfers")
+	}
+	return rows, nil
+}
+
+// parseBatchCSV expects a header naming the columns, in any order.
+func parseBatchCSV(r io.Reader) ([]BatchRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, csvError(err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(batchColumns, name) {
+			return nil, fileError(apperrors.CodeInvalidFormat, fmt.Sprintf("unknown column %q", name))
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fileError(apperrors.CodeInvalidFormat, fmt.Sprintf("column %q appears twice", name))
+		}
+		columns[name] = i
+	}
+	for _, name := range batchColumns[:4] {
+		if _, ok := columns[name]; !ok {
+			return nil, fileError(apperrors.CodeRequired, fmt.Sprintf("column %q is missing", name))
+		}
+	}
+
+	var rows []BatchRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, csvError(err)
+		}
+		if len(rows) == MaxBatchRows {
+			return nil, fileError(apperrors.CodeTooManyRows, fmt.Sprintf("must hold at most %d transfers", MaxBatchRows))
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(record) != len(header) {
+			var errs apperrors.ValidationError
+			errs.Add("line", apperrors.CodeInvalidFormat, fmt.Sprintf("has %d fields, the header has %d", len(record), len(header)))
+			rows = append(rows, BatchRow{Line: line, Err: errs.Err()})
+			continue
+		}
+
+		value := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		var errs apperrors.ValidationError
+		req := TransferRequest{
+			FromAccount: value("source_account_id"),
+			ToAccount:   value("destination_account_id"),
+			Currency:    value("currency"),
+			ExecuteAt:   parseTimeParam(&errs, "execute_at", value("execute_at")),
+		}
+		if amount := parseAmountParam(&errs, "amount", value("amount")); amount != nil {
+			req.Amount = *amount
+		} else if value("amount") == "" {
+			errs.Add("amount", apperrors.CodeRequired, "is required")
+		}
+
+		rows = append(rows, BatchRow{Line: line, Request: req, Err: validateParsed(&errs, req)})
+	}
+}
+
+// parseBatchNDJSON expects one TransferRequest object per line. Blank lines
+// are skipped.
+func parseBatchNDJSON(r io.Reader) ([]BatchRow, error) {
+	scanner := bufio.NewScanner(r)
+
+	var rows []BatchRow
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if len(rows) == MaxBatchRows {
+			return nil, fileError(apperrors.CodeTooManyRows, fmt.Sprintf("must hold at most %d transfers", MaxBatchRows))
+		}
+
+		req, err := DecodeTransferRequest([]byte(text))
+		var invalid *apperrors.ValidationError
+		if err != nil && !errors.As(err, &invalid) {
+			var errs apperrors.ValidationError
+			errs.Add("line", apperrors.CodeInvalidFormat, err.Error())
+			err = errs.Err()
+		}
+		rows = append(rows, BatchRow{Line: line, Request: req, Err: err})
+	}
+	if err := scanner.Err(); errors.Is(err, bufio.ErrTooLong) {
+		return nil, fileError(apperrors.CodeInvalidFormat, fmt.Sprintf("line %d is too long", line+1))
+	} else if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// csvError reports malformed CSV as a validation error and returns errors
+// reading the upload as they are.
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return fileError(apperrors.CodeInvalidFormat, parseErr.Error())
+	}
+	return err
+}
+
+func fileError(code, message string) error {
+	var errs apperrors.ValidationError
+	errs.Add("file", code, message)
+	return errs.Err()
+}
@@ -0,0 +1,121 @@
+package transfers_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/money"
+	"secure-payment-service/internal/transfers"
+)
+
+func fieldsOf(t *testing.T, err error) []string {
+	t.Helper()
+	var validationErr *apperrors.ValidationError
+	if !assert.True(t, errors.As(err, &validationErr)) {
+		return nil
+	}
+	fields := make([]string, 0, len(validationErr.Fields))
+	for _, f := range validationErr.Fields {
+		fields = append(fields, f.Field+":"+f.Code)
+	}
+	return fields
+}
+
+func TestParseBatch_CSV(t *testing.T) {
+	executeAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	upload := "currency,amount,source_account_id,destination_account_id,execute_at\n" +
+		"USD,10.50,acc-001,acc-002,\n" +
+		"\n" +
+		"eur, 3 ,acc-001,acc-003," + executeAt.Format(time.RFC3339) + "\n"
+
+	rows, err := transfers.ParseBatch(strings.NewReader(upload), transfers.BatchCSV)
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, transfers.BatchRow{Line: 2, Request: transfers.TransferRequest{
+		FromAccount: "acc-001", ToAccount: "acc-002", Amount: money.MustParse("10.50"), Currency: "USD",
+	}}, rows[0])
+	assert.Equal(t, 4, rows[1].Line)
+	assert.NoError(t, rows[1].Err)
+	assert.Equal(t, "eur", rows[1].Request.Currency)
+	assert.True(t, executeAt.Equal(*rows[1].Request.ExecuteAt))
+}
+
+func TestParseBatch_CSVReportsEachBadRow(t *testing.T) {
+	upload := "source_account_id,destination_account_id,amount,currency\n" +
+		"acc-001,acc-002,ten,USD\n" +
+		"acc-001,acc-001,,XYZ\n" +
+		"acc-001,acc-002\n" +
+		"acc-001,acc-002,1.00,USD\n"
+
+	rows, err := transfers.ParseBatch(strings.NewReader(upload), transfers.BatchCSV)
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 4)
+	assert.Equal(t, []string{"amount:invalid_amount"}, fieldsOf(t, rows[0].Err))
+	assert.Equal(t, []string{"amount:required", "destination_account_id:same_account", "currency:invalid_currency"}, fieldsOf(t, rows[1].Err))
+	assert.Equal(t, []string{"line:invalid_format"}, fieldsOf(t, rows[2].Err))
+	assert.NoError(t, rows[3].Err)
+}
+
+func TestParseBatch_NDJSON(t *testing.T) {
+	upload := `{"source_account_id":"acc-001","destination_account_id":"acc-002","amount":"10.50","currency":"USD"}` + "\n" +
+		"\n" +
+		`{"source_account_id":"acc-001","destination_account_id":"acc-002","amount":"1.005","currency":"USD"}` + "\n" +
+		`{"source_account_id":1,"destination_account_id":"acc-002","amount":"7","currency":"USD"}` + "\n" +
+		`not json` + "\n"
+
+	rows, err := transfers.ParseBatch(strings.NewReader(upload), transfers.BatchNDJSON)
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 4)
+	assert.Equal(t, 1, rows[0].Line)
+	assert.NoError(t, rows[0].Err)
+	assert.Equal(t, money.MustParse("10.50"), rows[0].Request.Amount)
+	assert.Equal(t, 3, rows[1].Line)
+	assert.Equal(t, []string{"amount:too_many_decimals"}, fieldsOf(t, rows[1].Err))
+	assert.Equal(t, []string{"source_account_id:invalid_type"}, fieldsOf(t, rows[2].Err))
+	assert.Equal(t, 5, rows[3].Line)
+	assert.Equal(t, []string{"line:invalid_format"}, fieldsOf(t, rows[3].Err))
+}
+
+func TestParseBatch_RejectsUnusableUploads(t *testing.T) {
+	tooMany := "source_account_id,destination_account_id,amount,currency\n" +
+		strings.Repeat("acc-001,acc-002,1,USD\n", transfers.MaxBatchRows+1)
+
+	tests := []struct {
+		name   string
+		upload string
+		format string
+		field  string
+	}{
+		{"empty", "", transfers.BatchCSV, "file:required"},
+		{"header only", "source_account_id,destination_account_id,amount,currency\n", transfers.BatchCSV, "file:required"},
+		{"missing column", "source_account_id,destination_account_id,amount\nacc-001,acc-002,1\n", transfers.BatchCSV, "file:required"},
+		{"unknown column", "source_account_id,destination_account_id,amount,currency,memo\n", transfers.BatchCSV, "file:invalid_format"},
+		{"bad quoting", "source_account_id,destination_account_id,amount,currency\n\"acc-001,acc-002,1,USD\n", transfers.BatchCSV, "file:invalid_format"},
+		{"too many rows", tooMany, transfers.BatchCSV, "file:too_many_rows"},
+		{"blank lines only", "\n\n", transfers.BatchNDJSON, "file:required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := transfers.ParseBatch(strings.NewReader(tt.upload), tt.format)
+
+			assert.ErrorIs(t, err, apperrors.ErrValidation)
+			assert.Equal(t, []string{tt.field}, fieldsOf(t, err))
+		})
+	}
+}
+
+func TestParseBatch_UnknownFormat(t *testing.T) {
+	_, err := transfers.ParseBatch(strings.NewReader("{}"), "application/json")
+
+	assert.EqualError(t, err, fmt.Sprintf("unsupported batch format %q", "application/json"))
+}
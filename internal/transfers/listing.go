@@ -0,0 +1,248 @@
+package transfers
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/money"
+)
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// ListQuery holds the raw query parameters of GET /transfers. Everything is
+// kept as a string so Filter can report each bad parameter on its own.
+type ListQuery struct {
+	Account     string `form:"account"`
+	Role        string `form:"role"`
+	Status      string `form:"status"`
+	Currency    string `form:"currency"`
+	MinAmount   string `form:"min_amount"`
+	MaxAmount   string `form:"max_amount"`
+	CreatedFrom string `form:"created_from"`
+	CreatedTo   string `form:"created_to"`
+	Limit       string `form:"limit"`
+	Cursor      string `form:"cursor"`
+}
+
+// Filter validates the query and turns it into a repository filter. All
+// invalid parameters are reported together as an *apperrors.ValidationError.
+func (q ListQuery) Filter() (models.TransferFilter, error) {
+	var errs apperrors.ValidationError
+	filter := models.TransferFilter{AccountRole: enums.RoleAny, Limit: DefaultListLimit}
+
+	if q.Account != "" {
+		validateAccountID(&errs, "account", q.Account)
+		filter.Accounts = []string{q.Account}
+	}
+
+	if q.Role != "" {
+		role := enums.AccountRole(strings.ToLower(q.Role))
+		if role.IsValid() {
+			filter.AccountRole = role
+		} else {
+			errs.Add("role", apperrors.CodeInvalidFormat, "must be one of source, destination, any")
+		}
+	}
+
+	if q.Status != "" {
+		for _, s := range strings.Split(q.Status, ",") {
+			status, err := enums.NewTransactionStatusFromString(strings.ToUpper(strings.TrimSpace(s)))
+			if err != nil {
+				errs.Add("status", apperrors.CodeInvalidStatus, err.Error())
+				continue
+			}
+			filter.Statuses = append(filter.Statuses, status.String())
+		}
+	}
+
+	if q.Currency != "" {
+		currency := strings.ToUpper(q.Currency)
+		if money.IsValidCurrency(currency) {
+			filter.Currency = currency
+		} else {
+			errs.Add("currency", apperrors.CodeInvalidCurrency, fmt.Sprintf("'%s' is not an ISO 4217 currency code", q.Currency))
+		}
+	}
+
+	filter.MinAmount = parseAmountParam(&errs, "min_amount", q.MinAmount)
+	filter.MaxAmount = parseAmountParam(&errs, "max_amount", q.MaxAmount)
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		errs.Add("max_amount", apperrors.CodeInvalidAmount, "must not be less than min_amount")
+	}
+
+	filter.CreatedFrom = parseTimeParam(&errs, "created_from", q.CreatedFrom)
+	filter.CreatedTo = parseTimeParam(&errs, "created_to", q.CreatedTo)
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		errs.Add("created_to", apperrors.CodeInvalidFormat, "must be after created_from")
+	}
+
+	filter.Limit = parseLimitParam(&errs, q.Limit)
+
+	if q.Cursor != "" {
+		cursor, err := DecodeCursor(q.Cursor)
+		if err != nil {
+			errs.Add("cursor", apperrors.CodeInvalidFormat, "is not a cursor returned by this endpoint")
+		} else {
+			filter.After = &cursor
+		}
+	}
+
+	if err := errs.Err(); err != nil {
+		return models.TransferFilter{}, err
+	}
+	return filter, nil
+}
+
+// ScheduledQuery holds the query parameters of GET /transfers/scheduled and
+// GET /standing-orders.
+type ScheduledQuery struct {
+	Account string `form:"account"`
+	Limit   string `form:"limit"`
+}
+
+// Filter validates the query. Only Accounts and Limit of the result are set.
+func (q ScheduledQuery) Filter() (models.TransferFilter, error) {
+	var errs apperrors.ValidationError
+	filter := models.TransferFilter{AccountRole: enums.RoleAny}
+
+	if q.Account != "" {
+		validateAccountID(&errs, "account", q.Account)
+		filter.Accounts = []string{q.Account}
+	}
+	filter.Limit = parseLimitParam(&errs, q.Limit)
+
+	if err := errs.Err(); err != nil {
+		return models.TransferFilter{}, err
+	}
+	return filter, nil
+}
+
+// ExecutionsQuery holds the query parameters of
+// GET /standing-order/:id/executions.
+type ExecutionsQuery struct {
+	Limit string `form:"limit"`
+}
+
+func (q ExecutionsQuery) ParseLimit() (int, error) {
+	var errs apperrors.ValidationError
+	limit := parseLimitParam(&errs, q.Limit)
+	return limit, errs.Err()
+}
+
+// BatchRowsQuery holds the query parameters of GET /transfers/batch/:id/rows.
+// After is the line of the last row of the previous page.
+type BatchRowsQuery struct {
+	Status string `form:"status"`
+	After  string `form:"after"`
+	Limit  string `form:"limit"`
+}
+
+// BatchRowsFilter is a validated BatchRowsQuery. An empty Status matches
+// every row.
+type BatchRowsFilter struct {
+	Status    enums.BatchRowStatus
+	AfterLine int
+	Limit     int
+}
+
+func (q BatchRowsQuery) Filter() (BatchRowsFilter, error) {
+	var errs apperrors.ValidationError
+	filter := BatchRowsFilter{Limit: parseLimitParam(&errs, q.Limit)}
+
+	if q.Status != "" {
+		filter.Status = enums.BatchRowStatus(strings.ToUpper(q.Status))
+		if !filter.Status.IsValid() {
+			errs.Add("status", apperrors.CodeInvalidStatus, "must be one of PENDING, RUNNING, CREATED, FAILED")
+		}
+	}
+
+	if q.After != "" {
+		after, err := strconv.Atoi(q.After)
+		if err != nil || after < 0 {
+			errs.Add("after", apperrors.CodeInvalidFormat, "must be a line number")
+		}
+		filter.AfterLine = after
+	}
+
+	if err := errs.Err(); err != nil {
+		return BatchRowsFilter{}, err
+	}
+	return filter, nil
+}
+
+// EncodeCursor renders a cursor as an opaque URL-safe token.
+func EncodeCursor(cursor models.TransferCursor) string {
+	raw := cursor.CreatedAt.Format(time.RFC3339Nano) + "|" + strconv.FormatUint(uint64(cursor.ID), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(token string) (models.TransferCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return models.TransferCursor{}, errInvalidCursor
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return models.TransferCursor{}, errInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return models.TransferCursor{}, errInvalidCursor
+	}
+	n, err := strconv.ParseUint(id, 10, 0)
+	if err != nil || n == 0 {
+		return models.TransferCursor{}, errInvalidCursor
+	}
+
+	return models.TransferCursor{CreatedAt: t, ID: uint(n)}, nil
+}
+
+func parseLimitParam(errs *apperrors.ValidationError, value string) int {
+	if value == "" {
+		return DefaultListLimit
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > MaxListLimit {
+		errs.Add("limit", apperrors.CodeInvalidFormat, fmt.Sprintf("must be an integer between 1 and %d", MaxListLimit))
+		return DefaultListLimit
+	}
+	return limit
+}
+
+func parseAmountParam(errs *apperrors.ValidationError, field, value string) *money.Amount {
+	if value == "" {
+		return nil
+	}
+	amount, err := money.Parse(value)
+	if err != nil {
+		errs.Add(field, apperrors.CodeInvalidAmount, err.Error())
+		return nil
+	}
+	return &amount
+}
+
+func parseTimeParam(errs *apperrors.ValidationError, field, value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		errs.Add(field, apperrors.CodeInvalidFormat, "must be an RFC 3339 timestamp")
+		return nil
+	}
+	return &t
+}
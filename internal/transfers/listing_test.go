@@ -0,0 +1,99 @@
+package transfers_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/enums"
+	"secure-payment-service/internal/models"
+	"secure-payment-service/internal/money"
+	"secure-payment-service/internal/transfers"
+)
+
+func TestListQuery_Filter_Defaults(t *testing.T) {
+	filter, err := transfers.ListQuery{}.Filter()
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.TransferFilter{AccountRole: enums.RoleAny, Limit: transfers.DefaultListLimit}, filter)
+}
+
+func TestListQuery_Filter_AllParameters(t *testing.T) {
+	cursor := models.TransferCursor{CreatedAt: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), ID: 42}
+
+	filter, err := transfers.ListQuery{
+		Account:     "acc-001",
+		Role:        "Source",
+		Status:      "completed, FAILED",
+		Currency:    "eur",
+		MinAmount:   "10",
+		MaxAmount:   "99.99",
+		CreatedFrom: "2024-01-01T00:00:00Z",
+		CreatedTo:   "2024-02-01T00:00:00Z",
+		Limit:       "20",
+		Cursor:      transfers.EncodeCursor(cursor),
+	}.Filter()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acc-001"}, filter.Accounts)
+	assert.Equal(t, enums.RoleSource, filter.AccountRole)
+	assert.Equal(t, []string{"COMPLETED", "FAILED"}, filter.Statuses)
+	assert.Equal(t, "EUR", filter.Currency)
+	assert.Equal(t, money.MustParse("10"), *filter.MinAmount)
+	assert.Equal(t, money.MustParse("99.99"), *filter.MaxAmount)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *filter.CreatedFrom)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), *filter.CreatedTo)
+	assert.Equal(t, 20, filter.Limit)
+	assert.True(t, cursor.CreatedAt.Equal(filter.After.CreatedAt))
+	assert.Equal(t, cursor.ID, filter.After.ID)
+}
+
+func TestListQuery_Filter_ReportsEveryInvalidParameter(t *testing.T) {
+	_, err := transfers.ListQuery{
+		Account:     "acc 001;",
+		Role:        "owner",
+		Status:      "DONE",
+		Currency:    "XYZ",
+		MinAmount:   "50",
+		MaxAmount:   "10",
+		CreatedFrom: "yesterday",
+		Limit:       "500",
+		Cursor:      "not-a-cursor",
+	}.Filter()
+
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+	var validationErr *apperrors.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+
+	fields := make([]string, 0, len(validationErr.Fields))
+	for _, f := range validationErr.Fields {
+		fields = append(fields, f.Field)
+	}
+	assert.Equal(t, []string{"account", "role", "status", "currency", "max_amount", "created_from", "limit", "cursor"}, fields)
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	cursor := models.TransferCursor{CreatedAt: time.Date(2024, 3, 1, 12, 0, 0, 123456000, time.UTC), ID: 7}
+
+	decoded, err := transfers.DecodeCursor(transfers.EncodeCursor(cursor))
+
+	assert.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, cursor.ID, decoded.ID)
+}
+
+func TestBatchRowsQuery_Filter(t *testing.T) {
+	filter, err := transfers.BatchRowsQuery{Status: "failed", After: "120", Limit: "10"}.Filter()
+
+	assert.NoError(t, err)
+	assert.Equal(t, transfers.BatchRowsFilter{Status: enums.BatchRowFailed, AfterLine: 120, Limit: 10}, filter)
+
+	_, err = transfers.BatchRowsQuery{Status: "DONE", After: "-1"}.Filter()
+
+	var validationErr *apperrors.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Len(t, validationErr.Fields, 2)
+}
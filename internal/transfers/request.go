@@ -1,13 +1,71 @@
 package transfers
 
+import (
+	"time"
+
+	"secure-payment-service/internal/money"
+)
+
 type TransferRequest struct {
-	FromAccount string  `json:"source_account_id"`
-	ToAccount   string  `json:"destination_account_id"`
-	Amount      float64 `json:"amount"`
-	Currency    string  `json:"currency"`
+	FromAccount string       `json:"source_account_id"`
+	ToAccount   string       `json:"destination_account_id"`
+	Amount      money.Amount `json:"amount"`
+	Currency    string       `json:"currency"`
+	// ExecuteAt, when set, schedules the transfer instead of running it now.
+	ExecuteAt *time.Time `json:"execute_at,omitempty"`
+}
+
+// TransferGroupRequest splits one payment from a source account into legs,
+// for example a marketplace sale paid to the seller, the platform and the tax
+// authority. Either every leg is created and settled or none is.
+type TransferGroupRequest struct {
+	FromAccount string               `json:"source_account_id"`
+	Currency    string               `json:"currency"`
+	Legs        []TransferLegRequest `json:"legs"`
+}
+
+type TransferLegRequest struct {
+	ToAccount string       `json:"destination_account_id"`
+	Amount    money.Amount `json:"amount"`
+}
+
+// StandingOrderRequest registers a recurring transfer. Either Frequency,
+// counted from StartAt, or Cron gives the schedule. StartAt defaults to now;
+// EndAt and MaxExecutions are optional limits.
+type StandingOrderRequest struct {
+	FromAccount   string       `json:"source_account_id"`
+	ToAccount     string       `json:"destination_account_id"`
+	Amount        money.Amount `json:"amount"`
+	Currency      string       `json:"currency"`
+	Frequency     string       `json:"frequency"`
+	Cron          string       `json:"cron"`
+	StartAt       *time.Time   `json:"start_at"`
+	EndAt         *time.Time   `json:"end_at"`
+	MaxExecutions int          `json:"max_executions"`
+}
+
+// ScheduledTransferUpdate edits a scheduled transfer. Omitted fields keep
+// their value.
+type ScheduledTransferUpdate struct {
+	ToAccount *string       `json:"destination_account_id"`
+	Amount    *money.Amount `json:"amount"`
+	ExecuteAt *time.Time    `json:"execute_at"`
+}
+
+// CancelRequest is the optional body of a cancellation.
+type CancelRequest struct {
+	Reason string `json:"reason"`
+}
+
+// RefundRequest gives back part or, when Amount is omitted, all of what is
+// left of a completed transfer.
+type RefundRequest struct {
+	Amount *money.Amount `json:"amount"`
+	Reason string        `json:"reason"`
 }
 
 type WebhookEvent struct {
 	ID     string `json:"transfer_id"`
 	Status string `json:"status"`
+	Reason string `json:"reason"`
 }
@@ -0,0 +1,254 @@
+package transfers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/money"
+	"secure-payment-service/internal/recurrence"
+)
+
+var accountIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+
+// MaxScheduleAhead is how far in the future a transfer may be scheduled.
+const MaxScheduleAhead = 366 * 24 * time.Hour
+
+// MinTransferLegs and MaxTransferLegs bound the legs of a multi-leg transfer.
+const (
+	MinTransferLegs = 2
+	MaxTransferLegs = 20
+)
+
+// Validate checks every field of the request and reports all problems at once
+// as an *apperrors.ValidationError.
+func (r TransferRequest) Validate() error {
+	var errs apperrors.ValidationError
+
+	validateMovement(&errs, r.FromAccount, r.ToAccount, r.Amount, r.Currency)
+	if r.ExecuteAt != nil {
+		validateFutureTime(&errs, "execute_at", *r.ExecuteAt)
+	}
+
+	return errs.Err()
+}
+
+// DecodeTransferRequest reads a TransferRequest from a JSON object. Fields
+// that cannot be decoded are reported together with what Validate finds in
+// the others, so one response lists every problem. Input that is not a JSON
+// object is returned as the decoding error.
+func DecodeTransferRequest(data []byte) (TransferRequest, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return TransferRequest{}, err
+	}
+
+	var req TransferRequest
+	var errs apperrors.ValidationError
+	decodeField(&errs, fields, "source_account_id", &req.FromAccount)
+	decodeField(&errs, fields, "destination_account_id", &req.ToAccount)
+	decodeField(&errs, fields, "amount", &req.Amount)
+	decodeField(&errs, fields, "currency", &req.Currency)
+	decodeField(&errs, fields, "execute_at", &req.ExecuteAt)
+
+	return req, validateParsed(&errs, req)
+}
+
+func decodeField(errs *apperrors.ValidationError, fields map[string]json.RawMessage, name string, target interface{}) {
+	raw, ok := fields[name]
+	if !ok {
+		return
+	}
+	err := json.Unmarshal(raw, target)
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case err == nil:
+	case errors.As(err, &typeErr):
+		errs.Add(name, apperrors.CodeInvalidType, "must be a "+typeErr.Type.String())
+	case errors.Is(err, money.ErrInvalidAmount):
+		errs.Add(name, apperrors.CodeInvalidAmount, err.Error())
+	default:
+		errs.Add(name, apperrors.CodeInvalidFormat, err.Error())
+	}
+}
+
+// validateParsed adds what Validate finds to the parse errors already in
+// errs, except for fields that could not be parsed in the first place.
+func validateParsed(errs *apperrors.ValidationError, req TransferRequest) error {
+	var invalid *apperrors.ValidationError
+	if !errors.As(req.Validate(), &invalid) {
+		return errs.Err()
+	}
+
+	reported := map[string]bool{}
+	for _, field := range errs.Fields {
+		reported[field.Field] = true
+	}
+	for _, field := range invalid.Fields {
+		if !reported[field.Field] {
+			errs.Add(field.Field, field.Code, field.Message)
+		}
+	}
+	return errs.Err()
+}
+
+// Validate checks every leg like TransferRequest.Validate. Problems with the
+// source account or currency are reported once; those of a leg are prefixed
+// with its position, as in legs[1].amount.
+func (r TransferGroupRequest) Validate() error {
+	var errs apperrors.ValidationError
+
+	switch {
+	case len(r.Legs) < MinTransferLegs:
+		errs.Add("legs", apperrors.CodeRequired, fmt.Sprintf("must have at least %d legs", MinTransferLegs))
+	case len(r.Legs) > MaxTransferLegs:
+		errs.Add("legs", apperrors.CodeTooManyLegs, fmt.Sprintf("must have at most %d legs", MaxTransferLegs))
+	}
+
+	legs := r.Legs
+	if len(legs) == 0 {
+		// Still check the source account and currency.
+		legs = []TransferLegRequest{{}}
+	}
+	for i, leg := range legs {
+		var legErrs apperrors.ValidationError
+		validateMovement(&legErrs, r.FromAccount, leg.ToAccount, leg.Amount, r.Currency)
+		for _, field := range legErrs.Fields {
+			switch {
+			case field.Field == "source_account_id" || field.Field == "currency":
+				if i == 0 {
+					errs.Add(field.Field, field.Code, field.Message)
+				}
+			case len(r.Legs) > 0:
+				errs.Add(fmt.Sprintf("legs[%d].%s", i, field.Field), field.Code, field.Message)
+			}
+		}
+	}
+
+	return errs.Err()
+}
+
+// Validate checks the transfer fields like TransferRequest.Validate and that
+// the request describes exactly one schedule that can fire.
+func (r StandingOrderRequest) Validate() error {
+	var errs apperrors.ValidationError
+
+	validateMovement(&errs, r.FromAccount, r.ToAccount, r.Amount, r.Currency)
+
+	switch {
+	case r.Frequency == "" && r.Cron == "":
+		errs.Add("frequency", apperrors.CodeRequired, "frequency or cron is required")
+	case r.Frequency != "" && r.Cron != "":
+		errs.Add("cron", apperrors.CodeInvalidFormat, "must not be combined with frequency")
+	case r.Frequency != "":
+		if !recurrence.Frequency(strings.ToUpper(r.Frequency)).IsValid() {
+			errs.Add("frequency", apperrors.CodeInvalidFormat, "must be one of DAILY, WEEKLY, MONTHLY")
+		}
+	default:
+		if _, err := recurrence.ParseCron(r.Cron, time.UTC); err != nil {
+			errs.Add("cron", apperrors.CodeInvalidFormat, err.Error())
+		}
+	}
+
+	// Unlike a one-off transfer, a standing order may start more than
+	// MaxScheduleAhead from now.
+	start := time.Now()
+	if r.StartAt != nil {
+		if !r.StartAt.After(start) {
+			errs.Add("start_at", apperrors.CodeMustBeFuture, "must be in the future")
+		}
+		start = *r.StartAt
+	}
+	if r.EndAt != nil && !r.EndAt.After(start) {
+		errs.Add("end_at", apperrors.CodeInvalidFormat, "must be after start_at")
+	}
+	if r.MaxExecutions < 0 {
+		errs.Add("max_executions", apperrors.CodeMustBePositive, "must be greater than zero")
+	}
+
+	return errs.Err()
+}
+
+// Validate checks the fields that are given. Amount precision and the
+// destination differing from the source depend on the stored transfer and are
+// checked by the service.
+func (r ScheduledTransferUpdate) Validate() error {
+	var errs apperrors.ValidationError
+
+	if r.ToAccount == nil && r.Amount == nil && r.ExecuteAt == nil {
+		errs.Add("body", apperrors.CodeRequired, "must change at least one of destination_account_id, amount or execute_at")
+	}
+	if r.ToAccount != nil {
+		validateAccountID(&errs, "destination_account_id", *r.ToAccount)
+	}
+	if r.Amount != nil && !r.Amount.IsPositive() {
+		errs.Add("amount", apperrors.CodeMustBePositive, "must be greater than zero")
+	}
+	if r.ExecuteAt != nil {
+		validateFutureTime(&errs, "execute_at", *r.ExecuteAt)
+	}
+
+	return errs.Err()
+}
+
+func validateMovement(errs *apperrors.ValidationError, from, to string, amount money.Amount, currency string) {
+	validateAccountID(errs, "source_account_id", from)
+	validateAccountID(errs, "destination_account_id", to)
+	if from != "" && from == to {
+		errs.Add("destination_account_id", apperrors.CodeSameAccount, "must differ from source_account_id")
+	}
+
+	upper := strings.ToUpper(currency)
+	validCurrency := false
+	switch {
+	case currency == "":
+		errs.Add("currency", apperrors.CodeRequired, "is required")
+	case !money.IsValidCurrency(upper):
+		errs.Add("currency", apperrors.CodeInvalidCurrency, fmt.Sprintf("'%s' is not an ISO 4217 currency code", currency))
+	default:
+		validCurrency = true
+	}
+
+	switch {
+	case !amount.IsPositive():
+		errs.Add("amount", apperrors.CodeMustBePositive, "must be greater than zero")
+	case validCurrency && !amount.FitsPrecision(upper):
+		places, _ := money.Precision(upper)
+		errs.Add("amount", apperrors.CodeTooPrecise, fmt.Sprintf("%s allows at most %d decimal places", upper, places))
+	}
+}
+
+func validateFutureTime(errs *apperrors.ValidationError, field string, t time.Time) {
+	now := time.Now()
+	switch {
+	case !t.After(now):
+		errs.Add(field, apperrors.CodeMustBeFuture, "must be in the future")
+	case t.After(now.Add(MaxScheduleAhead)):
+		errs.Add(field, apperrors.CodeTooFarAhead, "must be at most 366 days ahead")
+	}
+}
+
+// Validate checks the amount when one is given. Its precision depends on the
+// currency of the transfer being refunded and is checked by the service.
+func (r RefundRequest) Validate() error {
+	var errs apperrors.ValidationError
+
+	if r.Amount != nil && !r.Amount.IsPositive() {
+		errs.Add("amount", apperrors.CodeMustBePositive, "must be greater than zero")
+	}
+
+	return errs.Err()
+}
+
+func validateAccountID(errs *apperrors.ValidationError, field, value string) {
+	switch {
+	case value == "":
+		errs.Add(field, apperrors.CodeRequired, "is required")
+	case !accountIDPattern.MatchString(value):
+		errs.Add(field, apperrors.CodeInvalidFormat, "must be 1-64 letters, digits, '-' or '_'")
+	}
+}
@@ -0,0 +1,256 @@
+package transfers_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"secure-payment-service/internal/apperrors"
+	"secure-payment-service/internal/money"
+	"secure-payment-service/internal/transfers"
+)
+
+func validRequest() transfers.TransferRequest {
+	return transfers.TransferRequest{
+		FromAccount: "acc-001",
+		ToAccount:   "acc_002",
+		Amount:      money.MustParse("10.50"),
+		Currency:    "USD",
+	}
+}
+
+func TestTransferRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*transfers.TransferRequest)
+		expected []apperrors.FieldError
+	}{
+		{"valid", func(*transfers.TransferRequest) {}, nil},
+		{"lowercase_currency", func(r *transfers.TransferRequest) { r.Currency = "usd" }, nil},
+		{"zero_decimal_currency", func(r *transfers.TransferRequest) {
+			r.Currency = "JPY"
+			r.Amount = money.MustParse("1000")
+		}, nil},
+		{"missing_everything", func(r *transfers.TransferRequest) { *r = transfers.TransferRequest{} }, []apperrors.FieldError{
+			{Field: "source_account_id", Code: apperrors.CodeRequired, Message: "is required"},
+			{Field: "destination_account_id", Code: apperrors.CodeRequired, Message: "is required"},
+			{Field: "currency", Code: apperrors.CodeRequired, Message: "is required"},
+			{Field: "amount", Code: apperrors.CodeMustBePositive, Message: "must be greater than zero"},
+		}},
+		{"malformed_account", func(r *transfers.TransferRequest) { r.ToAccount = "acc 002;" }, []apperrors.FieldError{
+			{Field: "destination_account_id", Code: apperrors.CodeInvalidFormat, Message: "must be 1-64 letters, digits, '-' or '_'"},
+		}},
+		{"same_account", func(r *transfers.TransferRequest) { r.ToAccount = r.FromAccount }, []apperrors.FieldError{
+			{Field: "destination_account_id", Code: apperrors.CodeSameAccount, Message: "must differ from source_account_id"},
+		}},
+		{"negative_amount", func(r *transfers.TransferRequest) { r.Amount = money.MustParse("-1") }, []apperrors.FieldError{
+			{Field: "amount", Code: apperrors.CodeMustBePositive, Message: "must be greater than zero"},
+		}},
+		{"too_precise_for_currency", func(r *transfers.TransferRequest) {
+			r.Currency = "JPY"
+			r.Amount = money.MustParse("10.5")
+		}, []apperrors.FieldError{
+			{Field: "amount", Code: apperrors.CodeTooPrecise, Message: "JPY allows at most 0 decimal places"},
+		}},
+		{"unknown_currency", func(r *transfers.TransferRequest) { r.Currency = "ABC" }, []apperrors.FieldError{
+			{Field: "currency", Code: apperrors.CodeInvalidCurrency, Message: "'ABC' is not an ISO 4217 currency code"},
+		}},
+		{"scheduled", func(r *transfers.TransferRequest) {
+			executeAt := time.Now().Add(24 * time.Hour)
+			r.ExecuteAt = &executeAt
+		}, nil},
+		{"execute_at_in_past", func(r *transfers.TransferRequest) {
+			executeAt := time.Now().Add(-time.Minute)
+			r.ExecuteAt = &executeAt
+		}, []apperrors.FieldError{
+			{Field: "execute_at", Code: apperrors.CodeMustBeFuture, Message: "must be in the future"},
+		}},
+		{"execute_at_too_far_ahead", func(r *transfers.TransferRequest) {
+			executeAt := time.Now().Add(transfers.MaxScheduleAhead + time.Hour)
+			r.ExecuteAt = &executeAt
+		}, []apperrors.FieldError{
+			{Field: "execute_at", Code: apperrors.CodeTooFarAhead, Message: "must be at most 366 days ahead"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validRequest()
+			tt.modify(&req)
+
+			err := req.Validate()
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, apperrors.ErrValidation)
+			var validationErr *apperrors.ValidationError
+			assert.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tt.expected, validationErr.Fields)
+		})
+	}
+}
+
+func TestDecodeTransferRequest(t *testing.T) {
+	req, err := transfers.DecodeTransferRequest([]byte(`{"source_account_id": "acc-001", "destination_account_id": "acc_002", "amount": "10.50", "currency": "USD"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, validRequest(), req)
+
+	req, err = transfers.DecodeTransferRequest([]byte(`{"source_account_id": 7, "destination_account_id": "acc-001", "amount": "ten", "currency": "USD"}`))
+	var validationErr *apperrors.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "source_account_id", Code: apperrors.CodeInvalidType, Message: "must be a string"},
+		{Field: "amount", Code: apperrors.CodeInvalidAmount, Message: `invalid amount: "ten"`},
+	}, validationErr.Fields)
+	assert.Equal(t, "acc-001", req.ToAccount)
+
+	_, err = transfers.DecodeTransferRequest([]byte(`{"amount": 1`))
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &validationErr))
+}
+
+func TestOpenAccountRequest_Validate(t *testing.T) {
+	assert.NoError(t, transfers.OpenAccountRequest{Owner: "user-1"}.Validate())
+	assert.NoError(t, transfers.OpenAccountRequest{AccountID: "acc-001", Owner: "user-1", Currency: "eur"}.Validate())
+
+	metadata := map[string]string{}
+	for i := 0; i < 21; i++ {
+		metadata[string(rune('a'+i))] = "x"
+	}
+	err := transfers.OpenAccountRequest{AccountID: "acc 001", Currency: "ABC", Metadata: metadata}.Validate()
+
+	var validationErr *apperrors.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	fields := []string{}
+	for _, f := range validationErr.Fields {
+		fields = append(fields, f.Field)
+	}
+	assert.Equal(t, []string{"account_id", "owner", "currency", "metadata"}, fields)
+}
+
+func TestScheduledTransferUpdate_Validate(t *testing.T) {
+	executeAt := time.Now().Add(time.Hour)
+	assert.NoError(t, transfers.ScheduledTransferUpdate{ExecuteAt: &executeAt}.Validate())
+
+	payee, zero := "acc 002", money.MustParse("0")
+	err := transfers.ScheduledTransferUpdate{ToAccount: &payee, Amount: &zero}.Validate()
+	var validationErr *apperrors.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "destination_account_id", Code: apperrors.CodeInvalidFormat, Message: "must be 1-64 letters, digits, '-' or '_'"},
+		{Field: "amount", Code: apperrors.CodeMustBePositive, Message: "must be greater than zero"},
+	}, validationErr.Fields)
+
+	err = transfers.ScheduledTransferUpdate{}.Validate()
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "body", validationErr.Fields[0].Field)
+}
+
+func TestTransferGroupRequest_Validate(t *testing.T) {
+	valid := transfers.TransferGroupRequest{
+		FromAccount: "buyer",
+		Currency:    "usd",
+		Legs: []transfers.TransferLegRequest{
+			{ToAccount: "seller", Amount: money.MustParse("90")},
+			{ToAccount: "platform", Amount: money.MustParse("7.50")},
+			{ToAccount: "tax", Amount: money.MustParse("2.50")},
+		},
+	}
+	assert.NoError(t, valid.Validate())
+
+	invalid := valid
+	invalid.Currency = "ABC"
+	invalid.Legs = []transfers.TransferLegRequest{
+		{ToAccount: "buyer", Amount: money.MustParse("90")},
+		{ToAccount: "platform", Amount: money.MustParse("0")},
+	}
+	err := invalid.Validate()
+	var validationErr *apperrors.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "legs[0].destination_account_id", Code: apperrors.CodeSameAccount, Message: "must differ from source_account_id"},
+		{Field: "currency", Code: apperrors.CodeInvalidCurrency, Message: "'ABC' is not an ISO 4217 currency code"},
+		{Field: "legs[1].amount", Code: apperrors.CodeMustBePositive, Message: "must be greater than zero"},
+	}, validationErr.Fields)
+
+	err = transfers.TransferGroupRequest{FromAccount: "buyer"}.Validate()
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "legs", Code: apperrors.CodeRequired, Message: "must have at least 2 legs"},
+		{Field: "currency", Code: apperrors.CodeRequired, Message: "is required"},
+	}, validationErr.Fields)
+
+	tooMany := valid
+	tooMany.Legs = make([]transfers.TransferLegRequest, transfers.MaxTransferLegs+1)
+	for i := range tooMany.Legs {
+		tooMany.Legs[i] = valid.Legs[0]
+	}
+	err = tooMany.Validate()
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, apperrors.CodeTooManyLegs, validationErr.Fields[0].Code)
+}
+
+func TestStandingOrderRequest_Validate(t *testing.T) {
+	valid := transfers.StandingOrderRequest{FromAccount: "acc-001", ToAccount: "acc-002", Amount: money.MustParse("10"), Currency: "USD", Frequency: "monthly"}
+	assert.NoError(t, valid.Validate())
+
+	withCron := valid
+	withCron.Frequency = ""
+	withCron.Cron = "0 9 * * 1-5"
+	assert.NoError(t, withCron.Validate())
+
+	past := time.Now().Add(-time.Hour)
+	end := time.Now().Add(-2 * time.Hour)
+	invalid := transfers.StandingOrderRequest{
+		FromAccount:   "acc-001",
+		ToAccount:     "acc-001",
+		Amount:        money.MustParse("10"),
+		Currency:      "USD",
+		Frequency:     "DAILY",
+		Cron:          "0 9 * * *",
+		StartAt:       &past,
+		EndAt:         &end,
+		MaxExecutions: -1,
+	}
+	err := invalid.Validate()
+	var validationErr *apperrors.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	fields := []string{}
+	for _, f := range validationErr.Fields {
+		fields = append(fields, f.Field)
+	}
+	assert.Equal(t, []string{"destination_account_id", "cron", "start_at", "end_at", "max_executions"}, fields)
+
+	farStart := time.Now().Add(2 * transfers.MaxScheduleAhead)
+	farEnd := farStart.Add(30 * 24 * time.Hour)
+	startsLater := valid
+	startsLater.StartAt = &farStart
+	startsLater.EndAt = &farEnd
+	assert.NoError(t, startsLater.Validate())
+
+	endsBeforeStart := startsLater
+	endsBeforeStart.EndAt = &past
+	assert.ErrorIs(t, endsBeforeStart.Validate(), apperrors.ErrValidation)
+
+	noSchedule := valid
+	noSchedule.Frequency = ""
+	assert.ErrorIs(t, noSchedule.Validate(), apperrors.ErrValidation)
+
+	badCron := withCron
+	badCron.Cron = "0 25 * * *"
+	assert.ErrorIs(t, badCron.Validate(), apperrors.ErrValidation)
+}
+
+func TestRefundRequest_Validate(t *testing.T) {
+	assert.NoError(t, transfers.RefundRequest{}.Validate())
+
+	partial := money.MustParse("5")
+	assert.NoError(t, transfers.RefundRequest{Amount: &partial}.Validate())
+
+	zero := money.MustParse("0")
+	assert.ErrorIs(t, transfers.RefundRequest{Amount: &zero}.Validate(), apperrors.ErrValidation)
+}